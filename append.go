@@ -0,0 +1,195 @@
+package dino
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"sync/atomic"
+)
+
+// ErrAmbiguousBinding is returned when a single value is resolved for a type appended more than once.
+var ErrAmbiguousBinding = errors.New("ambiguous binding")
+
+// appendedBinding is the qualifier of the registry key a value or factory function appended for a type is
+// stored under. Bindings are numbered from 1 in append order within the container or scope, at the depth
+// of its scope, so that bindings appended to a scope never shadow those of the container.
+type appendedBinding struct {
+	depth int
+	index int64
+}
+
+// GoString names the binding in resolution paths and dumps by its position among the bindings of its type.
+func (b appendedBinding) GoString() string {
+	return fmt.Sprintf("appended #%d", b.index)
+}
+
+// Append registers a factory function returning a single value, optionally followed by an error, as one
+// more binding of its result type instead of replacing the bindings appended before. ResolveAll, and
+// function parameters or struct fields of the slice type, resolve every binding in append order; resolving
+// a single value fails with ErrAmbiguousBinding once more than one binding is appended. A dependency
+// registered for the type with Singleton or Factory takes precedence over the appended ones.
+// Appended bindings are stored in the registry like any other registration, under a qualifier of their own.
+func (d *Dino) Append(fn any) error {
+	rv := reflect.ValueOf(fn)
+
+	if isNil(rv) {
+		return fmt.Errorf("%w: appended function cannot be nil", ErrInvalidInputValue)
+	}
+
+	rt := rv.Type()
+
+	if !isFunction(rt) {
+		return fmt.Errorf("%w: append expected a function, got %v", ErrInvalidInputValue, rt.Kind())
+	}
+
+	if rt.NumOut() == 0 || isError(rt.Out(0)) || rt.NumOut() > 2 || (rt.NumOut() == 2 && !isError(rt.Out(1))) {
+		return fmt.Errorf("%w: appended function %s must return a single value, optionally followed by an error",
+			ErrInvalidInputValue, rt)
+	}
+
+	return d.factory(fn, nil, Qualify(d.options.nextAppended(rt.Out(0))))
+}
+
+// AppendValue registers an instance as one more binding of its dynamic type, like Append does
+// for the results of factory functions.
+func (d *Dino) AppendValue(val any) error {
+	rv := reflect.ValueOf(val)
+
+	if isNil(rv) {
+		return fmt.Errorf("%w: appended value cannot be nil", ErrInvalidInputValue)
+	}
+
+	keys := taggedKeys(rv.Type(), d.options.nextAppended(rv.Type()), nil)
+
+	if _, err := d.singleton(keys, rv); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ResolveAll returns every dependency of type T registered with Append or AppendValue, in append order,
+// calling the appended factory functions that were not called yet. Nothing appended returns an empty slice.
+func ResolveAll[T any](d *Dino) ([]T, error) {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	keys := appendedKeys(d.registry, reflect.TypeFor[T]())
+
+	values, err := newInjector(d.registry, d.options).resolveAppended(keys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve dependency: %w", err)
+	}
+
+	all := make([]T, 0, len(values))
+
+	for _, val := range values {
+		// A nil interface value converts to the zero T
+		typed, _ := val.Interface().(T)
+		all = append(all, typed)
+	}
+
+	return all, nil
+}
+
+// nextAppended returns the qualifier of the next binding appended for the type to the container.
+func (o *options) nextAppended(rt reflect.Type) appendedBinding {
+	value, _ := o.appended.LoadOrStore(rt, new(atomic.Int64))
+	count, _ := value.(*atomic.Int64)
+
+	depth := 0
+	for parent := o.parent; parent != nil; parent = parent.parent {
+		depth++
+	}
+
+	return appendedBinding{
+		depth: depth,
+		index: count.Add(1),
+	}
+}
+
+// appendedKeys returns the keys of the bindings appended for the type and visible from the registry,
+// those of the container first, each in append order.
+func appendedKeys(registry Registry, rt reflect.Type) []RegistryKey {
+	keys := slices.DeleteFunc(registry.FindByType(rt), func(key RegistryKey) bool {
+		_, ok := key.Qualifier.(appendedBinding)
+
+		return !ok || key.Tag != ""
+	})
+
+	slices.SortFunc(keys, func(a, b RegistryKey) int {
+		bindingA, _ := a.Qualifier.(appendedBinding)
+		bindingB, _ := b.Qualifier.(appendedBinding)
+
+		return cmp.Or(cmp.Compare(bindingA.depth, bindingB.depth), cmp.Compare(bindingA.index, bindingB.index))
+	})
+
+	return keys
+}
+
+// lookupAppended resolves an untagged key from the appended bindings: a slice of the appended type
+// resolves every binding, the appended type itself its only binding. It returns false if nothing
+// was appended for the key.
+func (i *Injector) lookupAppended(key RegistryKey) (reflect.Value, bool, error) {
+	if key.Tag != "" || key.Qualifier != nil {
+		return reflect.Value{}, false, nil
+	}
+
+	if keys := appendedKeys(i.registry, key.Type); len(keys) > 0 {
+		if len(keys) > 1 {
+			return reflect.Value{}, true, ambiguous(key, len(keys))
+		}
+
+		rv, err := i.resolve(keys[0])
+
+		return rv, true, err
+	}
+
+	if key.Type.Kind() != reflect.Slice {
+		return reflect.Value{}, false, nil
+	}
+
+	keys := appendedKeys(i.registry, key.Type.Elem())
+	if len(keys) == 0 {
+		return reflect.Value{}, false, nil
+	}
+
+	values, err := i.resolveAppended(keys)
+	if err != nil {
+		return reflect.Value{}, true, err
+	}
+
+	all := reflect.MakeSlice(key.Type, len(values), len(values))
+
+	for idx, val := range values {
+		all.Index(idx).Set(val)
+	}
+
+	return all, true, nil
+}
+
+// ambiguous returns the ErrAmbiguousBinding error of resolving a single value for the key, which has
+// the count of bindings appended.
+func ambiguous(key RegistryKey, count int) error {
+	return fmt.Errorf("%w: %d bindings appended for %s, resolve them as %s",
+		ErrAmbiguousBinding, count, key.Type, reflect.SliceOf(key.Type))
+}
+
+// resolveAppended resolves the appended bindings of the keys in order, stopping at the first failure.
+// Like registered factories, appended factory functions are called once and their results cached.
+func (i *Injector) resolveAppended(keys []RegistryKey) ([]reflect.Value, error) {
+	values := make([]reflect.Value, 0, len(keys))
+
+	for _, key := range keys {
+		rv, err := i.resolve(key)
+		if err != nil {
+			return nil, err
+		}
+
+		values = append(values, rv)
+	}
+
+	return values, nil
+}
@@ -0,0 +1,252 @@
+package dino_test
+
+import (
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/yuppyweb/dino"
+)
+
+var errAppendFailed = errors.New("append failed")
+
+type AppendedHandler struct {
+	Name string
+}
+
+type AppendedBus struct {
+	Handlers []*AppendedHandler
+}
+
+func appendedNames(handlers []*AppendedHandler) string {
+	names := make([]string, 0, len(handlers))
+
+	for _, handler := range handlers {
+		names = append(names, handler.Name)
+	}
+
+	return strings.Join(names, ",")
+}
+
+func TestAppend_Order(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	di := dino.New()
+
+	for _, name := range []string{"audit", "metrics", "mailer"} {
+		if err := di.Append(func() *AppendedHandler {
+			calls.Add(1)
+
+			return &AppendedHandler{Name: name}
+		}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	handlers, err := dino.ResolveAll[*AppendedHandler](di)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if names := appendedNames(handlers); names != "audit,metrics,mailer" {
+		t.Errorf("expected handlers in append order, got %s", names)
+	}
+
+	// Factory results are kept like those of registered factories
+	again, err := dino.ResolveAll[*AppendedHandler](di)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if again[0] != handlers[0] || calls.Load() != 3 {
+		t.Errorf("expected each factory to be called once, got %d calls", calls.Load())
+	}
+}
+
+func TestAppend_MixedFactoriesAndValues(t *testing.T) {
+	t.Parallel()
+
+	di := dino.New()
+
+	if err := di.AppendValue(&AppendedHandler{Name: "first"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := di.Append(func() (*AppendedHandler, error) {
+		return &AppendedHandler{Name: "second"}, nil
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := di.AppendValue(&AppendedHandler{Name: "third"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Slice parameters and fields resolve every binding
+	results, err := di.Invoke(func(handlers []*AppendedHandler) string {
+		return appendedNames(handlers)
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if results[0] != "first,second,third" {
+		t.Errorf("expected every binding as parameter, got %v", results[0])
+	}
+
+	var bus AppendedBus
+
+	if err := di.Inject(&bus); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if names := appendedNames(bus.Handlers); names != "first,second,third" {
+		t.Errorf("expected every binding as field, got %s", names)
+	}
+}
+
+func TestAppend_Ambiguous(t *testing.T) {
+	t.Parallel()
+
+	di := dino.New()
+
+	if err := di.AppendValue(&AppendedHandler{Name: "only"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// A single binding resolves as a single value
+	handler, err := dino.Resolve[*AppendedHandler](di)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if handler.Name != "only" {
+		t.Errorf("expected the only binding, got %s", handler.Name)
+	}
+
+	for range 2 {
+		if err := di.Append(func() *AppendedHandler {
+			return &AppendedHandler{Name: "more"}
+		}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	_, err = dino.Resolve[*AppendedHandler](di)
+	if !errors.Is(err, dino.ErrAmbiguousBinding) {
+		t.Fatalf("expected ErrAmbiguousBinding, got %v", err)
+	}
+
+	if !strings.Contains(err.Error(), "3 bindings") {
+		t.Errorf("expected the error to list the count, got %v", err)
+	}
+
+	// A registered dependency takes precedence over the appended ones
+	if err := di.Singleton(&AppendedHandler{Name: "registered"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	handler, err = dino.Resolve[*AppendedHandler](di)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if handler.Name != "registered" {
+		t.Errorf("expected the registered dependency, got %s", handler.Name)
+	}
+}
+
+func TestAppend_Errors(t *testing.T) {
+	t.Parallel()
+
+	di := dino.New()
+
+	testCases := []struct {
+		name string
+		fn   any
+	}{
+		{name: "Nil function", fn: nil},
+		{name: "Not a function", fn: 42},
+		{name: "No results", fn: func() {}},
+		{name: "Only an error", fn: func() error { return nil }},
+		{name: "Two values", fn: func() (*AppendedHandler, *AppendedBus) { return nil, nil }},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if err := di.Append(tc.fn); !errors.Is(err, dino.ErrInvalidInputValue) {
+				t.Errorf("expected ErrInvalidInputValue, got %v", err)
+			}
+		})
+	}
+
+	failing := dino.New()
+
+	if err := failing.Append(func() (*AppendedHandler, error) {
+		return nil, errAppendFailed
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := dino.ResolveAll[*AppendedHandler](failing); !errors.Is(err, dino.ErrFactoryFailed) ||
+		!errors.Is(err, errAppendFailed) {
+		t.Errorf("expected the factory error, got %v", err)
+	}
+
+	frozen := dino.New()
+	frozen.Freeze()
+
+	if err := frozen.AppendValue(&AppendedHandler{Name: "late"}); !errors.Is(err, dino.ErrContainerFrozen) {
+		t.Errorf("expected ErrContainerFrozen, got %v", err)
+	}
+}
+
+func TestAppend_SnapshotAndDump(t *testing.T) {
+	t.Parallel()
+
+	di := dino.New()
+
+	if err := di.AppendValue(&AppendedHandler{Name: "audit"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	snapshot := di.Snapshot()
+
+	if err := di.Append(func() *AppendedHandler { return &AppendedHandler{Name: "metrics"} }); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var out strings.Builder
+	if err := di.Dump(&out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := strings.Join([]string{
+		`*dino_test.AppendedHandler tag="" qualifier=appended #1 instance *dino_test.AppendedHandler`,
+		`*dino_test.AppendedHandler tag="" qualifier=appended #2 factory func() *dino_test.AppendedHandler`,
+		"",
+	}, "\n")
+
+	if out.String() != expected {
+		t.Fatalf("expected dump:\n%s\ngot:\n%s", expected, out.String())
+	}
+
+	// Appended bindings are registry entries, restoring the snapshot drops the later one
+	if err := di.Restore(snapshot); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	handlers, err := dino.ResolveAll[*AppendedHandler](di)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if names := appendedNames(handlers); names != "audit" {
+		t.Errorf("expected the bindings of the snapshot, got %s", names)
+	}
+}
@@ -0,0 +1,123 @@
+package dino
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"sync"
+)
+
+// maxPooledArity is the largest number of function parameters whose argument slices are pooled.
+const maxPooledArity = 8
+
+// argPools keeps argument slices for function calls, one pool per arity.
+var argPools [maxPooledArity + 1]sync.Pool
+
+// acquireArgs returns an argument slice of length n, reused from the pool when possible.
+// The slice must be handed back with releaseArgs once the function has been called.
+func acquireArgs(n int) *[]reflect.Value {
+	if n > maxPooledArity {
+		args := make([]reflect.Value, n)
+
+		return &args
+	}
+
+	if args, ok := argPools[n].Get().(*[]reflect.Value); ok {
+		return args
+	}
+
+	args := make([]reflect.Value, n)
+
+	return &args
+}
+
+// releaseArgs clears the argument slice so that it does not keep resolved values alive and returns it to the pool.
+func releaseArgs(args *[]reflect.Value) {
+	n := len(*args)
+	if n > maxPooledArity {
+		return
+	}
+
+	clear(*args)
+	argPools[n].Put(args)
+}
+
+// ParallelArgs makes function argument preparation resolve up to n parameters concurrently, e.g. for
+// a factory taking several connections that are dialled by their own factories. Every parameter is
+// resolved with its own copy of the resolution state, so circular dependencies are still detected.
+// Failures are reported in parameter order: the first one, or all of them with AggregateErrors.
+func ParallelArgs(n int) Option {
+	return func(o *options) {
+		o.parallelArgs = n
+	}
+}
+
+// prepareParallel resolves the arguments of the function type into args, up to the configured number
+// of parameters at a time.
+func (i *Injector) prepareParallel(fn reflect.Type, args []reflect.Value) error {
+	var wg sync.WaitGroup
+
+	errs := make([]error, fn.NumIn())
+	slots := make(chan struct{}, i.options.parallelArgs)
+
+	for idx := range fn.NumIn() {
+		branch := i.branch()
+
+		wg.Go(func() {
+			slots <- struct{}{}
+			defer func() { <-slots }()
+
+			rv, err := branch.prepareArg(fn.In(idx))
+			if err != nil {
+				errs[idx] = err
+
+				return
+			}
+
+			args[idx] = rv
+		})
+	}
+
+	wg.Wait()
+
+	for idx, err := range errs {
+		if err == nil {
+			continue
+		}
+
+		if !i.options.aggregateErrors {
+			return err
+		}
+
+		errs[idx] = fmt.Errorf("parameter %d of type %s: %w", idx, fn.In(idx), err)
+	}
+
+	return errors.Join(errs...)
+}
+
+// branch returns an injector resolving a single argument of the current call with a copy of its resolution state.
+func (i *Injector) branch() *Injector {
+	return &Injector{
+		registry: i.registry,
+		options:  i.options,
+		stack:    maps.Clone(i.stack),
+		creating: maps.Clone(i.creating),
+		path:     slices.Clone(i.path),
+		caller:   i,
+		report:   nil,
+		dryRun:   i.dryRun,
+	}
+}
+
+// within reports whether the injector is the other one or resolves arguments for it, directly or not.
+func (i *Injector) within(other *Injector) bool {
+	for call := i; call != nil; call = call.caller {
+		if call == other {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,214 @@
+package dino_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/yuppyweb/dino"
+)
+
+const dialDelay = 50 * time.Millisecond
+
+var (
+	errDatabaseDown = errors.New("database down")
+	errQueueDown    = errors.New("queue down")
+)
+
+type ParallelConfig struct{}
+
+type ParallelDatabase struct{}
+
+type ParallelCache struct{}
+
+type ParallelQueue struct{}
+
+type ParallelApp struct {
+	Database *ParallelDatabase
+	Cache    *ParallelCache
+	Queue    *ParallelQueue
+}
+
+func newParallelContainer(t *testing.T, configCalls *atomic.Int32, opts ...dino.Option) *dino.Dino {
+	t.Helper()
+
+	di := dino.New(opts...)
+
+	factories := []any{
+		func() *ParallelConfig {
+			configCalls.Add(1)
+
+			return &ParallelConfig{}
+		},
+		func(*ParallelConfig) *ParallelDatabase {
+			time.Sleep(dialDelay)
+
+			return &ParallelDatabase{}
+		},
+		func(*ParallelConfig) *ParallelCache {
+			time.Sleep(dialDelay)
+
+			return &ParallelCache{}
+		},
+		func(*ParallelConfig) *ParallelQueue {
+			time.Sleep(dialDelay)
+
+			return &ParallelQueue{}
+		},
+		func(db *ParallelDatabase, cache *ParallelCache, queue *ParallelQueue) *ParallelApp {
+			return &ParallelApp{Database: db, Cache: cache, Queue: queue}
+		},
+	}
+
+	for _, factory := range factories {
+		if err := di.Factory(factory); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	return di
+}
+
+func TestParallelArgs(t *testing.T) {
+	t.Parallel()
+
+	var configCalls atomic.Int32
+
+	di := newParallelContainer(t, &configCalls, dino.ParallelArgs(3))
+
+	start := time.Now()
+
+	app, err := dino.Resolve[*ParallelApp](di)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The three connections are dialled side by side
+	if elapsed := time.Since(start); elapsed >= 2*dialDelay {
+		t.Fatalf("expected the app to take about a single dial of %v, took %v", dialDelay, elapsed)
+	}
+
+	if app.Database == nil || app.Cache == nil || app.Queue == nil {
+		t.Fatalf("expected every argument to be resolved, got %+v", app)
+	}
+
+	if got := configCalls.Load(); got != 1 {
+		t.Fatalf("expected the shared config factory to be called once, got %d calls", got)
+	}
+}
+
+func TestParallelArgs_Limit(t *testing.T) {
+	t.Parallel()
+
+	var configCalls atomic.Int32
+
+	di := newParallelContainer(t, &configCalls, dino.ParallelArgs(2))
+
+	start := time.Now()
+
+	if _, err := dino.Resolve[*ParallelApp](di); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed < 2*dialDelay {
+		t.Fatalf("expected at most two dials at a time, took %v", elapsed)
+	}
+}
+
+func TestParallelArgs_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		opts []dino.Option
+		errs []error
+	}{
+		{
+			name: "first failure",
+			opts: []dino.Option{dino.ParallelArgs(3)},
+			errs: []error{errDatabaseDown},
+		},
+		{
+			name: "aggregated failures",
+			opts: []dino.Option{dino.ParallelArgs(3), dino.AggregateErrors()},
+			errs: []error{errDatabaseDown, errQueueDown},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			di := dino.New(tt.opts...)
+
+			factories := []any{
+				func() (*ParallelDatabase, error) {
+					// Fails last, yet is reported first
+					time.Sleep(dialDelay)
+
+					return nil, errDatabaseDown
+				},
+				func() *ParallelCache { return &ParallelCache{} },
+				func() (*ParallelQueue, error) { return nil, errQueueDown },
+			}
+
+			for _, factory := range factories {
+				if err := di.Factory(factory); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			}
+
+			_, err := di.Invoke(func(*ParallelDatabase, *ParallelCache, *ParallelQueue) {
+				t.Fatal("expected the function not to be called")
+			})
+
+			for _, want := range tt.errs {
+				if !errors.Is(err, want) {
+					t.Fatalf("expected %v, got %v", want, err)
+				}
+			}
+
+			if len(tt.errs) == 1 && errors.Is(err, errQueueDown) {
+				t.Fatalf("expected only the first failure, got %v", err)
+			}
+
+			if len(tt.errs) > 1 {
+				msg := err.Error()
+				if strings.Index(msg, "parameter 0") > strings.Index(msg, "parameter 2") {
+					t.Fatalf("expected failures in parameter order, got %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestParallelArgs_CircularDependency(t *testing.T) {
+	t.Parallel()
+
+	di := dino.New(dino.ParallelArgs(2))
+
+	factories := []any{
+		func(*ParallelDatabase, *ParallelCache) *ParallelApp { return &ParallelApp{} },
+		func() *ParallelDatabase { return &ParallelDatabase{} },
+		func(*ParallelApp) *ParallelCache { return &ParallelCache{} },
+	}
+
+	for _, factory := range factories {
+		if err := di.Factory(factory); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	_, err := di.Resolve(reflect.TypeFor[*ParallelApp]())
+	if !errors.Is(err, dino.ErrCircularDependency) {
+		t.Fatalf("expected ErrCircularDependency, got %v", err)
+	}
+
+	var resErr *dino.ResolutionError
+	if !errors.As(err, &resErr) || !strings.Contains(resErr.Error(), "*dino_test.ParallelCache") {
+		t.Fatalf("expected the cycle to go through the cache, got %v", err)
+	}
+}
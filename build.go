@@ -0,0 +1,97 @@
+package dino
+
+import (
+	"errors"
+	"fmt"
+)
+
+// WithBuildParallelism lets Build call up to n factories concurrently. Factories are started once the
+// factories they depend on are built, so independent subgraphs, e.g. connections to separate services,
+// are built side by side. If a factory fails, the factories depending on it are not called while
+// independent ones are still built, and the failures are returned together. Build calls one factory
+// at a time by default.
+func WithBuildParallelism(n int) Option {
+	return func(o *options) {
+		o.buildParallelism = n
+	}
+}
+
+// buildResult is the outcome of building the factory at index idx of the keys being built.
+type buildResult struct {
+	idx int
+	err error
+}
+
+// buildParallel resolves the factories registered for the keys, up to limit at a time, each one
+// once the keys it depends on are built. The dependencies of a factory must not form a cycle.
+func (d *Dino) buildParallel(keys []RegistryKey, limit int) error {
+	if _, err := topoOrder(keys, d.options.dependencies); err != nil {
+		return fmt.Errorf("failed to build: %w", err)
+	}
+
+	index := make(map[RegistryKey]int, len(keys))
+	for idx, key := range keys {
+		index[key] = idx
+	}
+
+	waiting := make([]int, len(keys))
+	dependents := make([][]int, len(keys))
+
+	for idx, key := range keys {
+		for _, dep := range d.options.dependencies(key) {
+			if pos, ok := index[dep]; ok && pos != idx {
+				waiting[idx]++
+				dependents[pos] = append(dependents[pos], idx)
+			}
+		}
+	}
+
+	var ready []int
+
+	for idx := range keys {
+		if waiting[idx] == 0 {
+			ready = append(ready, idx)
+		}
+	}
+
+	results := make(chan buildResult)
+	errs := make([]error, len(keys))
+	running := 0
+
+	for len(ready) > 0 || running > 0 {
+		for len(ready) > 0 && running < limit {
+			idx := ready[0]
+			ready = ready[1:]
+			running++
+
+			go func() {
+				_, err := newInjector(d.registry, d.options).resolve(keys[idx])
+
+				results <- buildResult{idx: idx, err: err}
+			}()
+		}
+
+		res := <-results
+		running--
+
+		key := keys[res.idx]
+
+		// The dependents of a failed factory are never started
+		if res.err != nil {
+			errs[res.idx] = fmt.Errorf("failed to build dependency %s with tag '%s': %w", key.Type, key.Tag, res.err)
+
+			continue
+		}
+
+		d.options.stats.build(key)
+
+		for _, dependent := range dependents[res.idx] {
+			waiting[dependent]--
+			if waiting[dependent] == 0 {
+				ready = append(ready, dependent)
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
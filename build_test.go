@@ -0,0 +1,203 @@
+package dino_test
+
+import (
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/yuppyweb/dino"
+)
+
+const buildDelay = 50 * time.Millisecond
+
+var errDialFailed = errors.New("dial failed")
+
+type BuildDatabase struct{}
+
+type BuildCache struct{}
+
+type BuildQueue struct{}
+
+type BuildApp struct {
+	Database *BuildDatabase
+	Cache    *BuildCache
+	Queue    *BuildQueue
+}
+
+type BuildWorker struct{}
+
+func newBuildContainer(t *testing.T, calls *atomic.Int32, opts ...dino.Option) *dino.Dino {
+	t.Helper()
+
+	di := dino.New(opts...)
+
+	factories := []any{
+		func() *BuildDatabase {
+			calls.Add(1)
+			time.Sleep(buildDelay)
+
+			return &BuildDatabase{}
+		},
+		func() *BuildCache {
+			calls.Add(1)
+			time.Sleep(buildDelay)
+
+			return &BuildCache{}
+		},
+		func() *BuildQueue {
+			calls.Add(1)
+			time.Sleep(buildDelay)
+
+			return &BuildQueue{}
+		},
+		func(db *BuildDatabase, cache *BuildCache, queue *BuildQueue) *BuildApp {
+			calls.Add(1)
+			time.Sleep(buildDelay)
+
+			return &BuildApp{Database: db, Cache: cache, Queue: queue}
+		},
+		func(*BuildDatabase) *BuildWorker {
+			calls.Add(1)
+
+			return &BuildWorker{}
+		},
+	}
+
+	for _, factory := range factories {
+		if err := di.Factory(factory); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	return di
+}
+
+func TestDino_BuildParallel(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	di := newBuildContainer(t, &calls, dino.WithBuildParallelism(4))
+
+	start := time.Now()
+
+	if err := di.Build(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The three connections are dialled side by side, only the app waits for them
+	if elapsed := time.Since(start); elapsed >= 3*buildDelay {
+		t.Fatalf("expected build to take about the critical path of %v, took %v", 2*buildDelay, elapsed)
+	}
+
+	if got := calls.Load(); got != 5 {
+		t.Fatalf("expected every factory to be called once, got %d calls", got)
+	}
+
+	var app *BuildApp
+
+	if _, err := di.Invoke(func(built *BuildApp, db *BuildDatabase) {
+		app = built
+
+		if built.Database != db {
+			t.Fatal("expected the app to get the built database")
+		}
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if app == nil || calls.Load() != 5 {
+		t.Fatalf("expected the built instances to be cached, got %d calls", calls.Load())
+	}
+}
+
+func TestDino_BuildSequentialByDefault(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	di := newBuildContainer(t, &calls)
+
+	start := time.Now()
+
+	if err := di.Build(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed < 4*buildDelay {
+		t.Fatalf("expected factories to be called one at a time, took %v", elapsed)
+	}
+
+	if got := calls.Load(); got != 5 {
+		t.Fatalf("expected every factory to be called once, got %d calls", got)
+	}
+}
+
+func TestDino_BuildParallelFailure(t *testing.T) {
+	t.Parallel()
+
+	var appCalls, cacheCalls atomic.Int32
+
+	di := dino.New(dino.WithBuildParallelism(4))
+
+	factories := []any{
+		func() (*BuildDatabase, error) {
+			return nil, errDialFailed
+		},
+		func() *BuildCache {
+			cacheCalls.Add(1)
+			time.Sleep(buildDelay)
+
+			return &BuildCache{}
+		},
+		func(db *BuildDatabase, cache *BuildCache) *BuildApp {
+			appCalls.Add(1)
+
+			return &BuildApp{Database: db, Cache: cache, Queue: nil}
+		},
+	}
+
+	for _, factory := range factories {
+		if err := di.Factory(factory); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	err := di.Build()
+	if !errors.Is(err, errDialFailed) {
+		t.Fatalf("expected the factory error, got %v", err)
+	}
+
+	if !strings.Contains(err.Error(), "failed to build dependency *dino_test.BuildDatabase") {
+		t.Fatalf("expected the error to name the failed dependency, got %v", err)
+	}
+
+	if got := appCalls.Load(); got != 0 {
+		t.Fatalf("expected the dependent factory not to be called, got %d calls", got)
+	}
+
+	// The independent branch in flight is still built
+	if got := cacheCalls.Load(); got != 1 {
+		t.Fatalf("expected the independent factory to be called once, got %d calls", got)
+	}
+}
+
+func TestDino_BuildParallelCycle(t *testing.T) {
+	t.Parallel()
+
+	di := dino.New(dino.WithBuildParallelism(4))
+
+	if err := di.Factory(func(*BuildCache) *BuildDatabase { return &BuildDatabase{} }); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := di.Factory(func(*BuildDatabase) *BuildCache { return &BuildCache{} }); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := di.Build(); !errors.Is(err, dino.ErrCircularDependency) {
+		t.Fatalf("expected ErrCircularDependency, got %v", err)
+	}
+}
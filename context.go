@@ -0,0 +1,48 @@
+package dino
+
+import (
+	"context"
+	"fmt"
+)
+
+// containerContextKey is the context key under which NewContext stores a container.
+type containerContextKey struct{}
+
+// NewContext returns a copy of the context carrying the container, typically a scope, for FromContext.
+// It lets code that is only handed a context, e.g. framework callbacks, reach the container of the
+// request. A container stored in a derived context takes precedence over one stored in its parent.
+func NewContext(ctx context.Context, d *Dino) context.Context {
+	return context.WithValue(ctx, containerContextKey{}, d)
+}
+
+// FromContext returns the container stored in the context by NewContext, Middleware or the grpcdino
+// interceptors, if any.
+func FromContext(ctx context.Context) (*Dino, bool) {
+	d, ok := ctx.Value(containerContextKey{}).(*Dino)
+
+	return d, ok && d != nil
+}
+
+// MustFromContext is like FromContext but panics if the context carries no container.
+func MustFromContext(ctx context.Context) *Dino {
+	d, ok := FromContext(ctx)
+	if !ok {
+		panic(fmt.Errorf(
+			"%w: no container in context, store one with dino.NewContext or dino.Dino.Middleware",
+			ErrValueNotFound,
+		))
+	}
+
+	return d
+}
+
+// InvokeFromContext calls the function like Invoke on the container stored in the context.
+// It fails with ErrValueNotFound if the context carries no container.
+func InvokeFromContext(ctx context.Context, fn any) ([]any, error) {
+	d, ok := FromContext(ctx)
+	if !ok {
+		return nil, fmt.Errorf("%w: no container in context", ErrValueNotFound)
+	}
+
+	return d.Invoke(fn)
+}
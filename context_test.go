@@ -0,0 +1,90 @@
+package dino_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/yuppyweb/dino"
+)
+
+type ContextGreeter struct {
+	Greeting string
+}
+
+func TestNewContext(t *testing.T) {
+	t.Parallel()
+
+	di := dino.New()
+
+	if err := di.Singleton(&ContextGreeter{Greeting: "hello"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	scope := di.Scope()
+
+	if err := scope.Singleton(&ContextGreeter{Greeting: "scoped"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	parentCtx := dino.NewContext(context.Background(), di)
+	scopeCtx := dino.NewContext(parentCtx, scope)
+
+	if got, ok := dino.FromContext(parentCtx); !ok || got != di {
+		t.Fatalf("expected the container, got %v", got)
+	}
+
+	// The scope stored over its parent container takes precedence
+	if got := dino.MustFromContext(scopeCtx); got != scope {
+		t.Fatalf("expected the scope, got %v", got)
+	}
+
+	if _, ok := dino.ScopeFromContext(parentCtx); ok {
+		t.Fatal("expected the container not to be reported as a scope")
+	}
+
+	if got, ok := dino.ScopeFromContext(scopeCtx); !ok || got != scope {
+		t.Fatalf("expected the scope, got %v", got)
+	}
+
+	var greeting string
+
+	if _, err := dino.InvokeFromContext(scopeCtx, func(greeter *ContextGreeter) {
+		greeting = greeter.Greeting
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if greeting != "scoped" {
+		t.Fatalf("expected the scoped greeter, got %q", greeting)
+	}
+}
+
+func TestFromContext_Missing(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	if d, ok := dino.FromContext(ctx); ok {
+		t.Fatalf("expected no container, got %v", d)
+	}
+
+	if _, ok := dino.FromContext(dino.NewContext(ctx, nil)); ok {
+		t.Fatal("expected a nil container not to be reported")
+	}
+
+	if _, err := dino.InvokeFromContext(ctx, func() {
+		t.Fatal("expected the function not to be called")
+	}); !errors.Is(err, dino.ErrValueNotFound) {
+		t.Fatalf("expected ErrValueNotFound, got %v", err)
+	}
+
+	defer func() {
+		err, ok := recover().(error)
+		if !ok || !errors.Is(err, dino.ErrValueNotFound) {
+			t.Fatalf("expected a panic with ErrValueNotFound, got %v", err)
+		}
+	}()
+
+	dino.MustFromContext(ctx)
+}
@@ -0,0 +1,105 @@
+package dino
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// DebugHandler returns an HTTP handler exposing the state of the container for debugging:
+//   - / writes the registry dump, see Dump;
+//   - /graph.dot writes the dependency graph, see WriteDOT;
+//   - /stats writes the Stats summary as JSON;
+//   - /explain?type=...&tag=... writes the explanation of a type, see Explain.
+//
+// The type to explain is looked up among the registered types and their dependencies, by its name as
+// printed by reflect, e.g. *main.Database, or qualified by its package path, e.g. *example.com/app.Database.
+// Factories are never called, so the handler is safe to mount on an internal mux of a running service,
+// e.g. mux.Handle("/debug/dino/", http.StripPrefix("/debug/dino", di.DebugHandler())).
+func (d *Dino) DebugHandler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /{$}", debugText(d.Dump, "text/plain; charset=utf-8"))
+	mux.HandleFunc("GET /graph.dot", debugText(d.WriteDOT, "text/vnd.graphviz; charset=utf-8"))
+
+	mux.HandleFunc("GET /stats", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		_ = json.NewEncoder(w).Encode(d.Stats())
+	})
+
+	mux.HandleFunc("GET /explain", func(w http.ResponseWriter, r *http.Request) {
+		name := r.URL.Query().Get("type")
+		if name == "" {
+			http.Error(w, "missing type parameter", http.StatusBadRequest)
+
+			return
+		}
+
+		rt, ok := d.typeByName(name)
+		if !ok {
+			http.Error(w, "unknown type "+name, http.StatusNotFound)
+
+			return
+		}
+
+		explanation, err := d.Explain(rt, r.URL.Query().Get("tag"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+
+		_, _ = io.WriteString(w, explanation)
+	})
+
+	return mux
+}
+
+// debugText returns a handler serving what the write function writes with the content type,
+// or 500 Internal Server Error if it fails.
+func debugText(write func(io.Writer) error, contentType string) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		var out strings.Builder
+
+		if err := write(&out); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", contentType)
+
+		_, _ = io.WriteString(w, out.String())
+	}
+}
+
+// typeByName returns the registered type, or type a registration depends on, with the name.
+func (d *Dino) typeByName(name string) (reflect.Type, bool) {
+	for _, key := range d.graph().nodes {
+		if key.Type.String() == name || qualifiedTypeName(key.Type) == name {
+			return key.Type, true
+		}
+	}
+
+	return nil, false
+}
+
+// qualifiedTypeName returns the name of the type with its package path instead of the package name,
+// e.g. *example.com/app.Database.
+func qualifiedTypeName(rt reflect.Type) string {
+	switch {
+	case rt.Name() != "" && rt.PkgPath() != "":
+		return rt.PkgPath() + "." + rt.Name()
+
+	case rt.Kind() == reflect.Pointer:
+		return "*" + qualifiedTypeName(rt.Elem())
+
+	default:
+		return rt.String()
+	}
+}
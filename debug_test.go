@@ -0,0 +1,197 @@
+package dino_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/yuppyweb/dino"
+)
+
+type DebugDatabase struct{}
+
+type DebugService struct{}
+
+func newDebugContainer(t *testing.T, called *bool) *dino.Dino {
+	t.Helper()
+
+	di := dino.New(dino.WithStats())
+
+	if err := di.Singleton(&DebugDatabase{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := di.Factory(func(*DebugDatabase) *DebugService {
+		*called = true
+
+		return &DebugService{}
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	return di
+}
+
+func debugRequest(t *testing.T, handler http.Handler, target string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	request := httptest.NewRequestWithContext(context.Background(), http.MethodGet, target, nil)
+	recorder := httptest.NewRecorder()
+
+	handler.ServeHTTP(recorder, request)
+
+	return recorder
+}
+
+func TestDino_DebugHandler(t *testing.T) {
+	t.Parallel()
+
+	called := false
+	di := newDebugContainer(t, &called)
+	handler := di.DebugHandler()
+
+	tests := []struct {
+		name        string
+		target      string
+		status      int
+		contentType string
+		body        string
+	}{
+		{
+			name:        "dump",
+			target:      "/",
+			status:      http.StatusOK,
+			contentType: "text/plain; charset=utf-8",
+			body:        `*dino_test.DebugService tag="" factory func(*dino_test.DebugDatabase)`,
+		},
+		{
+			name:        "graph",
+			target:      "/graph.dot",
+			status:      http.StatusOK,
+			contentType: "text/vnd.graphviz; charset=utf-8",
+			body:        "    n1 -> n0;\n",
+		},
+		{
+			name:        "explain",
+			target:      "/explain?type=*dino_test.DebugService",
+			status:      http.StatusOK,
+			contentType: "text/plain; charset=utf-8",
+			body:        "  *dino_test.DebugDatabase -> instance *dino_test.DebugDatabase\n",
+		},
+		{
+			name:        "explain qualified type",
+			target:      "/explain?type=*github.com/yuppyweb/dino_test.DebugDatabase",
+			status:      http.StatusOK,
+			contentType: "text/plain; charset=utf-8",
+			body:        "*dino_test.DebugDatabase -> instance *dino_test.DebugDatabase\n",
+		},
+		{
+			name:        "explain tagged",
+			target:      "/explain?type=*dino_test.DebugDatabase&tag=replica",
+			status:      http.StatusOK,
+			contentType: "text/plain; charset=utf-8",
+			body:        "NOT FOUND",
+		},
+		{
+			name:        "explain missing type",
+			target:      "/explain",
+			status:      http.StatusBadRequest,
+			contentType: "text/plain; charset=utf-8",
+			body:        "missing type parameter",
+		},
+		{
+			name:        "explain unknown type",
+			target:      "/explain?type=*main.Unknown",
+			status:      http.StatusNotFound,
+			contentType: "text/plain; charset=utf-8",
+			body:        "unknown type *main.Unknown",
+		},
+		{
+			name:        "unknown route",
+			target:      "/unknown",
+			status:      http.StatusNotFound,
+			contentType: "text/plain; charset=utf-8",
+			body:        "404 page not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := debugRequest(t, handler, tt.target)
+
+			if recorder.Code != tt.status {
+				t.Fatalf("expected status %d, got %d", tt.status, recorder.Code)
+			}
+
+			if got := recorder.Header().Get("Content-Type"); got != tt.contentType {
+				t.Fatalf("expected content type %q, got %q", tt.contentType, got)
+			}
+
+			if !strings.Contains(recorder.Body.String(), tt.body) {
+				t.Fatalf("expected body to contain %q, got:\n%s", tt.body, recorder.Body.String())
+			}
+		})
+	}
+
+	if called {
+		t.Fatal("expected the factory not to be called")
+	}
+}
+
+func TestDino_DebugHandlerStats(t *testing.T) {
+	t.Parallel()
+
+	called := false
+	di := newDebugContainer(t, &called)
+
+	recorder := debugRequest(t, di.DebugHandler(), "/stats")
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", recorder.Code)
+	}
+
+	if got := recorder.Header().Get("Content-Type"); got != "application/json" {
+		t.Fatalf("expected JSON, got %q", got)
+	}
+
+	var stats dino.ContainerStats
+	if err := json.Unmarshal(recorder.Body.Bytes(), &stats); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if stats.Registrations != 2 {
+		t.Fatalf("expected 2 registrations, got %d", stats.Registrations)
+	}
+
+	if called {
+		t.Fatal("expected the factory not to be called")
+	}
+}
+
+func TestDino_DebugHandlerMounted(t *testing.T) {
+	t.Parallel()
+
+	called := false
+	di := newDebugContainer(t, &called)
+
+	mux := http.NewServeMux()
+	mux.Handle("/debug/dino/", http.StripPrefix("/debug/dino", di.DebugHandler()))
+
+	for _, target := range []string{"/debug/dino/", "/debug/dino/graph.dot", "/debug/dino/stats"} {
+		if recorder := debugRequest(t, mux, target); recorder.Code != http.StatusOK {
+			t.Fatalf("expected status 200 for %s, got %d", target, recorder.Code)
+		}
+	}
+
+	recorder := debugRequest(t, mux, "/debug/dino/explain?type=*dino_test.DebugService")
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", recorder.Code)
+	}
+
+	if called {
+		t.Fatal("expected the factory not to be called")
+	}
+}
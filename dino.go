@@ -10,20 +10,53 @@ import (
 	"sync"
 )
 
-var ErrInvalidInputValue = errors.New("invalid input value")
+var (
+	ErrInvalidInputValue    = errors.New("invalid input value")
+	ErrNoRegistrableOutputs = errors.New("factory has no registrable outputs")
+	ErrFactoryFailed        = errors.New("factory function failed")
+	ErrInvokeFailed         = errors.New("failed to invoke function")
+)
 
 // Dino is the main dependency injection container.
 type Dino struct {
-	registry Registry
-	mutex    sync.Mutex
+	registry      Registry
+	options       *options
+	mutex         sync.RWMutex
+	parent        *Dino
+	subscriptions []replaceSubscription
+	runners       []reflect.Value
+}
+
+// binding is a type a factory function is registered for, together with its tags.
+type binding struct {
+	typ  reflect.Type
+	tags []string
 }
 
-// New creates a new instance of the Dino dependency injection container.
-func New() *Dino {
-	return &Dino{
-		registry: new(SyncMapRegistry),
-		mutex:    sync.Mutex{},
+// New creates a new instance of the Dino dependency injection container configured with the provided options.
+// Without the WithRegistry option, dependencies are stored in a SyncMapRegistry.
+func New(opts ...Option) *Dino {
+	options := newOptions(opts...)
+
+	var registry Registry = new(SyncMapRegistry)
+	if options.registry != nil {
+		registry = options.registry
 	}
+
+	d := &Dino{
+		registry:      registry,
+		options:       options,
+		mutex:         sync.RWMutex{},
+		parent:        nil,
+		subscriptions: nil,
+		runners:       nil,
+	}
+
+	if options.expvarName != "" {
+		publishExpvar(options.expvarName, d)
+	}
+
+	return d
 }
 
 // WithRegistry sets a custom registry for the Dino container.
@@ -38,6 +71,21 @@ func (d *Dino) WithRegistry(registry Registry) *Dino {
 
 // Factory registers a factory function that produces instances of dependencies.
 func (d *Dino) Factory(fn any, tags ...string) error {
+	return d.FactoryWith(fn, Tags(tags...))
+}
+
+// FactoryWith registers a factory function configured with registration options, e.g.
+// di.FactoryWith(NewToken, dino.Tags("auth"), dino.TTL(5*time.Minute)).
+func (d *Dino) FactoryWith(fn any, opts ...RegisterOption) error {
+	return d.factory(fn, nil, opts...)
+}
+
+// factory registers the factory function together with its typed form, if any, under a single lock,
+// so that no resolution calls the factory through reflection while the typed form is being recorded.
+func (d *Dino) factory(fn any, typed typedFactory, opts ...RegisterOption) error {
+	regOpts := newRegisterOptions(opts...)
+	tags := regOpts.tags
+
 	rv := reflect.ValueOf(fn)
 
 	if isNil(rv) {
@@ -54,26 +102,86 @@ func (d *Dino) Factory(fn any, tags ...string) error {
 		)
 	}
 
+	if err := validateQualifier(regOpts.qualifier); err != nil {
+		return err
+	}
+
+	bindings := factoryBindings(rt, tags)
+
+	if len(bindings) == 0 {
+		// Functions returning only errors are run for their effect, not registered
+		if rt.NumOut() > 0 {
+			return fmt.Errorf("%w: %s returns no values besides errors, register it with Runner to run it on Build",
+				ErrNoRegistrableOutputs, rt)
+		}
+
+		return fmt.Errorf("%w: %s returns no values besides errors", ErrNoRegistrableOutputs, rt)
+	}
+
 	d.mutex.Lock()
 	defer d.mutex.Unlock()
 
+	if _, ok := d.registry.(ExpiringRegistry); regOpts.ttl > 0 && !ok {
+		return fmt.Errorf("%w: %T", ErrTTLUnsupported, d.registry)
+	}
+
 	// Create a new injector to resolve the factory function's output types and bind them to the registry
-	injector := NewInjector(d.registry)
+	injector := newInjector(d.registry, d.options)
+	deps := factoryDependencies(rt)
 
-	for outType := range rt.Outs() {
-		if outType.Implements(reflect.TypeFor[error]()) {
-			continue
-		}
+	for _, bnd := range bindings {
+		keys := taggedKeys(bnd.typ, regOpts.qualifier, bnd.tags)
 
-		if err := injector.Bind(outType, reflect.ValueOf(fn), tags...); err != nil {
+		if err := injector.bind(keys, rv); err != nil {
 			return fmt.Errorf("failed to bind factory function output: %w", err)
 		}
+
+		d.options.setLifetime(keys, regOpts.ttl)
+		d.options.setPerScope(keys, regOpts.scoped)
+		d.options.setDependencies(keys, deps)
+		d.options.setFactory(keys, rv)
+
+		if typed != nil {
+			d.options.setTypedFactory(keys, typed)
+		}
 	}
 
 	return nil
 }
 
-// Singleton registers a singleton instance of a dependency.
+// factoryBindings returns the types and tags the factory function type can be resolved by.
+// Error returns are not dependencies.
+func factoryBindings(rt reflect.Type, tags []string) []binding {
+	bindings := make([]binding, 0, rt.NumOut())
+
+	for outType := range rt.Outs() {
+		switch {
+		case isError(outType):
+			continue
+
+		case isOutStruct(outType):
+			// Each field of a result object is bound under its own tag, or the factory tags
+			for _, field := range outFields(outType) {
+				fieldTags := tags
+				if field.tag != "" {
+					fieldTags = []string{field.tag}
+				}
+
+				bindings = append(bindings, binding{typ: field.typ, tags: fieldTags})
+			}
+
+		default:
+			bindings = append(bindings, binding{typ: outType, tags: tags})
+		}
+	}
+
+	return bindings
+}
+
+// Singleton registers a singleton instance of a dependency under its dynamic type: a *ConsoleLogger held
+// by a Logger variable is registered as *ConsoleLogger, use SingletonTyped to register it as Logger.
+// A nil interface value has no type and is rejected with ErrInvalidInputValue.
+// Replacing an instance registered before notifies the OnReplace subscriptions of its type.
 func (d *Dino) Singleton(val any, tags ...string) error {
 	rv := reflect.ValueOf(val)
 
@@ -81,38 +189,184 @@ func (d *Dino) Singleton(val any, tags ...string) error {
 		return fmt.Errorf("%w: singleton value cannot be nil", ErrInvalidInputValue)
 	}
 
-	d.mutex.Lock()
-	defer d.mutex.Unlock()
+	replacements, err := d.singleton(taggedKeys(rv.Type(), nil, tags), rv)
+	if err != nil {
+		return err
+	}
 
-	injector := NewInjector(d.registry)
+	d.notifyReplaced(replacements)
 
-	if err := injector.Bind(reflect.TypeOf(val), rv, tags...); err != nil {
-		return fmt.Errorf("failed to bind singleton: %w", err)
+	return nil
+}
+
+// SingletonWith registers a singleton instance of a dependency like Singleton, configured by the options,
+// e.g. di.SingletonWith(db, dino.Qualify(primary{})). Singletons never expire: TTL is rejected with
+// ErrInvalidInputValue, as are non-comparable qualifiers.
+func (d *Dino) SingletonWith(val any, opts ...RegisterOption) error {
+	rv := reflect.ValueOf(val)
+
+	if isNil(rv) {
+		return fmt.Errorf("%w: singleton value cannot be nil", ErrInvalidInputValue)
 	}
 
+	regOpts := newRegisterOptions(opts...)
+
+	if regOpts.ttl != 0 {
+		return fmt.Errorf("%w: singleton cannot expire", ErrInvalidInputValue)
+	}
+
+	if regOpts.scoped {
+		return fmt.Errorf("%w: singleton cannot be created per scope", ErrInvalidInputValue)
+	}
+
+	if err := validateQualifier(regOpts.qualifier); err != nil {
+		return err
+	}
+
+	replacements, err := d.singleton(taggedKeys(rv.Type(), regOpts.qualifier, regOpts.tags), rv)
+	if err != nil {
+		return err
+	}
+
+	d.notifyReplaced(replacements)
+
 	return nil
 }
 
-// Inject resolves and injects dependencies into the provided target struct.
-func (d *Dino) Inject(target any) error {
-	rv := reflect.ValueOf(target)
+// SingletonTyped registers a singleton instance of a dependency under the given type, which the value
+// must be assignable to, e.g. di.SingletonTyped(reflect.TypeFor[Logger](), &ConsoleLogger{}) makes
+// the logger resolvable as Logger rather than *ConsoleLogger.
+func (d *Dino) SingletonTyped(rt reflect.Type, val any, tags ...string) error {
+	if rt == nil {
+		return fmt.Errorf("%w: singleton type cannot be nil", ErrInvalidInputValue)
+	}
+
+	rv := reflect.ValueOf(val)
 
 	if isNil(rv) {
-		return fmt.Errorf("%w: inject target cannot be nil", ErrInvalidInputValue)
+		return fmt.Errorf("%w: singleton value cannot be nil", ErrInvalidInputValue)
 	}
 
+	if !rv.Type().AssignableTo(rt) {
+		return fmt.Errorf("%w: singleton of type %s is not assignable to %s", ErrInvalidInputValue, rv.Type(), rt)
+	}
+
+	replacements, err := d.singleton(taggedKeys(rt, nil, tags), rv)
+	if err != nil {
+		return err
+	}
+
+	d.notifyReplaced(replacements)
+
+	return nil
+}
+
+// singleton binds the value under the keys and returns the instances it replaced.
+func (d *Dino) singleton(keys []RegistryKey, rv reflect.Value) ([]replacement, error) {
 	d.mutex.Lock()
 	defer d.mutex.Unlock()
 
-	injector := NewInjector(d.registry)
+	replacements := d.replacing(keys, rv)
+
+	injector := newInjector(d.registry, d.options)
+
+	if err := injector.bind(keys, rv); err != nil {
+		return nil, fmt.Errorf("failed to bind singleton: %w", err)
+	}
+
+	// A singleton replacing a factory no longer depends on anything
+	d.options.setDependencies(keys, nil)
+	d.options.setFactory(keys, reflect.Value{})
+
+	d.options.lifecycle.track(keys[0], rv)
+
+	return replacements, nil
+}
+
+// replacing returns the instances registered under the keys that binding the value replaces.
+// Factories are not instances, and nothing is looked up without subscriptions to notify.
+func (d *Dino) replacing(keys []RegistryKey, rv reflect.Value) []replacement {
+	if len(d.subscriptions) == 0 {
+		return nil
+	}
+
+	var replacements []replacement
+
+	for _, key := range keys {
+		old, err := d.registry.Find(key)
+		if err != nil || isFactory(key, old) || old.Comparable() && rv.Comparable() && old.Equal(rv) {
+			continue
+		}
+
+		replacements = append(replacements, replacement{
+			key:   key,
+			old:   old,
+			value: rv,
+		})
+	}
+
+	return replacements
+}
+
+// Inject resolves and injects dependencies into the provided target struct.
+// Injecting the same target again only fills the fields that are still unset, e.g. after registering one
+// more dependency: fields already holding a value are kept, and structs auto-created by a previous injection
+// are revisited to fill their own unset fields. Set a field back to its zero value to resolve it again,
+// e.g. to pick up an instance replaced by Refresh.
+func (d *Dino) Inject(target any) error {
+	return d.inject(target, nil)
+}
+
+// inject injects the target, recording the outcome of its fields in the report, if any.
+func (d *Dino) inject(target any, report *reportRecorder) error {
+	rv, err := targetValue(target)
+	if err != nil {
+		return fmt.Errorf("failed to inject dependencies: %w", err)
+	}
+
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	injector := newInjector(d.registry, d.options)
+	injector.report = report
+
+	if err := injector.injectTarget(rv); err != nil {
+		d.options.stats.resolutionError()
 
-	if err := injector.Inject(rv); err != nil {
 		return fmt.Errorf("failed to inject dependencies: %w", err)
 	}
 
 	return nil
 }
 
+// targetValue returns the value of an injection target, which must be a non-nil pointer to a struct.
+func targetValue(target any) (reflect.Value, error) {
+	rv := reflect.ValueOf(target)
+
+	if isNil(rv) {
+		return rv, fmt.Errorf("%w: inject target cannot be nil", ErrInvalidInputValue)
+	}
+
+	if isStruct(rv.Type()) {
+		return rv, fmt.Errorf(
+			"%w: got %s, pass a pointer to it instead",
+			ErrExpectedPointerToStruct,
+			rv.Type(),
+		)
+	}
+
+	if !isPointerToStruct(rv.Type()) {
+		return rv, fmt.Errorf(
+			"%w: %w: got %s",
+			ErrInvalidInputValue,
+			ErrExpectedStruct,
+			rv.Type(),
+		)
+	}
+
+	return rv, nil
+}
+
 // Invoke calls a function with automatic dependency resolution.
 func (d *Dino) Invoke(fn any) ([]any, error) {
 	rv := reflect.ValueOf(fn)
@@ -131,14 +385,16 @@ func (d *Dino) Invoke(fn any) ([]any, error) {
 		)
 	}
 
-	d.mutex.Lock()
-	defer d.mutex.Unlock()
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
 
-	injector := NewInjector(d.registry)
+	injector := newInjector(d.registry, d.options)
 
-	values, err := injector.Invoke(rv)
+	values, err := injector.invoke(rv)
 	if err != nil {
-		return nil, fmt.Errorf("failed to invoke function: %w", err)
+		d.options.stats.resolutionError()
+
+		return nil, fmt.Errorf("%w: %w", ErrInvokeFailed, err)
 	}
 
 	results := make([]any, len(values))
@@ -149,3 +405,42 @@ func (d *Dino) Invoke(fn any) ([]any, error) {
 
 	return results, nil
 }
+
+// Resolve returns the dependency registered for the specified type.
+// Only the first tag is used; without tags the untagged dependency is resolved.
+// Unlike Inject and Invoke, a missing dependency is reported as ErrValueNotFound instead of being created.
+func (d *Dino) Resolve(rt reflect.Type, tags ...string) (any, error) {
+	if rt == nil {
+		return nil, fmt.Errorf("%w: type to resolve cannot be nil", ErrInvalidInputValue)
+	}
+
+	key := RegistryKey{
+		Tag:       "",
+		Type:      rt,
+		Scope:     "",
+		Qualifier: nil,
+	}
+
+	if len(tags) > 0 {
+		key.Tag = tags[0]
+	}
+
+	return d.resolveKey(key)
+}
+
+// resolveKey returns the dependency registered under the key.
+func (d *Dino) resolveKey(key RegistryKey) (any, error) {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	injector := newInjector(d.registry, d.options)
+
+	rv, err := injector.Resolve(key)
+	if err != nil {
+		d.options.stats.resolutionError()
+
+		return nil, fmt.Errorf("failed to resolve dependency: %w", err)
+	}
+
+	return rv.Interface(), nil
+}
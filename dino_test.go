@@ -6,9 +6,11 @@ import (
 	"reflect"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 
 	"github.com/yuppyweb/dino"
+	"github.com/yuppyweb/dino/dinotest"
 )
 
 //go:generate go tool cp ./dino_mock.tmpl ./dino_mock.go
@@ -28,14 +30,71 @@ func TestDino_WithCustomRegistry(t *testing.T) {
 	t.Parallel()
 
 	di := dino.New()
-	di.WithRegistry(NewMockRegistry())
+	di.WithRegistry(dinotest.NewMockRegistry())
 	registry := di.MockRegistry()
 
-	if _, ok := registry.(*MockRegistry); !ok {
+	if _, ok := registry.(*dinotest.MockRegistry); !ok {
 		t.Fatalf("expected custom registry to be of type MockRegistry")
 	}
 }
 
+func TestDino_WithRegistryOption(t *testing.T) {
+	t.Parallel()
+
+	registry := dino.NewShardedRegistry(16)
+
+	di := dino.New(dino.WithRegistry(registry))
+
+	if di.MockRegistry() != registry {
+		t.Fatalf("expected registry passed as option to be used")
+	}
+
+	if err := di.Singleton(42); err != nil {
+		t.Fatalf("unexpected error during singleton registration: %v", err)
+	}
+
+	if registry.Len() != 1 {
+		t.Fatalf("expected singleton to be stored in the provided registry, got %d entries", registry.Len())
+	}
+}
+
+func TestDino_RegistryObserverSeesCachedFactoryResults(t *testing.T) {
+	t.Parallel()
+
+	type Service struct{}
+
+	var observed []dino.RegistryKey
+
+	registry := new(dino.SyncMapRegistry)
+	registry.OnRegister(func(key dino.RegistryKey, rv reflect.Value) {
+		if !rv.IsValid() {
+			t.Errorf("expected a valid value for %v", key)
+		}
+
+		observed = append(observed, key)
+	})
+
+	di := dino.New(dino.WithRegistry(registry))
+
+	if err := di.Factory(func() *Service { return &Service{} }, "svc"); err != nil {
+		t.Fatalf("unexpected error during factory registration: %v", err)
+	}
+
+	if _, err := di.Resolve(reflect.TypeFor[*Service](), "svc"); err != nil {
+		t.Fatalf("unexpected error from Resolve: %v", err)
+	}
+
+	if len(observed) != 2 {
+		t.Fatalf("expected factory registration and cached result to be observed, got %v", observed)
+	}
+
+	for _, key := range observed {
+		if key.Tag != "svc" || key.Type != reflect.TypeFor[*Service]() {
+			t.Fatalf("expected observed key for tagged service, got %v", key)
+		}
+	}
+}
+
 func TestDino_FactoryNilFunction(t *testing.T) {
 	t.Parallel()
 
@@ -96,8 +155,9 @@ func TestDino_FactorySingleOutputWithoutTags(t *testing.T) {
 
 	registry := di.MockRegistry()
 	key := dino.RegistryKey{
-		Tag:  "",
-		Type: reflect.TypeOf(service),
+		Tag:   "",
+		Type:  reflect.TypeOf(service),
+		Scope: "",
 	}
 
 	val, err := registry.Find(key)
@@ -141,18 +201,21 @@ func TestDino_FactorySingleOutputWithTags(t *testing.T) {
 	registry := di.MockRegistry()
 
 	key1 := dino.RegistryKey{
-		Tag:  "tag1",
-		Type: reflect.TypeOf(service),
+		Tag:   "tag1",
+		Type:  reflect.TypeOf(service),
+		Scope: "",
 	}
 
 	key2 := dino.RegistryKey{
-		Tag:  "tag2",
-		Type: reflect.TypeOf(service),
+		Tag:   "tag2",
+		Type:  reflect.TypeOf(service),
+		Scope: "",
 	}
 
 	emptyKey := dino.RegistryKey{
-		Tag:  "",
-		Type: reflect.TypeOf(service),
+		Tag:   "",
+		Type:  reflect.TypeOf(service),
+		Scope: "",
 	}
 
 	val1, err := registry.Find(key1)
@@ -191,6 +254,80 @@ func TestDino_FactorySingleOutputWithTags(t *testing.T) {
 	}
 }
 
+func TestDino_FactoryMultipleOutputsError(t *testing.T) {
+	t.Parallel()
+
+	type ServiceA struct{}
+
+	type ServiceB struct{}
+
+	errFailed := errors.New("first call failed")
+
+	tests := []struct {
+		name    string
+		factory func(calls *int) any
+	}{
+		{
+			name: "value then error",
+			factory: func(calls *int) any {
+				return func() (*ServiceA, *ServiceB, error) {
+					*calls++
+					if *calls == 1 {
+						return &ServiceA{}, &ServiceB{}, errFailed
+					}
+
+					return &ServiceA{}, &ServiceB{}, nil
+				}
+			},
+		},
+		{
+			name: "error then value",
+			factory: func(calls *int) any {
+				return func() (error, *ServiceA, *ServiceB) {
+					*calls++
+					if *calls == 1 {
+						return errFailed, &ServiceA{}, &ServiceB{}
+					}
+
+					return nil, &ServiceA{}, &ServiceB{}
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			calls := 0
+			di := dino.New()
+
+			if err := di.Factory(tt.factory(&calls)); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if _, err := dino.Resolve[*ServiceA](di); !errors.Is(err, errFailed) {
+				t.Fatalf("expected the factory error, got %v", err)
+			}
+
+			// Nothing from the failed call was bound: the factory is called again for either output
+			srvB, err := dino.Resolve[*ServiceB](di)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			srvA, err := dino.Resolve[*ServiceA](di)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if srvA == nil || srvB == nil || calls != 2 {
+				t.Fatalf("expected both outputs from the second call, got %v, %v after %d calls", srvA, srvB, calls)
+			}
+		})
+	}
+}
+
 func TestDino_FactoryMultipleOutputs(t *testing.T) {
 	t.Parallel()
 
@@ -215,13 +352,15 @@ func TestDino_FactoryMultipleOutputs(t *testing.T) {
 	registry := di.MockRegistry()
 
 	keyA := dino.RegistryKey{
-		Tag:  "",
-		Type: reflect.TypeOf(srvA),
+		Tag:   "",
+		Type:  reflect.TypeOf(srvA),
+		Scope: "",
 	}
 
 	keyB := dino.RegistryKey{
-		Tag:  "",
-		Type: reflect.TypeOf(srvB),
+		Tag:   "",
+		Type:  reflect.TypeOf(srvB),
+		Scope: "",
 	}
 
 	valA, err := registry.Find(keyA)
@@ -270,14 +409,18 @@ func TestDino_FactoryWithNilError(t *testing.T) {
 		return nil
 	}
 
-	registry := NewMockRegistry()
+	registry := dinotest.NewMockRegistry()
 
 	di := dino.New()
 	di = di.WithRegistry(registry)
 
 	err := di.Factory(factory)
-	if err != nil {
-		t.Fatalf("unexpected error: %v", err)
+	if !errors.Is(err, dino.ErrNoRegistrableOutputs) {
+		t.Fatalf("expected ErrNoRegistrableOutputs, got %v", err)
+	}
+
+	if !strings.Contains(err.Error(), "func() error returns no values besides errors") {
+		t.Fatalf("expected error message to contain the factory signature, got %s", err.Error())
 	}
 
 	if len(registry.RegisterOn) != 0 {
@@ -292,14 +435,40 @@ func TestDino_FactoryWithOnlyError(t *testing.T) {
 		return errors.New("some error")
 	}
 
-	registry := NewMockRegistry()
+	registry := dinotest.NewMockRegistry()
 
 	di := dino.New()
 	di = di.WithRegistry(registry)
 
 	err := di.Factory(factory)
-	if err != nil {
-		t.Fatalf("unexpected error: %v", err)
+	if !errors.Is(err, dino.ErrNoRegistrableOutputs) {
+		t.Fatalf("expected ErrNoRegistrableOutputs, got %v", err)
+	}
+
+	if !strings.Contains(err.Error(), "func() error returns no values besides errors") {
+		t.Fatalf("expected error message to contain the factory signature, got %s", err.Error())
+	}
+
+	if len(registry.RegisterOn) != 0 {
+		t.Fatalf("expected no registrations in registry, got %d", len(registry.RegisterOn))
+	}
+}
+
+func TestDino_FactoryWithoutOutputs(t *testing.T) {
+	t.Parallel()
+
+	registry := dinotest.NewMockRegistry()
+
+	di := dino.New()
+	di = di.WithRegistry(registry)
+
+	err := di.Factory(func() {})
+	if !errors.Is(err, dino.ErrNoRegistrableOutputs) {
+		t.Fatalf("expected ErrNoRegistrableOutputs, got %v", err)
+	}
+
+	if err.Error() != "factory has no registrable outputs: func() returns no values besides errors" {
+		t.Fatalf("unexpected error message: %s", err.Error())
 	}
 
 	if len(registry.RegisterOn) != 0 {
@@ -322,7 +491,7 @@ func TestDino_FactoryWithErrorAndOtherOutputs(t *testing.T) {
 		return srv, nil
 	}
 
-	registry := NewMockRegistry()
+	registry := dinotest.NewMockRegistry()
 	registry.RegisterOut = append(registry.RegisterOut, nil)
 
 	di := dino.New()
@@ -363,7 +532,7 @@ func TestDino_FactoryBindError(t *testing.T) {
 		return &SimpleService{}
 	}
 
-	registry := NewMockRegistry()
+	registry := dinotest.NewMockRegistry()
 	registry.RegisterOut = append(registry.RegisterOut, errors.New("some bind error"))
 
 	di := dino.New()
@@ -401,8 +570,9 @@ func TestDino_FactoryIntegerWithoutTag(t *testing.T) {
 
 	registry := di.MockRegistry()
 	key := dino.RegistryKey{
-		Tag:  "",
-		Type: reflect.TypeOf(value),
+		Tag:   "",
+		Type:  reflect.TypeOf(value),
+		Scope: "",
 	}
 
 	val, err := registry.Find(key)
@@ -436,8 +606,9 @@ func TestDino_FactoryIntegerWithTag(t *testing.T) {
 
 	registry := di.MockRegistry()
 	key1 := dino.RegistryKey{
-		Tag:  "intTag",
-		Type: reflect.TypeOf(value),
+		Tag:   "intTag",
+		Type:  reflect.TypeOf(value),
+		Scope: "",
 	}
 
 	val, err := registry.Find(key1)
@@ -456,8 +627,9 @@ func TestDino_FactoryIntegerWithTag(t *testing.T) {
 	}
 
 	key2 := dino.RegistryKey{
-		Tag:  "",
-		Type: reflect.TypeOf(value),
+		Tag:   "",
+		Type:  reflect.TypeOf(value),
+		Scope: "",
 	}
 
 	_, err = registry.Find(key2)
@@ -481,8 +653,9 @@ func TestDino_FactoryStringWithoutTag(t *testing.T) {
 
 	registry := di.MockRegistry()
 	key := dino.RegistryKey{
-		Tag:  "",
-		Type: reflect.TypeOf(value),
+		Tag:   "",
+		Type:  reflect.TypeOf(value),
+		Scope: "",
 	}
 
 	val, err := registry.Find(key)
@@ -516,8 +689,9 @@ func TestDino_FactoryStringWithTag(t *testing.T) {
 
 	registry := di.MockRegistry()
 	key1 := dino.RegistryKey{
-		Tag:  "stringTag",
-		Type: reflect.TypeOf(value),
+		Tag:   "stringTag",
+		Type:  reflect.TypeOf(value),
+		Scope: "",
 	}
 
 	val, err := registry.Find(key1)
@@ -536,8 +710,9 @@ func TestDino_FactoryStringWithTag(t *testing.T) {
 	}
 
 	key2 := dino.RegistryKey{
-		Tag:  "",
-		Type: reflect.TypeOf(value),
+		Tag:   "",
+		Type:  reflect.TypeOf(value),
+		Scope: "",
 	}
 
 	_, err = registry.Find(key2)
@@ -567,8 +742,9 @@ func TestDino_FactoryConcurrentAccess(t *testing.T) {
 
 	for idx := range 100 {
 		keyNum := dino.RegistryKey{
-			Tag:  fmt.Sprintf("concurrentTag%d", idx),
-			Type: reflect.TypeOf(0),
+			Tag:   fmt.Sprintf("concurrentTag%d", idx),
+			Type:  reflect.TypeOf(0),
+			Scope: "",
 		}
 
 		registry := di.MockRegistry()
@@ -628,8 +804,9 @@ func TestDino_SingletonWithoutTags(t *testing.T) {
 
 	registry := di.MockRegistry()
 	key := dino.RegistryKey{
-		Tag:  "",
-		Type: reflect.TypeOf(srv),
+		Tag:   "",
+		Type:  reflect.TypeOf(srv),
+		Scope: "",
 	}
 
 	val, err := registry.Find(key)
@@ -668,18 +845,21 @@ func TestDino_SingletonWithTags(t *testing.T) {
 	registry := di.MockRegistry()
 
 	keyA := dino.RegistryKey{
-		Tag:  "tagA",
-		Type: reflect.TypeOf(srv),
+		Tag:   "tagA",
+		Type:  reflect.TypeOf(srv),
+		Scope: "",
 	}
 
 	keyB := dino.RegistryKey{
-		Tag:  "tagB",
-		Type: reflect.TypeOf(srv),
+		Tag:   "tagB",
+		Type:  reflect.TypeOf(srv),
+		Scope: "",
 	}
 
 	emptyKey := dino.RegistryKey{
-		Tag:  "",
-		Type: reflect.TypeOf(srv),
+		Tag:   "",
+		Type:  reflect.TypeOf(srv),
+		Scope: "",
 	}
 
 	valA, err := registry.Find(keyA)
@@ -730,8 +910,9 @@ func TestDino_SingletonFunctionValue(t *testing.T) {
 
 	registry := di.MockRegistry()
 	key := dino.RegistryKey{
-		Tag:  "",
-		Type: reflect.TypeOf(func() int { return 0 }),
+		Tag:   "",
+		Type:  reflect.TypeOf(func() int { return 0 }),
+		Scope: "",
 	}
 
 	val, err := registry.Find(key)
@@ -763,8 +944,9 @@ func TestDino_SingletonIntegerWithoutTag(t *testing.T) {
 
 	registry := di.MockRegistry()
 	key := dino.RegistryKey{
-		Tag:  "",
-		Type: reflect.TypeOf(value),
+		Tag:   "",
+		Type:  reflect.TypeOf(value),
+		Scope: "",
 	}
 
 	val, err := registry.Find(key)
@@ -795,8 +977,9 @@ func TestDino_SingletonIntegerWithTag(t *testing.T) {
 
 	registry := di.MockRegistry()
 	key1 := dino.RegistryKey{
-		Tag:  "intSingletonTag",
-		Type: reflect.TypeOf(value),
+		Tag:   "intSingletonTag",
+		Type:  reflect.TypeOf(value),
+		Scope: "",
 	}
 
 	val, err := registry.Find(key1)
@@ -814,8 +997,9 @@ func TestDino_SingletonIntegerWithTag(t *testing.T) {
 	}
 
 	key2 := dino.RegistryKey{
-		Tag:  "",
-		Type: reflect.TypeOf(value),
+		Tag:   "",
+		Type:  reflect.TypeOf(value),
+		Scope: "",
 	}
 
 	_, err = registry.Find(key2)
@@ -837,8 +1021,9 @@ func TestDino_SingletonStringWithoutTag(t *testing.T) {
 
 	registry := di.MockRegistry()
 	key := dino.RegistryKey{
-		Tag:  "",
-		Type: reflect.TypeOf(value),
+		Tag:   "",
+		Type:  reflect.TypeOf(value),
+		Scope: "",
 	}
 
 	val, err := registry.Find(key)
@@ -869,8 +1054,9 @@ func TestDino_SingletonStringWithTag(t *testing.T) {
 
 	registry := di.MockRegistry()
 	key1 := dino.RegistryKey{
-		Tag:  "stringSingletonTag",
-		Type: reflect.TypeOf(value),
+		Tag:   "stringSingletonTag",
+		Type:  reflect.TypeOf(value),
+		Scope: "",
 	}
 
 	val, err := registry.Find(key1)
@@ -888,8 +1074,9 @@ func TestDino_SingletonStringWithTag(t *testing.T) {
 	}
 
 	key2 := dino.RegistryKey{
-		Tag:  "",
-		Type: reflect.TypeOf(value),
+		Tag:   "",
+		Type:  reflect.TypeOf(value),
+		Scope: "",
 	}
 
 	_, err = registry.Find(key2)
@@ -911,8 +1098,9 @@ func TestDino_SingletonErrorValue(t *testing.T) {
 
 	registry := di.MockRegistry()
 	key := dino.RegistryKey{
-		Tag:  "",
-		Type: reflect.TypeOf(expectedErr),
+		Tag:   "",
+		Type:  reflect.TypeOf(expectedErr),
+		Scope: "",
 	}
 
 	val, err := registry.Find(key)
@@ -933,7 +1121,7 @@ func TestDino_SingletonErrorValue(t *testing.T) {
 func TestDino_SingletonBindError(t *testing.T) {
 	t.Parallel()
 
-	registry := NewMockRegistry()
+	registry := dinotest.NewMockRegistry()
 	expectedErr := errors.New("some bind error")
 
 	di := dino.New()
@@ -955,6 +1143,105 @@ func TestDino_SingletonBindError(t *testing.T) {
 	}
 }
 
+type TypedLogger interface {
+	Log(msg string)
+}
+
+type TypedConsoleLogger struct {
+	prefix string
+}
+
+func (l *TypedConsoleLogger) Log(string) {}
+
+type TypedLoggerConsumers struct {
+	ByInterface TypedLogger
+	ByConcrete  *TypedConsoleLogger
+}
+
+func TestDino_SingletonTyped(t *testing.T) {
+	t.Parallel()
+
+	var logger TypedLogger = &TypedConsoleLogger{prefix: "app"}
+
+	di := dino.New()
+
+	if err := di.SingletonTyped(reflect.TypeFor[TypedLogger](), logger); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Singleton keeps registering under the dynamic type
+	if err := di.Singleton(logger); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	consumers := new(TypedLoggerConsumers)
+	if err := di.Inject(consumers); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if consumers.ByInterface != logger || consumers.ByConcrete != logger {
+		t.Fatalf("expected both consumers to get the logger, got %+v", consumers)
+	}
+
+	resolved, err := dino.Resolve[TypedLogger](di)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if resolved != logger {
+		t.Fatalf("expected the logger under its interface, got %v", resolved)
+	}
+}
+
+func TestDino_SingletonTypedInvalid(t *testing.T) {
+	t.Parallel()
+
+	var nilLogger TypedLogger
+
+	tests := []struct {
+		name    string
+		rt      reflect.Type
+		val     any
+		message string
+	}{
+		{
+			name:    "nil type",
+			rt:      nil,
+			val:     &TypedConsoleLogger{prefix: ""},
+			message: "singleton type cannot be nil",
+		},
+		{
+			name:    "nil interface value",
+			rt:      reflect.TypeFor[TypedLogger](),
+			val:     nilLogger,
+			message: "singleton value cannot be nil",
+		},
+		{
+			name:    "not assignable",
+			rt:      reflect.TypeFor[TypedLogger](),
+			val:     TypedConsoleLogger{prefix: ""},
+			message: "singleton of type dino_test.TypedConsoleLogger is not assignable to dino_test.TypedLogger",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			di := dino.New()
+
+			err := di.SingletonTyped(tt.rt, tt.val)
+			if !errors.Is(err, dino.ErrInvalidInputValue) {
+				t.Fatalf("expected ErrInvalidInputValue, got %v", err)
+			}
+
+			if !strings.Contains(err.Error(), tt.message) {
+				t.Fatalf("expected error message to contain %q, got %s", tt.message, err.Error())
+			}
+		})
+	}
+}
+
 func TestDino_SingletonConcurrentAccess(t *testing.T) {
 	t.Parallel()
 
@@ -974,8 +1261,9 @@ func TestDino_SingletonConcurrentAccess(t *testing.T) {
 
 	for idx := range 100 {
 		keyNum := dino.RegistryKey{
-			Tag:  fmt.Sprintf("singletonTag%d", idx),
-			Type: reflect.TypeFor[int](),
+			Tag:   fmt.Sprintf("singletonTag%d", idx),
+			Type:  reflect.TypeFor[int](),
+			Scope: "",
 		}
 
 		registry := di.MockRegistry()
@@ -1006,11 +1294,10 @@ func TestDino_InjectNilTarget(t *testing.T) {
 		t.Fatalf("expected ErrInvalidInputValue, got %v", err)
 	}
 
-	if !strings.Contains(err.Error(), "inject target cannot be nil") {
-		t.Fatalf(
-			"expected error message to contain 'inject target cannot be nil', got %s",
-			err.Error(),
-		)
+	errMsg := "failed to inject dependencies: invalid input value: inject target cannot be nil"
+
+	if err.Error() != errMsg {
+		t.Fatalf("expected error message '%s', got '%s'", errMsg, err.Error())
 	}
 }
 
@@ -1032,6 +1319,51 @@ func TestDino_InjectNotStruct(t *testing.T) {
 	}
 }
 
+func TestDino_InjectStructByValue(t *testing.T) {
+	t.Parallel()
+
+	type App struct {
+		Name string
+	}
+
+	di := dino.New()
+
+	err := di.Inject(App{})
+	if !errors.Is(err, dino.ErrExpectedPointerToStruct) {
+		t.Fatalf("expected ErrExpectedPointerToStruct, got %v", err)
+	}
+
+	errMsg := "failed to inject dependencies: expected pointer to struct: " +
+		"got dino_test.App, pass a pointer to it instead"
+
+	if err.Error() != errMsg {
+		t.Fatalf("expected error message '%s', got '%s'", errMsg, err.Error())
+	}
+}
+
+func TestDino_InjectNilPointerToStruct(t *testing.T) {
+	t.Parallel()
+
+	type App struct {
+		Name string
+	}
+
+	var app *App
+
+	di := dino.New()
+
+	err := di.Inject(app)
+	if !errors.Is(err, dino.ErrInvalidInputValue) {
+		t.Fatalf("expected ErrInvalidInputValue, got %v", err)
+	}
+
+	errMsg := "failed to inject dependencies: invalid input value: inject target cannot be nil"
+
+	if err.Error() != errMsg {
+		t.Fatalf("expected error message '%s', got '%s'", errMsg, err.Error())
+	}
+}
+
 func TestDino_InjectUnregisteredSingleDependency(t *testing.T) {
 	t.Parallel()
 
@@ -1314,6 +1646,146 @@ func TestDino_InjectNestedDependency(t *testing.T) {
 	}
 }
 
+func TestDino_InjectMutuallyRecursiveStructs(t *testing.T) {
+	t.Parallel()
+
+	type Consumer struct {
+		A *RecursiveA
+	}
+
+	di := dino.New(dino.SkipCyclicFields())
+	consumer := new(Consumer)
+
+	if err := di.Inject(consumer); err != nil {
+		t.Fatalf("unexpected error during injection: %v", err)
+	}
+
+	if consumer.A == nil || consumer.A.B == nil {
+		t.Fatalf("expected RecursiveA and RecursiveB to be auto-created, got %v", consumer.A)
+	}
+
+	if consumer.A.B.A != nil {
+		t.Fatalf("expected RecursiveB.A to be left nil, got %v", consumer.A.B.A)
+	}
+}
+
+type CacheSharingMetrics struct {
+	Name string
+}
+
+type CacheSharingConsumer struct {
+	Metrics *CacheSharingMetrics
+}
+
+func TestDino_CacheSharingOnlyTagged(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		opts     []dino.Option
+		expected string
+	}{
+		{
+			name:     "Disabled",
+			opts:     nil,
+			expected: "",
+		},
+		{
+			name:     "Enabled",
+			opts:     []dino.Option{dino.WithCacheSharing()},
+			expected: "metrics",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			di := dino.New(tc.opts...)
+
+			err := di.Factory(func() *CacheSharingMetrics {
+				return &CacheSharingMetrics{Name: "metrics"}
+			}, "metrics")
+			if err != nil {
+				t.Fatalf("unexpected error during factory registration: %v", err)
+			}
+
+			if _, err := di.Resolve(reflect.TypeFor[*CacheSharingMetrics](), "metrics"); err != nil {
+				t.Fatalf("unexpected error from Resolve: %v", err)
+			}
+
+			consumer := new(CacheSharingConsumer)
+
+			if err := di.Inject(consumer); err != nil {
+				t.Fatalf("unexpected error during injection: %v", err)
+			}
+
+			if consumer.Metrics == nil || consumer.Metrics.Name != tc.expected {
+				t.Fatalf("expected metrics named '%s', got %v", tc.expected, consumer.Metrics)
+			}
+		})
+	}
+}
+
+func TestDino_CacheSharingTaggedAndUntagged(t *testing.T) {
+	t.Parallel()
+
+	di := dino.New(dino.WithCacheSharing())
+
+	if err := di.Singleton(&CacheSharingMetrics{Name: "untagged"}); err != nil {
+		t.Fatalf("unexpected error during singleton registration: %v", err)
+	}
+
+	err := di.Factory(func() *CacheSharingMetrics {
+		return &CacheSharingMetrics{Name: "metrics"}
+	}, "metrics")
+	if err != nil {
+		t.Fatalf("unexpected error during factory registration: %v", err)
+	}
+
+	if _, err := di.Resolve(reflect.TypeFor[*CacheSharingMetrics](), "metrics"); err != nil {
+		t.Fatalf("unexpected error from Resolve: %v", err)
+	}
+
+	consumer := new(CacheSharingConsumer)
+
+	if err := di.Inject(consumer); err != nil {
+		t.Fatalf("unexpected error during injection: %v", err)
+	}
+
+	if consumer.Metrics == nil || consumer.Metrics.Name != "untagged" {
+		t.Fatalf("expected explicit untagged registration to take precedence, got %v", consumer.Metrics)
+	}
+}
+
+func TestDino_CacheSharingOnlyUntagged(t *testing.T) {
+	t.Parallel()
+
+	di := dino.New(dino.WithCacheSharing())
+
+	err := di.Factory(func() *CacheSharingMetrics {
+		return &CacheSharingMetrics{Name: "untagged"}
+	})
+	if err != nil {
+		t.Fatalf("unexpected error during factory registration: %v", err)
+	}
+
+	consumer := new(CacheSharingConsumer)
+
+	if err := di.Inject(consumer); err != nil {
+		t.Fatalf("unexpected error during injection: %v", err)
+	}
+
+	if consumer.Metrics == nil || consumer.Metrics.Name != "untagged" {
+		t.Fatalf("expected untagged metrics, got %v", consumer.Metrics)
+	}
+
+	_, err = di.Resolve(reflect.TypeFor[*CacheSharingMetrics](), "metrics")
+	if !errors.Is(err, dino.ErrValueNotFound) {
+		t.Fatalf("expected untagged result not to be shared under a tag, got %v", err)
+	}
+}
+
 func TestDino_InjectConcurrentAccess(t *testing.T) {
 	t.Parallel()
 
@@ -1419,8 +1891,9 @@ func TestDino_InvokeError(t *testing.T) {
 	t.Parallel()
 
 	key := dino.RegistryKey{
-		Tag:  "",
-		Type: reflect.TypeFor[int](),
+		Tag:   "",
+		Type:  reflect.TypeFor[int](),
+		Scope: "",
 	}
 
 	registry := new(dino.SyncMapRegistry)
@@ -1429,8 +1902,13 @@ func TestDino_InvokeError(t *testing.T) {
 	di := dino.New().WithRegistry(registry)
 
 	results, err := di.Invoke(func(i int) int { return i })
-	if err == nil {
-		t.Fatalf("expected error from Invoke, got nil")
+	if !errors.Is(err, dino.ErrInvokeFailed) {
+		t.Fatalf("expected ErrInvokeFailed, got %v", err)
+	}
+
+	// The container failed to wire the arguments, no factory did
+	if errors.Is(err, dino.ErrFactoryFailed) {
+		t.Fatalf("expected no ErrFactoryFailed, got %v", err)
 	}
 
 	if !strings.Contains(err.Error(), "failed to invoke function:") {
@@ -1725,6 +2203,65 @@ func TestDino_InvokeWithUnregisteredDependency(t *testing.T) {
 	}
 }
 
+func TestDino_InvokeAggregateErrors(t *testing.T) {
+	t.Parallel()
+
+	type Database struct{}
+
+	type Cache struct{}
+
+	errDatabase := errors.New("database unavailable")
+	errCache := errors.New("cache unavailable")
+
+	testCases := []struct {
+		name      string
+		opts      []dino.Option
+		wantCache bool
+	}{
+		{
+			name:      "First error only",
+			opts:      nil,
+			wantCache: false,
+		},
+		{
+			name:      "All errors",
+			opts:      []dino.Option{dino.AggregateErrors()},
+			wantCache: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			di := dino.New(tc.opts...)
+
+			if err := di.Factory(func() (*Database, error) { return nil, errDatabase }); err != nil {
+				t.Fatalf("unexpected error during factory registration: %v", err)
+			}
+
+			if err := di.Factory(func() (*Cache, error) { return nil, errCache }); err != nil {
+				t.Fatalf("unexpected error during factory registration: %v", err)
+			}
+
+			called := false
+
+			_, err := di.Invoke(func(*Database, string, *Cache) { called = true })
+			if !errors.Is(err, errDatabase) {
+				t.Fatalf("expected database error, got %v", err)
+			}
+
+			if errors.Is(err, errCache) != tc.wantCache {
+				t.Fatalf("expected cache error reported: %v, got %v", tc.wantCache, err)
+			}
+
+			if called {
+				t.Fatal("expected function not to be called")
+			}
+		})
+	}
+}
+
 func TestDino_InvokeWithNestedFunctionDependencies(t *testing.T) {
 	t.Parallel()
 
@@ -1771,12 +2308,73 @@ func TestDino_InvokeWithNestedFunctionDependencies(t *testing.T) {
 	}
 }
 
-func TestDino_InvokeConcurrentAccess(t *testing.T) {
-	t.Parallel()
+type LazyPinger interface {
+	Ping() string
+}
 
-	type Service struct {
-		Value string
-	}
+type LazyPonger interface {
+	Pong() string
+}
+
+type lazyPinger struct {
+	ponger LazyPonger
+}
+
+func (p *lazyPinger) Ping() string {
+	return "ping " + p.ponger.Pong()
+}
+
+type lazyPonger struct {
+	pinger func() LazyPinger
+}
+
+func (p *lazyPonger) Pong() string {
+	return "pong"
+}
+
+func TestDino_InvokeInterfaceCycleWithProvider(t *testing.T) {
+	t.Parallel()
+
+	di := dino.New()
+
+	if err := di.Factory(func(ponger LazyPonger) LazyPinger { return &lazyPinger{ponger: ponger} }); err != nil {
+		t.Fatalf("unexpected error during factory registration: %v", err)
+	}
+
+	if err := di.Factory(func(pinger func() LazyPinger) LazyPonger { return &lazyPonger{pinger: pinger} }); err != nil {
+		t.Fatalf("unexpected error during factory registration: %v", err)
+	}
+
+	results, err := di.Invoke(func(pinger LazyPinger) LazyPinger { return pinger })
+	if err != nil {
+		t.Fatalf("unexpected error from Invoke: %v", err)
+	}
+
+	pinger, ok := results[0].(*lazyPinger)
+	if !ok {
+		t.Fatalf("expected result to be of type *lazyPinger, got %T", results[0])
+	}
+
+	if pinger.Ping() != "ping pong" {
+		t.Fatalf("expected 'ping pong', got '%s'", pinger.Ping())
+	}
+
+	ponger, ok := pinger.ponger.(*lazyPonger)
+	if !ok {
+		t.Fatalf("expected ponger to be of type *lazyPonger, got %T", pinger.ponger)
+	}
+
+	if ponger.pinger() != pinger {
+		t.Fatalf("expected lazy provider to return the constructed pinger")
+	}
+}
+
+func TestDino_InvokeConcurrentAccess(t *testing.T) {
+	t.Parallel()
+
+	type Service struct {
+		Value string
+	}
 
 	srv := &Service{
 		Value: "test",
@@ -1825,3 +2423,470 @@ func TestDino_InvokeConcurrentAccess(t *testing.T) {
 		}
 	}
 }
+
+func TestDino_InvokeConcurrentFactoryCalledOnce(t *testing.T) {
+	t.Parallel()
+
+	type Service struct {
+		Value string
+	}
+
+	type Handler struct {
+		Service *Service
+	}
+
+	var calls atomic.Int32
+
+	di := dino.New()
+
+	if err := di.Factory(func() *Service {
+		calls.Add(1)
+
+		return &Service{Value: "test"}
+	}); err != nil {
+		t.Fatalf("unexpected error during factory registration: %v", err)
+	}
+
+	if err := di.Factory(func(srv *Service) *Handler {
+		return &Handler{Service: srv}
+	}); err != nil {
+		t.Fatalf("unexpected error during factory registration: %v", err)
+	}
+
+	handlers := make([]*Handler, 100)
+	wg := sync.WaitGroup{}
+
+	for idx := range 100 {
+		wg.Go(func() {
+			if _, err := di.Invoke(func(handler *Handler) {
+				handlers[idx] = handler
+			}); err != nil {
+				t.Errorf("unexpected error from Invoke: %v", err)
+			}
+		})
+	}
+
+	wg.Wait()
+
+	if got := calls.Load(); got != 1 {
+		t.Fatalf("expected the factory to be called once, got %d calls", got)
+	}
+
+	for idx, handler := range handlers {
+		if handler != handlers[0] {
+			t.Fatalf("expected goroutine %d to get the cached handler", idx)
+		}
+	}
+}
+
+func TestDino_ResolveNilType(t *testing.T) {
+	t.Parallel()
+
+	di := dino.New()
+
+	val, err := di.Resolve(nil)
+	if !errors.Is(err, dino.ErrInvalidInputValue) {
+		t.Fatalf("expected ErrInvalidInputValue, got %v", err)
+	}
+
+	if !strings.Contains(err.Error(), "type to resolve cannot be nil") {
+		t.Fatalf(
+			"expected error message to contain 'type to resolve cannot be nil', got %s",
+			err.Error(),
+		)
+	}
+
+	if val != nil {
+		t.Fatalf("expected value to be nil, got %v", val)
+	}
+}
+
+func TestDino_ResolveSingleton(t *testing.T) {
+	t.Parallel()
+
+	type Service struct {
+		Value string
+	}
+
+	srv := &Service{
+		Value: "singleton",
+	}
+
+	di := dino.New()
+
+	if err := di.Singleton(srv); err != nil {
+		t.Fatalf("unexpected error during singleton registration: %v", err)
+	}
+
+	val, err := di.Resolve(reflect.TypeFor[*Service]())
+	if err != nil {
+		t.Fatalf("unexpected error from Resolve: %v", err)
+	}
+
+	if val != srv {
+		t.Fatalf("expected resolved value to be %v, got %v", srv, val)
+	}
+}
+
+func TestDino_ResolveFactory(t *testing.T) {
+	t.Parallel()
+
+	type Config struct {
+		Name string
+	}
+
+	type Service struct {
+		Config *Config
+	}
+
+	cfg := &Config{
+		Name: "config",
+	}
+
+	di := dino.New()
+
+	if err := di.Singleton(cfg); err != nil {
+		t.Fatalf("unexpected error during singleton registration: %v", err)
+	}
+
+	if err := di.Factory(func(c *Config) *Service { return &Service{Config: c} }); err != nil {
+		t.Fatalf("unexpected error during factory registration: %v", err)
+	}
+
+	first, err := di.Resolve(reflect.TypeFor[*Service]())
+	if err != nil {
+		t.Fatalf("unexpected error from Resolve: %v", err)
+	}
+
+	srv, ok := first.(*Service)
+	if !ok {
+		t.Fatalf("expected resolved value to be of type *Service, got %T", first)
+	}
+
+	if srv.Config != cfg {
+		t.Fatalf("expected factory dependency to be %v, got %v", cfg, srv.Config)
+	}
+
+	second, err := di.Resolve(reflect.TypeFor[*Service]())
+	if err != nil {
+		t.Fatalf("unexpected error from Resolve: %v", err)
+	}
+
+	if second != first {
+		t.Fatalf("expected factory result to be reused, got %v and %v", first, second)
+	}
+}
+
+func TestDino_ResolveTagged(t *testing.T) {
+	t.Parallel()
+
+	di := dino.New()
+
+	if err := di.Singleton("primary-db", "primary"); err != nil {
+		t.Fatalf("unexpected error during singleton registration: %v", err)
+	}
+
+	if err := di.Singleton("replica-db", "replica"); err != nil {
+		t.Fatalf("unexpected error during singleton registration: %v", err)
+	}
+
+	val, err := di.Resolve(reflect.TypeFor[string](), "replica", "ignored")
+	if err != nil {
+		t.Fatalf("unexpected error from Resolve: %v", err)
+	}
+
+	if val != "replica-db" {
+		t.Fatalf("expected resolved value to be 'replica-db', got %v", val)
+	}
+
+	if _, err := di.Resolve(reflect.TypeFor[string]()); !errors.Is(err, dino.ErrValueNotFound) {
+		t.Fatalf("expected ErrValueNotFound for empty tag, got %v", err)
+	}
+}
+
+func TestDino_ResolveMissing(t *testing.T) {
+	t.Parallel()
+
+	type Service struct{}
+
+	di := dino.New()
+
+	val, err := di.Resolve(reflect.TypeFor[*Service](), "missing")
+	if !errors.Is(err, dino.ErrValueNotFound) {
+		t.Fatalf("expected ErrValueNotFound, got %v", err)
+	}
+
+	if !strings.Contains(err.Error(), "failed to resolve dependency:") {
+		t.Fatalf(
+			"expected error message to contain 'failed to resolve dependency:', got %s",
+			err.Error(),
+		)
+	}
+
+	if val != nil {
+		t.Fatalf("expected value to be nil, got %v", val)
+	}
+}
+
+type BenchA struct{}
+
+type BenchB struct{}
+
+type BenchC struct{}
+
+type BenchD struct{}
+
+type BenchE struct{}
+
+type BenchF struct{}
+
+type BenchG struct{}
+
+type BenchH struct{}
+
+type BenchTarget struct {
+	A *BenchA
+	B *BenchB
+	C *BenchC
+	D *BenchD
+	E *BenchE
+	F *BenchF
+	G *BenchG
+	H *BenchH
+}
+
+func newBenchContainer(b *testing.B) *dino.Dino {
+	b.Helper()
+
+	di := dino.New()
+
+	for _, singleton := range []any{
+		&BenchA{}, &BenchB{}, &BenchC{}, &BenchD{}, &BenchE{}, &BenchF{}, &BenchG{}, &BenchH{},
+	} {
+		if err := di.Singleton(singleton); err != nil {
+			b.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	return di
+}
+
+func BenchmarkInvokeSmall(b *testing.B) {
+	di := newBenchContainer(b)
+	fn := func(*BenchA) {}
+
+	b.ReportAllocs()
+
+	for b.Loop() {
+		if _, err := di.Invoke(fn); err != nil {
+			b.Fatalf("unexpected error: %v", err)
+		}
+	}
+}
+
+func BenchmarkInvokeFiveArgs(b *testing.B) {
+	di := newBenchContainer(b)
+	fn := func(*BenchA, *BenchB, *BenchC, *BenchD, *BenchE) {}
+
+	b.ReportAllocs()
+
+	for b.Loop() {
+		if _, err := di.Invoke(fn); err != nil {
+			b.Fatalf("unexpected error: %v", err)
+		}
+	}
+}
+
+func BenchmarkInjectEightFields(b *testing.B) {
+	di := newBenchContainer(b)
+	target := new(BenchTarget)
+
+	b.ReportAllocs()
+
+	for b.Loop() {
+		// Inject keeps fields already set, every iteration starts from an empty target
+		*target = BenchTarget{}
+
+		if err := di.Inject(target); err != nil {
+			b.Fatalf("unexpected error: %v", err)
+		}
+	}
+}
+
+func BenchmarkInvokeParallel(b *testing.B) {
+	for _, goroutines := range []int{1, 8, 32} {
+		b.Run(fmt.Sprintf("goroutines=%d", goroutines), func(b *testing.B) {
+			di := newBenchContainer(b)
+			fn := func(*BenchA, *BenchB, *BenchC) {}
+
+			var wg sync.WaitGroup
+
+			calls := b.N/goroutines + 1
+
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for range goroutines {
+				wg.Go(func() {
+					for range calls {
+						if _, err := di.Invoke(fn); err != nil {
+							b.Errorf("unexpected error: %v", err)
+
+							return
+						}
+					}
+				})
+			}
+
+			wg.Wait()
+		})
+	}
+}
+
+func TestDino_InvalidInput(t *testing.T) {
+	t.Parallel()
+
+	var (
+		nilFunc    func() *BenchA
+		nilPointer *BenchA
+		nilError   error
+	)
+
+	entryPoints := map[string]func(di *dino.Dino, input any) error{
+		"Factory": func(di *dino.Dino, input any) error {
+			return di.Factory(input)
+		},
+		"Singleton": func(di *dino.Dino, input any) error {
+			return di.Singleton(input)
+		},
+		"Inject": func(di *dino.Dino, input any) error {
+			return di.Inject(input)
+		},
+		"Invoke": func(di *dino.Dino, input any) error {
+			_, err := di.Invoke(input)
+
+			return err
+		},
+	}
+
+	// Every invalid target is reported in the same format, whatever the check it fails
+	injectNil := "failed to inject dependencies: invalid input value: inject target cannot be nil"
+
+	tests := []struct {
+		name       string
+		entryPoint string
+		input      any
+		message    string
+	}{
+		{name: "untyped nil", entryPoint: "Factory", input: nil, message: "factory function cannot be nil"},
+		{name: "typed nil", entryPoint: "Factory", input: nilFunc, message: "factory function cannot be nil"},
+		{name: "nil error", entryPoint: "Factory", input: nilError, message: "factory function cannot be nil"},
+		{name: "wrong kind", entryPoint: "Factory", input: 42, message: "factory expected a function, got int"},
+		{name: "untyped nil", entryPoint: "Singleton", input: nil, message: "singleton value cannot be nil"},
+		{name: "typed nil", entryPoint: "Singleton", input: nilPointer, message: "singleton value cannot be nil"},
+		{name: "nil func", entryPoint: "Singleton", input: nilFunc, message: "singleton value cannot be nil"},
+		{name: "untyped nil", entryPoint: "Inject", input: nil, message: injectNil},
+		{name: "typed nil", entryPoint: "Inject", input: nilPointer, message: injectNil},
+		{name: "nil func", entryPoint: "Inject", input: nilFunc, message: injectNil},
+		{name: "wrong kind", entryPoint: "Inject", input: 42, message: "failed to inject dependencies: " +
+			"invalid input value: expected struct or pointer to struct: got int"},
+		{name: "pointer to int", entryPoint: "Inject", input: new(int), message: "failed to inject dependencies: " +
+			"invalid input value: expected struct or pointer to struct: got *int"},
+		{name: "untyped nil", entryPoint: "Invoke", input: nil, message: "function to invoke cannot be nil"},
+		{name: "typed nil", entryPoint: "Invoke", input: nilFunc, message: "function to invoke cannot be nil"},
+		{name: "wrong kind", entryPoint: "Invoke", input: "run", message: "invoke expected a function, got string"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.entryPoint+" "+tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			di := dino.New()
+
+			err := entryPoints[tt.entryPoint](di, tt.input)
+			if !errors.Is(err, dino.ErrInvalidInputValue) {
+				t.Fatalf("expected ErrInvalidInputValue, got %v", err)
+			}
+
+			if !strings.Contains(err.Error(), tt.message) {
+				t.Fatalf("expected error message to contain %q, got %s", tt.message, err.Error())
+			}
+
+			// Rejected input leaves nothing behind
+			if keys, _ := di.Order(); len(keys) != 0 {
+				t.Fatalf("expected no registrations, got %v", keys)
+			}
+		})
+	}
+}
+
+type IdempotentDB struct {
+	DSN string
+}
+
+type IdempotentCache struct {
+	Size int
+}
+
+type IdempotentRepo struct {
+	DB    *IdempotentDB
+	Cache *IdempotentCache `inject:",optional"`
+}
+
+type IdempotentApp struct {
+	DB    *IdempotentDB
+	Repo  *IdempotentRepo
+	Cache *IdempotentCache `inject:",optional"`
+}
+
+func TestDino_InjectIdempotent(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	di := dino.New()
+
+	if err := di.Factory(func() *IdempotentDB {
+		calls.Add(1)
+
+		return &IdempotentDB{DSN: "postgres"}
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var app IdempotentApp
+
+	if err := di.Inject(&app); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if app.Repo == nil || app.Repo.DB != app.DB || app.Cache != nil || app.Repo.Cache != nil {
+		t.Fatalf("unexpected first injection: %+v", app)
+	}
+
+	db, repo := app.DB, app.Repo
+
+	// A caller-set field is kept as well
+	owned := &IdempotentDB{DSN: "owned"}
+	app.Repo.DB = owned
+
+	if err := di.Singleton(&IdempotentCache{Size: 64}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := di.Inject(&app); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if app.DB != db || app.Repo != repo || app.Repo.DB != owned {
+		t.Errorf("expected previously set pointers to be unchanged, got %+v", app)
+	}
+
+	if app.Cache == nil || app.Repo.Cache != app.Cache {
+		t.Errorf("expected the new dependency to be injected, got %+v and %+v", app.Cache, app.Repo.Cache)
+	}
+
+	if calls.Load() != 1 {
+		t.Errorf("expected the factory to be called once, got %d", calls.Load())
+	}
+}
@@ -0,0 +1,256 @@
+// Package dinotest provides a mock registry to test code built on a dino container without real
+// dependencies. The mock records every call and returns the results programmed by the test:
+//
+//	registry := dinotest.NewMockRegistry()
+//	registry.RegisterOut = []error{errFull}
+//	registry.ExpectRegister(key).Times(1)
+//
+//	di := dino.New(dino.WithRegistry(registry))
+//	// ...
+//	registry.AssertExpectations(t)
+package dinotest
+
+import (
+	"fmt"
+	"iter"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/yuppyweb/dino"
+)
+
+// RegisterCall is a recorded call to Register.
+type RegisterCall struct {
+	Key   dino.RegistryKey
+	Value reflect.Value
+}
+
+// FindResult is a programmed result of Find.
+type FindResult struct {
+	Value reflect.Value
+	Err   error
+}
+
+// MockRegistry is a dino.Registry recording its calls in the On fields and returning the results
+// programmed in the Out fields, one per call in order. Once the programmed results are used up,
+// Register and Delete succeed, Find reports dino.ErrValueNotFound and FindByType finds nothing.
+// The mock stores nothing: RegisterIfAbsent returns the given value as not loaded, and All is empty.
+// It is safe for concurrent use, but the fields must not be accessed while it is in use.
+type MockRegistry struct {
+	RegisterOn    []RegisterCall
+	RegisterOut   []error
+	FindOn        []dino.RegistryKey
+	FindOut       []FindResult
+	FindByTypeOn  []reflect.Type
+	FindByTypeOut [][]dino.RegistryKey
+	DeleteOn      []dino.RegistryKey
+	DeleteOut     []error
+	IfAbsentOn    []dino.RegistryKey
+
+	mutex        sync.Mutex
+	expectations []*Expectation
+}
+
+var _ dino.Registry = (*MockRegistry)(nil)
+
+// NewMockRegistry creates a mock registry without programmed results.
+func NewMockRegistry() *MockRegistry {
+	return &MockRegistry{
+		RegisterOn:    []RegisterCall{},
+		RegisterOut:   []error{},
+		FindOn:        []dino.RegistryKey{},
+		FindOut:       []FindResult{},
+		FindByTypeOn:  []reflect.Type{},
+		FindByTypeOut: [][]dino.RegistryKey{},
+		DeleteOn:      []dino.RegistryKey{},
+		DeleteOut:     []error{},
+		IfAbsentOn:    []dino.RegistryKey{},
+		mutex:         sync.Mutex{},
+		expectations:  nil,
+	}
+}
+
+// Register records the call and returns the next programmed error.
+func (m *MockRegistry) Register(key dino.RegistryKey, value reflect.Value) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.RegisterOn = append(m.RegisterOn, RegisterCall{
+		Key:   key,
+		Value: value,
+	})
+
+	return next(m.RegisterOut, len(m.RegisterOn), nil)
+}
+
+// RegisterIfAbsent records the call and returns the value as not loaded.
+func (m *MockRegistry) RegisterIfAbsent(key dino.RegistryKey, value reflect.Value) (reflect.Value, bool, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.IfAbsentOn = append(m.IfAbsentOn, key)
+
+	return value, false, nil
+}
+
+// Find records the call and returns the next programmed result.
+func (m *MockRegistry) Find(key dino.RegistryKey) (reflect.Value, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.FindOn = append(m.FindOn, key)
+
+	notFound := FindResult{
+		Value: reflect.Value{},
+		Err:   dino.ErrValueNotFound,
+	}
+
+	result := next(m.FindOut, len(m.FindOn), notFound)
+
+	return result.Value, result.Err
+}
+
+// FindByType records the call and returns the next programmed keys.
+func (m *MockRegistry) FindByType(rt reflect.Type) []dino.RegistryKey {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.FindByTypeOn = append(m.FindByTypeOn, rt)
+
+	return next(m.FindByTypeOut, len(m.FindByTypeOn), nil)
+}
+
+// FindAssignableTo finds nothing.
+func (m *MockRegistry) FindAssignableTo(reflect.Type) []dino.RegistryKey {
+	return nil
+}
+
+// Delete records the call and returns the next programmed error.
+func (m *MockRegistry) Delete(key dino.RegistryKey) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.DeleteOn = append(m.DeleteOn, key)
+
+	return next(m.DeleteOut, len(m.DeleteOn), nil)
+}
+
+// DeleteAll deletes nothing.
+func (m *MockRegistry) DeleteAll(reflect.Type) error {
+	return nil
+}
+
+// All yields nothing.
+func (m *MockRegistry) All() iter.Seq2[dino.RegistryKey, reflect.Value] {
+	return func(func(dino.RegistryKey, reflect.Value) bool) {}
+}
+
+// next returns the programmed result of the call with the number, counting from 1, or the fallback
+// once the results are used up.
+func next[T any](results []T, call int, fallback T) T {
+	if call > len(results) {
+		return fallback
+	}
+
+	return results[call-1]
+}
+
+// Expectation is a call the test expects the mock registry to receive, checked by AssertExpectations.
+type Expectation struct {
+	method string
+	key    dino.RegistryKey
+	times  int
+}
+
+// Times expects exactly n calls instead of at least one.
+func (e *Expectation) Times(n int) *Expectation {
+	e.times = n
+
+	return e
+}
+
+// ExpectRegister expects Register to be called with the key.
+func (m *MockRegistry) ExpectRegister(key dino.RegistryKey) *Expectation {
+	return m.expect("Register", key)
+}
+
+// ExpectFind expects Find to be called with the key.
+func (m *MockRegistry) ExpectFind(key dino.RegistryKey) *Expectation {
+	return m.expect("Find", key)
+}
+
+// ExpectDelete expects Delete to be called with the key.
+func (m *MockRegistry) ExpectDelete(key dino.RegistryKey) *Expectation {
+	return m.expect("Delete", key)
+}
+
+// expect adds an expectation of at least one call of the method with the key.
+func (m *MockRegistry) expect(method string, key dino.RegistryKey) *Expectation {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	exp := &Expectation{
+		method: method,
+		key:    key,
+		times:  -1,
+	}
+
+	m.expectations = append(m.expectations, exp)
+
+	return exp
+}
+
+// AssertExpectations fails the test for every expectation not met by the calls recorded so far.
+func (m *MockRegistry) AssertExpectations(tb testing.TB) {
+	tb.Helper()
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	for _, exp := range m.expectations {
+		calls := m.calls(exp.method, exp.key)
+
+		switch {
+		case exp.times < 0 && calls == 0:
+			tb.Errorf("dinotest: expected %s to be called with %s, got no calls", exp.method, keyName(exp.key))
+
+		case exp.times >= 0 && calls != exp.times:
+			tb.Errorf("dinotest: expected %s to be called %d times with %s, got %d calls",
+				exp.method, exp.times, keyName(exp.key), calls)
+		}
+	}
+}
+
+// calls counts the recorded calls of the method with the key.
+func (m *MockRegistry) calls(method string, key dino.RegistryKey) int {
+	var keys []dino.RegistryKey
+
+	switch method {
+	case "Register":
+		for _, call := range m.RegisterOn {
+			keys = append(keys, call.Key)
+		}
+
+	case "Find":
+		keys = m.FindOn
+
+	case "Delete":
+		keys = m.DeleteOn
+	}
+
+	count := 0
+
+	for _, called := range keys {
+		if called == key {
+			count++
+		}
+	}
+
+	return count
+}
+
+// keyName describes the key in failure messages.
+func keyName(key dino.RegistryKey) string {
+	return fmt.Sprintf("%v with tag '%s'", key.Type, key.Tag)
+}
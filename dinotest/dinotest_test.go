@@ -0,0 +1,142 @@
+package dinotest_test
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/yuppyweb/dino"
+	"github.com/yuppyweb/dino/dinotest"
+)
+
+var errRegistryFull = errors.New("registry full")
+
+type Clock struct{}
+
+type Service struct {
+	Clock *Clock
+}
+
+// errorTB records the errors reported by an assertion instead of failing the test.
+type errorTB struct {
+	testing.TB
+
+	errors []string
+}
+
+func (tb *errorTB) Helper() {}
+
+func (tb *errorTB) Errorf(format string, args ...any) {
+	tb.errors = append(tb.errors, fmt.Sprintf(format, args...))
+}
+
+func clockKey(tag string) dino.RegistryKey {
+	return dino.RegistryKey{Tag: tag, Type: reflect.TypeFor[*Clock](), Scope: ""}
+}
+
+func TestMockRegistry_RecordsCalls(t *testing.T) {
+	t.Parallel()
+
+	registry := dinotest.NewMockRegistry()
+	di := dino.New(dino.WithRegistry(registry))
+
+	if err := di.Singleton(&Clock{}, "utc"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(registry.RegisterOn) != 1 || registry.RegisterOn[0].Key != clockKey("utc") {
+		t.Fatalf("expected the singleton to be registered, got %v", registry.RegisterOn)
+	}
+
+	// Nothing is stored: the clock is created and injected, never found
+	if err := di.Inject(new(Service)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(registry.FindOn) == 0 || registry.FindOn[0] != clockKey("") {
+		t.Fatalf("expected the untagged clock to be looked up, got %v", registry.FindOn)
+	}
+}
+
+func TestMockRegistry_ProgrammedResults(t *testing.T) {
+	t.Parallel()
+
+	clock := &Clock{}
+
+	registry := dinotest.NewMockRegistry()
+	registry.RegisterOut = []error{errRegistryFull}
+	registry.FindOut = []dinotest.FindResult{{Value: reflect.ValueOf(clock), Err: nil}}
+	registry.FindByTypeOut = [][]dino.RegistryKey{{clockKey("utc")}}
+	registry.DeleteOut = []error{dino.ErrValueNotFound}
+
+	if err := registry.Register(clockKey(""), reflect.ValueOf(clock)); !errors.Is(err, errRegistryFull) {
+		t.Fatalf("expected the programmed error, got %v", err)
+	}
+
+	if err := registry.Register(clockKey(""), reflect.ValueOf(clock)); err != nil {
+		t.Fatalf("expected registrations to succeed once the errors are used up, got %v", err)
+	}
+
+	if rv, err := registry.Find(clockKey("")); err != nil || rv.Interface() != clock {
+		t.Fatalf("expected the programmed clock, got %v, %v", rv, err)
+	}
+
+	if _, err := registry.Find(clockKey("")); !errors.Is(err, dino.ErrValueNotFound) {
+		t.Fatalf("expected ErrValueNotFound once the results are used up, got %v", err)
+	}
+
+	if keys := registry.FindByType(reflect.TypeFor[*Clock]()); len(keys) != 1 || keys[0] != clockKey("utc") {
+		t.Fatalf("expected the programmed keys, got %v", keys)
+	}
+
+	if err := registry.Delete(clockKey("")); !errors.Is(err, dino.ErrValueNotFound) {
+		t.Fatalf("expected the programmed error, got %v", err)
+	}
+}
+
+func TestMockRegistry_ErrorThroughContainer(t *testing.T) {
+	t.Parallel()
+
+	registry := dinotest.NewMockRegistry()
+	registry.RegisterOut = []error{errRegistryFull}
+
+	di := dino.New(dino.WithRegistry(registry))
+
+	if err := di.Singleton(&Clock{}); !errors.Is(err, errRegistryFull) {
+		t.Fatalf("expected the programmed error, got %v", err)
+	}
+}
+
+func TestMockRegistry_AssertExpectations(t *testing.T) {
+	t.Parallel()
+
+	registry := dinotest.NewMockRegistry()
+	registry.ExpectRegister(clockKey("utc")).Times(1)
+	registry.ExpectFind(clockKey(""))
+	registry.ExpectDelete(clockKey("utc"))
+
+	di := dino.New(dino.WithRegistry(registry))
+
+	for range 2 {
+		if err := di.Singleton(&Clock{}, "utc"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if err := di.Inject(new(Service)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tb := &errorTB{TB: t, errors: nil}
+	registry.AssertExpectations(tb)
+
+	want := []string{
+		"dinotest: expected Register to be called 1 times with *dinotest_test.Clock with tag 'utc', got 2 calls",
+		"dinotest: expected Delete to be called with *dinotest_test.Clock with tag 'utc', got no calls",
+	}
+
+	if !reflect.DeepEqual(tb.errors, want) {
+		t.Fatalf("expected the unmet expectations to be reported, got %q", tb.errors)
+	}
+}
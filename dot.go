@@ -0,0 +1,53 @@
+package dino
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// WriteDOT writes the dependency graph of the container in the Graphviz DOT language, e.g. for
+// rendering with dot -Tsvg. Nodes and edges are the same as in WriteMermaid: interfaces are drawn as
+// ellipses, concrete types as boxes, and edges point from a factory result to its dependencies,
+// labelled with the dependency tag, if any. Factories are never called.
+func (d *Dino) WriteDOT(w io.Writer) error {
+	g := d.graph()
+
+	var out strings.Builder
+
+	out.WriteString("digraph dino {\n")
+	out.WriteString("    node [shape=box];\n")
+
+	if g.cycle != nil {
+		fmt.Fprintf(&out, "    // %s\n", g.cycle)
+	}
+
+	for idx, key := range g.nodes {
+		if isInterfaceKey(key) {
+			fmt.Fprintf(&out, "    n%d [label=%s, shape=ellipse];\n", idx, dotQuote(key.Type.String()))
+		} else {
+			fmt.Fprintf(&out, "    n%d [label=%s];\n", idx, dotQuote(key.Type.String()))
+		}
+	}
+
+	for _, edge := range g.edges {
+		if tag := g.nodes[edge.to].Tag; tag != "" {
+			fmt.Fprintf(&out, "    n%d -> n%d [label=%s];\n", edge.from, edge.to, dotQuote(tag))
+		} else {
+			fmt.Fprintf(&out, "    n%d -> n%d;\n", edge.from, edge.to)
+		}
+	}
+
+	out.WriteString("}\n")
+
+	if _, err := io.WriteString(w, out.String()); err != nil {
+		return fmt.Errorf("write dot graph: %w", err)
+	}
+
+	return nil
+}
+
+// dotQuote quotes the text as a DOT string, escaping quotes and backslashes.
+func dotQuote(text string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(text) + `"`
+}
@@ -0,0 +1,101 @@
+package dino_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/yuppyweb/dino"
+)
+
+func TestDino_WriteDOT(t *testing.T) {
+	t.Parallel()
+
+	di := dino.New()
+
+	if err := di.Singleton(&MermaidConfig{}, "primary"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	factories := []any{
+		func() MermaidLogger { return nil },
+		func(MermaidParams) *MermaidRepository { return &MermaidRepository{} },
+		func(*MermaidRepository, MermaidLogger) *MermaidService { return &MermaidService{} },
+	}
+
+	for _, factory := range factories {
+		if err := di.Factory(factory); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	var out strings.Builder
+
+	if err := di.WriteDOT(&out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := `digraph dino {
+    node [shape=box];
+    n0 [label="*dino_test.MermaidConfig"];
+    n1 [label="*dino_test.MermaidRepository"];
+    n2 [label="*dino_test.MermaidService"];
+    n3 [label="dino_test.MermaidLogger", shape=ellipse];
+    n1 -> n0 [label="primary"];
+    n1 -> n3;
+    n2 -> n1;
+    n2 -> n3;
+}
+`
+
+	if out.String() != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, out.String())
+	}
+}
+
+func TestDino_WriteDOTCycle(t *testing.T) {
+	t.Parallel()
+
+	di := dino.New()
+
+	if err := di.Factory(func(*MermaidB) *MermaidA { return &MermaidA{} }); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := di.Factory(func(*MermaidA) *MermaidB { return &MermaidB{} }); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var out strings.Builder
+
+	if err := di.WriteDOT(&out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := `digraph dino {
+    node [shape=box];
+    // circular dependency detected between *dino_test.MermaidA, *dino_test.MermaidB
+    n0 [label="*dino_test.MermaidA"];
+    n1 [label="*dino_test.MermaidB"];
+    n0 -> n1;
+    n1 -> n0;
+}
+`
+
+	if out.String() != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, out.String())
+	}
+}
+
+func TestDino_WriteDOTWriteError(t *testing.T) {
+	t.Parallel()
+
+	di := dino.New()
+
+	if err := di.Singleton(&MermaidConfig{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := di.WriteDOT(failingWriter{}); err == nil {
+		t.Fatalf("expected write error, got %v", err)
+	}
+}
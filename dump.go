@@ -0,0 +1,77 @@
+package dino
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// Dump writes one line per registry entry: the key type, its tag, scope and qualifier, and whether the stored value
+// is a factory function (with its signature) or an instance. Entries are sorted by type name and tag.
+// Factories are never called.
+func Dump(w io.Writer, registry Registry) error {
+	entries := maps.Collect(registry.All())
+
+	keys := slices.Collect(maps.Keys(entries))
+	sortKeys(keys)
+
+	for _, key := range keys {
+		if _, err := fmt.Fprintln(w, dumpLine(key, entries[key])); err != nil {
+			return fmt.Errorf("dump registry entry %s: %w", key.Type, err)
+		}
+	}
+
+	return nil
+}
+
+// dumpLine describes a single registry entry.
+func dumpLine(key RegistryKey, rv reflect.Value) string {
+	var line strings.Builder
+
+	fmt.Fprintf(&line, "%s tag=%q", key.Type, key.Tag)
+
+	if key.Scope != "" {
+		fmt.Fprintf(&line, " scope=%q", key.Scope)
+	}
+
+	if key.Qualifier != nil {
+		fmt.Fprintf(&line, " qualifier=%#v", key.Qualifier)
+	}
+
+	switch {
+	case !rv.IsValid():
+		line.WriteString(" invalid")
+	case isFactory(key, rv):
+		fmt.Fprintf(&line, " factory %s", rv.Type())
+	default:
+		fmt.Fprintf(&line, " instance %s", rv.Type())
+	}
+
+	return line.String()
+}
+
+// Dump writes one line per registry entry, see Dump.
+func (r *SyncMapRegistry) Dump(w io.Writer) error {
+	return Dump(w, r)
+}
+
+// Dump writes one line per registry entry, see Dump.
+func (r *MapRegistry) Dump(w io.Writer) error {
+	return Dump(w, r)
+}
+
+// Dump writes one line per registry entry, see Dump.
+func (r *ShardedRegistry) Dump(w io.Writer) error {
+	return Dump(w, r)
+}
+
+// Dump writes one line per dependency registered in the container, see Dump.
+func (d *Dino) Dump(w io.Writer) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return Dump(w, d.registry)
+}
@@ -0,0 +1,123 @@
+package dino_test
+
+import (
+	"errors"
+	"iter"
+	"maps"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/yuppyweb/dino"
+	"github.com/yuppyweb/dino/dinotest"
+)
+
+type DumpService struct{}
+
+func TestDino_Dump(t *testing.T) {
+	t.Parallel()
+
+	di := dino.New()
+
+	if err := di.Singleton(42, "answer"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := di.Singleton("name"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	called := false
+
+	err := di.Factory(func(name string) (*DumpService, error) {
+		called = true
+
+		return &DumpService{}, nil
+	}, "primary")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var out strings.Builder
+	if err := di.Dump(&out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := strings.Join([]string{
+		`*dino_test.DumpService tag="primary" factory func(string) (*dino_test.DumpService, error)`,
+		`int tag="answer" instance int`,
+		`string tag="" instance string`,
+		"",
+	}, "\n")
+
+	if out.String() != expected {
+		t.Fatalf("expected dump:\n%s\ngot:\n%s", expected, out.String())
+	}
+
+	if called {
+		t.Fatalf("expected Dump not to call factories")
+	}
+}
+
+func TestDump_OddValues(t *testing.T) {
+	t.Parallel()
+
+	registry := entriesRegistry{
+		MockRegistry: dinotest.NewMockRegistry(),
+		entries: map[dino.RegistryKey]reflect.Value{
+			{Tag: "", Type: reflect.TypeFor[int](), Scope: "request"}: {},
+			{Tag: "", Type: reflect.TypeFor[func()](), Scope: ""}:     reflect.ValueOf(func() {}),
+		},
+	}
+
+	var out strings.Builder
+	if err := dino.Dump(&out, registry); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, line := range []string{
+		`func() tag="" instance func()`,
+		`int tag="" scope="request" invalid`,
+	} {
+		if !strings.Contains(out.String(), line) {
+			t.Fatalf("expected line %q in dump:\n%s", line, out.String())
+		}
+	}
+}
+
+// entriesRegistry serves a fixed set of entries, including ones a real registry would reject.
+type entriesRegistry struct {
+	*dinotest.MockRegistry
+
+	entries map[dino.RegistryKey]reflect.Value
+}
+
+func (r entriesRegistry) All() iter.Seq2[dino.RegistryKey, reflect.Value] {
+	return maps.All(r.entries)
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("write failed")
+}
+
+func TestDump_WriteError(t *testing.T) {
+	t.Parallel()
+
+	registry := new(dino.SyncMapRegistry)
+
+	key := dino.RegistryKey{
+		Tag:   "",
+		Type:  reflect.TypeFor[int](),
+		Scope: "",
+	}
+
+	if err := registry.Register(key, reflect.ValueOf(1)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := registry.Dump(failingWriter{}); err == nil {
+		t.Fatalf("expected write error")
+	}
+}
@@ -0,0 +1,118 @@
+package dino
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+var (
+	ErrEnvNotSet  = errors.New("environment variable not set")
+	ErrEnvInvalid = errors.New("invalid environment variable value")
+)
+
+// WithEnvLookup makes the container read the environment variables named by `inject:"env:NAME"`
+// struct tags with the lookup function instead of os.LookupEnv, e.g. to serve fixed values in tests.
+func WithEnvLookup(lookup func(name string) (string, bool)) Option {
+	return func(o *options) {
+		o.envLookup = lookup
+	}
+}
+
+// injectEnv sets the field to the value of the environment variable named by its tag, converted
+// to the type of the field. A missing variable leaves an optional field untouched.
+func (i *Injector) injectEnv(field reflect.Value, fieldStruct reflect.StructField, tag injectTag) error {
+	lookup := i.options.envLookup
+	if lookup == nil {
+		lookup = os.LookupEnv
+	}
+
+	outcome := fieldOutcome(field.Type(), FieldResolved)
+	outcome.Env = tag.env
+
+	raw, ok := lookup(tag.env)
+	if !ok {
+		if tag.optional {
+			outcome.Outcome = FieldMissing
+			i.record(fieldStruct.Name, outcome)
+
+			return nil
+		}
+
+		return fmt.Errorf("%w: %s for field %s", ErrEnvNotSet, tag.env, fieldStruct.Name)
+	}
+
+	val, err := parseValue(raw, field.Type())
+	if err != nil {
+		return fmt.Errorf("%w: %s=%q for field %s of type %s: %w",
+			ErrEnvInvalid, tag.env, raw, fieldStruct.Name, field.Type(), err)
+	}
+
+	i.record(fieldStruct.Name, outcome)
+	i.set(field, val)
+
+	return nil
+}
+
+// parseValue converts the raw value of an environment variable or flag to the type: strings, booleans,
+// integers, floats and time.Duration are supported.
+func parseValue(raw string, rt reflect.Type) (reflect.Value, error) {
+	rv := reflect.New(rt).Elem()
+
+	// Durations are integers too, but are written like "1m30s"
+	if rt == reflect.TypeFor[time.Duration]() {
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return rv, fmt.Errorf("parse duration: %w", err)
+		}
+
+		rv.SetInt(int64(d))
+
+		return rv, nil
+	}
+
+	switch rt.Kind() {
+	case reflect.String:
+		rv.SetString(raw)
+
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return rv, fmt.Errorf("parse bool: %w", err)
+		}
+
+		rv.SetBool(b)
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, rt.Bits())
+		if err != nil {
+			return rv, fmt.Errorf("parse int: %w", err)
+		}
+
+		rv.SetInt(n)
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(raw, 10, rt.Bits())
+		if err != nil {
+			return rv, fmt.Errorf("parse uint: %w", err)
+		}
+
+		rv.SetUint(n)
+
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(raw, rt.Bits())
+		if err != nil {
+			return rv, fmt.Errorf("parse float: %w", err)
+		}
+
+		rv.SetFloat(f)
+
+	default:
+		return rv, fmt.Errorf("unsupported kind %s", rt.Kind())
+	}
+
+	return rv, nil
+}
@@ -0,0 +1,189 @@
+package dino_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yuppyweb/dino"
+)
+
+type EnvConfig struct {
+	URL     string        `inject:"env:DATABASE_URL"`
+	Debug   bool          `inject:"env:DEBUG"`
+	Port    int           `inject:"env:PORT"`
+	Retries int8          `inject:"env:RETRIES"`
+	Workers uint16        `inject:"env:WORKERS"`
+	Ratio   float64       `inject:"env:RATIO"`
+	Timeout time.Duration `inject:"env:TIMEOUT"`
+}
+
+type EnvOptionalConfig struct {
+	Host string `inject:"env:HOST,optional"`
+	Port int    `inject:"env:PORT"`
+}
+
+// envValue holds a single field of the type read from the V variable.
+type envValue[T any] struct {
+	V T `inject:"env:V"`
+}
+
+// envLookup serves the variables from a map instead of the process environment.
+func envLookup(vars map[string]string) dino.Option {
+	return dino.WithEnvLookup(func(name string) (string, bool) {
+		val, ok := vars[name]
+
+		return val, ok
+	})
+}
+
+func TestInject_Env(t *testing.T) {
+	t.Parallel()
+
+	di := dino.New(envLookup(map[string]string{
+		"DATABASE_URL": "postgres://localhost/app",
+		"DEBUG":        "true",
+		"PORT":         "8080",
+		"RETRIES":      "-3",
+		"WORKERS":      "16",
+		"RATIO":        "0.75",
+		"TIMEOUT":      "1m30s",
+	}))
+
+	config := new(EnvConfig)
+	if err := di.Inject(config); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := EnvConfig{
+		URL:     "postgres://localhost/app",
+		Debug:   true,
+		Port:    8080,
+		Retries: -3,
+		Workers: 16,
+		Ratio:   0.75,
+		Timeout: 90 * time.Second,
+	}
+
+	if *config != expected {
+		t.Fatalf("expected %+v, got %+v", expected, *config)
+	}
+}
+
+func TestInject_EnvOptional(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		vars     map[string]string
+		expected EnvOptionalConfig
+		err      error
+		message  string
+	}{
+		{
+			name:     "both set",
+			vars:     map[string]string{"HOST": "db", "PORT": "5432"},
+			expected: EnvOptionalConfig{Host: "db", Port: 5432},
+			err:      nil,
+			message:  "",
+		},
+		{
+			name:     "optional missing",
+			vars:     map[string]string{"PORT": "5432"},
+			expected: EnvOptionalConfig{Host: "default", Port: 5432},
+			err:      nil,
+			message:  "",
+		},
+		{
+			name:     "optional set empty",
+			vars:     map[string]string{"HOST": "", "PORT": "5432"},
+			expected: EnvOptionalConfig{Host: "", Port: 5432},
+			err:      nil,
+			message:  "",
+		},
+		{
+			name:     "required missing",
+			vars:     map[string]string{"HOST": "db"},
+			expected: EnvOptionalConfig{Host: "db", Port: 0},
+			err:      dino.ErrEnvNotSet,
+			message:  "PORT for field Port",
+		},
+		{
+			name:     "required invalid",
+			vars:     map[string]string{"PORT": "http"},
+			expected: EnvOptionalConfig{Host: "default", Port: 0},
+			err:      dino.ErrEnvInvalid,
+			message:  `PORT="http" for field Port of type int`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			config := &EnvOptionalConfig{Host: "default", Port: 0}
+
+			err := dino.New(envLookup(tt.vars)).Inject(config)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("expected %v, got %v", tt.err, err)
+			}
+
+			if err != nil && !strings.Contains(err.Error(), tt.message) {
+				t.Fatalf("expected the error to contain %q, got %v", tt.message, err)
+			}
+
+			if *config != tt.expected {
+				t.Fatalf("expected %+v, got %+v", tt.expected, *config)
+			}
+		})
+	}
+}
+
+func TestInject_EnvConversionErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		target any
+		raw    string
+	}{
+		{name: "bool", target: &envValue[bool]{}, raw: "maybe"},
+		{name: "int overflow", target: &envValue[int8]{}, raw: "300"},
+		{name: "negative uint", target: &envValue[uint]{}, raw: "-1"},
+		{name: "float", target: &envValue[float32]{}, raw: "fast"},
+		{name: "duration without unit", target: &envValue[time.Duration]{}, raw: "30"},
+		{name: "unsupported type", target: &envValue[[]string]{}, raw: "a,b"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := dino.New(envLookup(map[string]string{"V": tt.raw})).Inject(tt.target)
+			if !errors.Is(err, dino.ErrEnvInvalid) {
+				t.Fatalf("expected ErrEnvInvalid, got %v", err)
+			}
+
+			if !strings.Contains(err.Error(), `V="`+tt.raw+`"`) {
+				t.Fatalf("expected the error to contain the raw value, got %v", err)
+			}
+		})
+	}
+}
+
+func TestInject_EnvProcessEnvironment(t *testing.T) {
+	t.Setenv("DINO_TEST_PORT", "9090")
+
+	config := &struct {
+		Port int `inject:"env:DINO_TEST_PORT"`
+	}{}
+
+	if err := dino.New().Inject(config); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if config.Port != 9090 {
+		t.Fatalf("expected the port from the environment, got %d", config.Port)
+	}
+}
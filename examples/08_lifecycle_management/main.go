@@ -69,7 +69,19 @@ type Application struct {
 	Cache *Cache
 }
 
-// Example demonstrating lifecycle management with shutdown hooks.
+func (a *Application) Shutdown() error {
+	fmt.Println("[Application] Shutdown")
+
+	return nil
+}
+
+func run(app *Application) {
+	fmt.Println("=== Application Running ===")
+	fmt.Printf("Database: %s (open: %v)\n", app.DB.Name, app.DB.open)
+	fmt.Printf("Cache: %s (open: %v)\n", app.Cache.Name, app.Cache.open)
+}
+
+// Example demonstrating lifecycle management with container-managed shutdown.
 func main() {
 	di := dino.New()
 
@@ -92,25 +104,21 @@ func main() {
 		log.Fatal(err)
 	}
 
-	// Create application
-	app := &Application{}
-	if err := di.Inject(app); err != nil {
+	// The application is created by the container, after the resources it depends on
+	if err := di.Factory(func(db *Database, cache *Cache) *Application {
+		return &Application{DB: db, Cache: cache}
+	}); err != nil {
 		log.Fatal(err)
 	}
 
-	// Use application
-	fmt.Println("=== Application Running ===")
-	fmt.Printf("Database: %s (open: %v)\n", app.DB.Name, app.DB.open)
-	fmt.Printf("Cache: %s (open: %v)\n", app.Cache.Name, app.Cache.open)
-
-	// Shutdown resources (simulate graceful shutdown)
-	fmt.Println("\n=== Shutting Down ===")
-
-	if err := app.Cache.Shutdown(); err != nil {
+	if _, err := di.Invoke(run); err != nil {
 		log.Fatal(err)
 	}
 
-	if err := app.DB.Shutdown(); err != nil {
+	// Shut everything down in reverse creation order: Application, Cache, Database
+	fmt.Println("\n=== Shutting Down ===")
+
+	if err := di.Close(); err != nil {
 		log.Fatal(err)
 	}
 
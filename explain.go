@@ -0,0 +1,157 @@
+package dino
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// explainer describes how the keys of a container would be resolved, without resolving them.
+type explainer struct {
+	registry Registry
+	options  *options
+	out      strings.Builder
+	stack    map[RegistryKey]struct{}
+}
+
+// Explain describes how the type would be resolved, without calling any factory: the registry entry
+// matching it, which is an instance, a cached factory result or a factory with its signature, then what
+// every factory parameter would resolve to, recursively. Dependencies missing from the registry end in
+// "-> NOT FOUND (auto-create zero value)", followed by the fields injected into them if they are structs.
+// Only the first tag is used. The description is an indented tree, one line per dependency.
+func (d *Dino) Explain(rt reflect.Type, tags ...string) (string, error) {
+	if rt == nil {
+		return "", fmt.Errorf("%w: type to explain cannot be nil", ErrInvalidInputValue)
+	}
+
+	key := RegistryKey{
+		Tag:       "",
+		Type:      rt,
+		Scope:     "",
+		Qualifier: nil,
+	}
+
+	if len(tags) > 0 {
+		key.Tag = tags[0]
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	exp := &explainer{
+		registry: d.registry,
+		options:  d.options,
+		out:      strings.Builder{},
+		stack:    make(map[RegistryKey]struct{}),
+	}
+
+	exp.key(key, 0, "")
+
+	return exp.out.String(), nil
+}
+
+// line writes a line of the tree at the depth.
+func (e *explainer) line(depth int, format string, args ...any) {
+	e.out.WriteString(strings.Repeat("  ", depth))
+	fmt.Fprintf(&e.out, format, args...)
+	e.out.WriteString("\n")
+}
+
+// key describes the resolution of the key and, for a factory, of its parameters.
+// The label, e.g. the name of the field the key is injected into, precedes the key.
+func (e *explainer) key(key RegistryKey, depth int, label string) {
+	name := label + keyName(key)
+
+	if _, ok := e.stack[key]; ok {
+		e.line(depth, "%s -> CIRCULAR DEPENDENCY", name)
+
+		return
+	}
+
+	rv, err := e.registry.Find(key)
+
+	switch {
+	case errors.Is(err, ErrValueNotFound) && provides(e.registry, key):
+		e.line(depth, "%s -> lazy provider", name)
+
+	case errors.Is(err, ErrValueNotFound):
+		e.line(depth, "%s -> NOT FOUND (auto-create zero value)", name)
+		e.fields(key, depth+1)
+
+	case err != nil:
+		e.line(depth, "%s -> ERROR %v", name, err)
+
+	case isFactory(key, rv):
+		e.line(depth, "%s -> factory %s", name, rv.Type())
+
+		e.stack[key] = struct{}{}
+		e.params(rv.Type(), depth+1)
+		delete(e.stack, key)
+
+	default:
+		if fn, ok := e.options.factory(key); ok {
+			e.line(depth, "%s -> cached instance %s from factory %s", name, rv.Type(), fn.Type())
+		} else {
+			e.line(depth, "%s -> instance %s", name, rv.Type())
+		}
+	}
+}
+
+// params describes the resolution of every parameter of a factory function.
+func (e *explainer) params(fn reflect.Type, depth int) {
+	for param := range fn.Ins() {
+		key := RegistryKey{
+			Tag:       "",
+			Type:      param,
+			Scope:     "",
+			Qualifier: nil,
+		}
+
+		switch {
+		case param == reflect.TypeFor[*Lifecycle]():
+			e.line(depth, "%s -> container lifecycle", keyName(key))
+
+		case isInStruct(param):
+			e.line(depth, "%s -> parameter object", keyName(key))
+			e.fields(key, depth+1)
+
+		default:
+			e.key(key, depth, "")
+		}
+	}
+}
+
+// fields describes the resolution of the fields injected into an auto-created struct.
+func (e *explainer) fields(key RegistryKey, depth int) {
+	rt := structOf(key.Type)
+	if !isStruct(rt) {
+		return
+	}
+
+	e.stack[key] = struct{}{}
+	defer delete(e.stack, key)
+
+	for field := range rt.Fields() {
+		if !field.IsExported() || field.Type == reflect.TypeFor[In]() {
+			continue
+		}
+
+		tag := parseInjectTag(field.Tag.Get("inject"))
+
+		fieldKey := RegistryKey{
+			Tag:       tag.name,
+			Type:      field.Type,
+			Scope:     "",
+			Qualifier: nil,
+		}
+
+		if _, err := e.registry.Find(fieldKey); tag.optional && errors.Is(err, ErrValueNotFound) {
+			e.line(depth, "%s: %s -> NOT FOUND (optional, left empty)", field.Name, keyName(fieldKey))
+
+			continue
+		}
+
+		e.key(fieldKey, depth, field.Name+": ")
+	}
+}
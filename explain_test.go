@@ -0,0 +1,118 @@
+package dino_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/yuppyweb/dino"
+)
+
+type ExplainLogger struct{}
+
+type ExplainDatabase struct{}
+
+type ExplainMetrics struct{}
+
+type ExplainRepository struct{}
+
+type ExplainService struct{}
+
+type ExplainClock struct{}
+
+type ExplainParams struct {
+	dino.In
+
+	Repository *ExplainRepository
+	Metrics    *ExplainMetrics `inject:",optional"`
+}
+
+type ExplainHandler struct {
+	Service *ExplainService
+	Clock   *ExplainClock
+	Logger  *ExplainLogger `inject:"audit"`
+}
+
+func TestDino_Explain(t *testing.T) {
+	t.Parallel()
+
+	di := dino.New()
+
+	if err := di.Singleton(&ExplainLogger{}, "audit"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	factories := []any{
+		func() *ExplainDatabase { return &ExplainDatabase{} },
+		func(*ExplainDatabase, *dino.Lifecycle) *ExplainRepository { return &ExplainRepository{} },
+		func(ExplainParams) *ExplainService { return &ExplainService{} },
+	}
+
+	for _, factory := range factories {
+		if err := di.Factory(factory); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	// The database is cached, its factory is not called again
+	if _, err := di.Resolve(reflect.TypeFor[*ExplainDatabase]()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	explanation, err := di.Explain(reflect.TypeFor[*ExplainService]())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := `*dino_test.ExplainService -> factory func(dino_test.ExplainParams) *dino_test.ExplainService
+  dino_test.ExplainParams -> parameter object
+    Repository: *dino_test.ExplainRepository -> factory ` +
+		`func(*dino_test.ExplainDatabase, *dino.Lifecycle) *dino_test.ExplainRepository
+      *dino_test.ExplainDatabase -> cached instance *dino_test.ExplainDatabase ` +
+		`from factory func() *dino_test.ExplainDatabase
+      *dino.Lifecycle -> container lifecycle
+    Metrics: *dino_test.ExplainMetrics -> NOT FOUND (optional, left empty)
+`
+
+	if explanation != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, explanation)
+	}
+}
+
+func TestDino_ExplainMissing(t *testing.T) {
+	t.Parallel()
+
+	di := dino.New()
+
+	if err := di.Singleton(&ExplainLogger{}, "audit"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := di.Factory(func(*ExplainHandler) *ExplainService { return &ExplainService{} }); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	explanation, err := di.Explain(reflect.TypeFor[*ExplainHandler]())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := `*dino_test.ExplainHandler -> NOT FOUND (auto-create zero value)
+  Service: *dino_test.ExplainService -> factory func(*dino_test.ExplainHandler) *dino_test.ExplainService
+    *dino_test.ExplainHandler -> CIRCULAR DEPENDENCY
+  Clock: *dino_test.ExplainClock -> NOT FOUND (auto-create zero value)
+  Logger: *dino_test.ExplainLogger[audit] -> instance *dino_test.ExplainLogger
+`
+
+	if explanation != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, explanation)
+	}
+}
+
+func TestDino_ExplainNilType(t *testing.T) {
+	t.Parallel()
+
+	if _, err := dino.New().Explain(nil); !errors.Is(err, dino.ErrInvalidInputValue) {
+		t.Fatalf("expected ErrInvalidInputValue, got %v", err)
+	}
+}
@@ -0,0 +1,64 @@
+package dino
+
+import (
+	"expvar"
+	"sync"
+)
+
+// expvarContainers maps the names published with WithExpvar to the container last created with them.
+var expvarContainers sync.Map
+
+// WithExpvar publishes the container's counters under the name in the expvar package, and enables
+// WithStats, whose counters back them: the number of resolutions, cache hits, factory calls and factory
+// errors, the current registry size, and the factory calls per type. Publishing under a name again,
+// e.g. from another container, makes the variable report the latest container instead of panicking
+// like expvar.Publish. A name already published outside of the container is left untouched.
+func WithExpvar(name string) Option {
+	return func(o *options) {
+		o.expvarName = name
+
+		if o.stats == nil {
+			o.stats = new(statsCollector)
+		}
+	}
+}
+
+// publishExpvar makes the variable published under the name report the container's counters.
+func publishExpvar(name string, d *Dino) {
+	if _, loaded := expvarContainers.Swap(name, d); loaded || expvar.Get(name) != nil {
+		return
+	}
+
+	expvar.Publish(name, expvar.Func(func() any {
+		value, _ := expvarContainers.Load(name)
+
+		container, ok := value.(*Dino)
+		if !ok {
+			return nil
+		}
+
+		return container.expvarMetrics()
+	}))
+}
+
+// expvarMetrics returns the counters published by WithExpvar, read from Stats.
+func (d *Dino) expvarMetrics() map[string]any {
+	stats := d.Stats()
+
+	calls := make(map[string]uint64)
+
+	for key, keyStats := range stats.Keys {
+		if keyStats.FactoryCalls > 0 {
+			calls[keyName(key)] += keyStats.FactoryCalls
+		}
+	}
+
+	return map[string]any{
+		"resolutions":           stats.Hits + stats.Misses,
+		"cache_hits":            stats.CacheHits,
+		"factory_calls":         stats.FactoryCalls,
+		"factory_errors":        stats.FactoryErrors,
+		"registry_size":         stats.Registrations,
+		"factory_calls_by_type": calls,
+	}
+}
@@ -0,0 +1,111 @@
+package dino_test
+
+import (
+	"encoding/json"
+	"expvar"
+	"reflect"
+	"testing"
+
+	"github.com/yuppyweb/dino"
+)
+
+type ExpvarService struct{}
+
+type expvarMetrics struct {
+	Resolutions        uint64            `json:"resolutions"`
+	CacheHits          uint64            `json:"cache_hits"`
+	FactoryCalls       uint64            `json:"factory_calls"`
+	FactoryErrors      uint64            `json:"factory_errors"`
+	RegistrySize       int               `json:"registry_size"`
+	FactoryCallsByType map[string]uint64 `json:"factory_calls_by_type"`
+}
+
+func readExpvar(t *testing.T, name string) expvarMetrics {
+	t.Helper()
+
+	variable := expvar.Get(name)
+	if variable == nil {
+		t.Fatalf("expected %q to be published", name)
+	}
+
+	var metrics expvarMetrics
+	if err := json.Unmarshal([]byte(variable.String()), &metrics); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	return metrics
+}
+
+func TestWithExpvar(t *testing.T) {
+	t.Parallel()
+
+	di := dino.New(dino.WithExpvar("dino_test_metrics"))
+
+	if err := di.Singleton(8080); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := di.Factory(func(int) *ExpvarService { return &ExpvarService{} }); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := di.Factory(func() (string, error) { return "", errHookFailed }); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for range 2 {
+		if _, err := di.Resolve(reflect.TypeFor[*ExpvarService]()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if _, err := di.Resolve(reflect.TypeFor[string]()); err == nil {
+		t.Fatal("expected the failing factory to return an error")
+	}
+
+	if _, err := di.Resolve(reflect.TypeFor[bool]()); err == nil {
+		t.Fatal("expected an error resolving an unregistered type")
+	}
+
+	metrics := readExpvar(t, "dino_test_metrics")
+
+	expected := expvarMetrics{
+		Resolutions:   5,
+		CacheHits:     2,
+		FactoryCalls:  2,
+		FactoryErrors: 1,
+		RegistrySize:  3,
+		FactoryCallsByType: map[string]uint64{
+			"*dino_test.ExpvarService": 1,
+			"string":                   1,
+		},
+	}
+
+	if !reflect.DeepEqual(metrics, expected) {
+		t.Errorf("expected %+v, got %+v", expected, metrics)
+	}
+}
+
+func TestWithExpvar_Republish(t *testing.T) {
+	t.Parallel()
+
+	first := dino.New(dino.WithExpvar("dino_test_republished"))
+	second := dino.New(dino.WithExpvar("dino_test_republished"))
+
+	if err := first.Singleton(1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := second.Singleton("value"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := second.Resolve(reflect.TypeFor[string]()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	metrics := readExpvar(t, "dino_test_republished")
+	if metrics.Resolutions != 1 || metrics.RegistrySize != 1 {
+		t.Errorf("expected the latest container to be reported, got %+v", metrics)
+	}
+}
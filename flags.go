@@ -0,0 +1,142 @@
+package dino
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"reflect"
+)
+
+var (
+	ErrDuplicateFlag  = errors.New("duplicate flag")
+	ErrFlagsNotParsed = errors.New("flag set not parsed")
+)
+
+// flagSpec is a command-line flag declared with Flag.
+type flagSpec struct {
+	name  string
+	usage string
+	value reflect.Value
+}
+
+// Flag declares a command-line flag for BindFlags, e.g. dino.Flag("port", "listen port", 8080).
+// The default value sets the type of the flag: a string, bool, integer, float or time.Duration.
+// Once parsed, the value is resolved under that type with the flag name as the tag.
+func Flag(name, usage string, value any) Option {
+	return func(o *options) {
+		o.flags = append(o.flags, flagSpec{
+			name:  name,
+			usage: usage,
+			value: reflect.ValueOf(value),
+		})
+	}
+}
+
+// BindFlags defines the flags declared with Flag on the flag set, named with the prefix, and registers
+// their values under their types with the declared names as tags, e.g. a flag declared as "port" and
+// bound with the prefix "http-" is set by --http-port and resolved as int tagged "port". Flags declared
+// twice or already defined on the flag set are reported as ErrDuplicateFlag before any flag is defined.
+// Resolving a flag value before the flag set is parsed fails with ErrFlagsNotParsed.
+func (d *Dino) BindFlags(fs *flag.FlagSet, prefix string) error {
+	if fs == nil {
+		return fmt.Errorf("%w: flag set cannot be nil", ErrInvalidInputValue)
+	}
+
+	seen := make(map[string]bool, len(d.options.flags))
+
+	// Check every flag first, FlagSet panics on redefinition
+	for _, spec := range d.options.flags {
+		name := prefix + spec.name
+
+		if seen[name] || fs.Lookup(name) != nil {
+			return fmt.Errorf("failed to bind flags: %w: %s", ErrDuplicateFlag, name)
+		}
+
+		seen[name] = true
+
+		if !spec.value.IsValid() {
+			return fmt.Errorf("failed to bind flags: %w: flag %s has no default value", ErrInvalidInputValue, name)
+		}
+
+		if !isParsable(spec.value.Type()) {
+			return fmt.Errorf("failed to bind flags: %w: flag %s of type %s is not supported",
+				ErrInvalidInputValue, name, spec.value.Type())
+		}
+	}
+
+	for _, spec := range d.options.flags {
+		value := &flagValue{rv: reflect.New(spec.value.Type()).Elem()}
+		value.rv.Set(spec.value)
+
+		fs.Var(value, prefix+spec.name, spec.usage)
+
+		if err := d.Factory(value.factory(fs, prefix+spec.name).Interface(), spec.name); err != nil {
+			return fmt.Errorf("failed to bind flag %s: %w", prefix+spec.name, err)
+		}
+	}
+
+	return nil
+}
+
+// flagValue is a flag.Value holding a value of any type supported by parseValue.
+type flagValue struct {
+	rv reflect.Value
+}
+
+// String returns the current value of the flag.
+func (v *flagValue) String() string {
+	// The flag package calls String on a zero flagValue to detect default values
+	if v == nil || !v.rv.IsValid() {
+		return ""
+	}
+
+	return fmt.Sprint(v.rv.Interface())
+}
+
+// Set parses the raw value of the flag.
+func (v *flagValue) Set(raw string) error {
+	parsed, err := parseValue(raw, v.rv.Type())
+	if err != nil {
+		return err
+	}
+
+	v.rv.Set(parsed)
+
+	return nil
+}
+
+// IsBoolFlag lets boolean flags be set without a value, like --verbose.
+func (v *flagValue) IsBoolFlag() bool {
+	return v.rv.Kind() == reflect.Bool
+}
+
+// factory returns a factory function of type func() (T, error) returning the value of the flag
+// once the flag set is parsed.
+func (v *flagValue) factory(fs *flag.FlagSet, name string) reflect.Value {
+	fnType := reflect.FuncOf(nil, []reflect.Type{v.rv.Type(), reflect.TypeFor[error]()}, false)
+
+	return reflect.MakeFunc(fnType, func([]reflect.Value) []reflect.Value {
+		if !fs.Parsed() {
+			err := fmt.Errorf("%w: flag %s", ErrFlagsNotParsed, name)
+
+			return []reflect.Value{reflect.Zero(v.rv.Type()), reflect.ValueOf(&err).Elem()}
+		}
+
+		// Copy the value, later changes to the flag do not reach the cached instance
+		return []reflect.Value{reflect.ValueOf(v.rv.Interface()), reflect.Zero(reflect.TypeFor[error]())}
+	})
+}
+
+// isParsable reports whether parseValue supports the type.
+func isParsable(rt reflect.Type) bool {
+	switch rt.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+
+	default:
+		return false
+	}
+}
@@ -0,0 +1,190 @@
+package dino_test
+
+import (
+	"errors"
+	"flag"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/yuppyweb/dino"
+)
+
+type FlagServer struct {
+	Port    int
+	DBURL   string
+	Verbose bool
+	Timeout time.Duration
+}
+
+func newFlagContainer(opts ...dino.Option) *dino.Dino {
+	return dino.New(append([]dino.Option{
+		dino.Flag("port", "listen port", 8080),
+		dino.Flag("db-url", "database URL", "postgres://localhost/app"),
+		dino.Flag("verbose", "log every request", false),
+		dino.Flag("timeout", "request timeout", 5*time.Second),
+	}, opts...)...)
+}
+
+func newFlagSet() *flag.FlagSet {
+	fs := flag.NewFlagSet("server", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	return fs
+}
+
+func TestDino_BindFlags(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		args     []string
+		expected FlagServer
+	}{
+		{
+			name: "defaults",
+			args: nil,
+			expected: FlagServer{
+				Port:    8080,
+				DBURL:   "postgres://localhost/app",
+				Verbose: false,
+				Timeout: 5 * time.Second,
+			},
+		},
+		{
+			name: "parsed",
+			args: []string{"--app-port=9000", "--app-db-url", "postgres://db/app", "--app-verbose", "--app-timeout=1m"},
+			expected: FlagServer{
+				Port:    9000,
+				DBURL:   "postgres://db/app",
+				Verbose: true,
+				Timeout: time.Minute,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			di := newFlagContainer()
+			fs := newFlagSet()
+
+			if err := di.BindFlags(fs, "app-"); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if err := fs.Parse(tt.args); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if err := di.Factory(func(in struct {
+				dino.In
+
+				Port    int           `inject:"port"`
+				DBURL   string        `inject:"db-url"`
+				Verbose bool          `inject:"verbose"`
+				Timeout time.Duration `inject:"timeout"`
+			},
+			) *FlagServer {
+				return &FlagServer{Port: in.Port, DBURL: in.DBURL, Verbose: in.Verbose, Timeout: in.Timeout}
+			}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			server, err := dino.Resolve[*FlagServer](di)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if *server != tt.expected {
+				t.Fatalf("expected %+v, got %+v", tt.expected, *server)
+			}
+		})
+	}
+}
+
+func TestDino_BindFlagsErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		di      *dino.Dino
+		defined string
+		err     error
+	}{
+		{
+			name:    "declared twice",
+			di:      newFlagContainer(dino.Flag("port", "admin port", 9090)),
+			defined: "",
+			err:     dino.ErrDuplicateFlag,
+		},
+		{
+			name:    "defined on the flag set",
+			di:      newFlagContainer(),
+			defined: "app-verbose",
+			err:     dino.ErrDuplicateFlag,
+		},
+		{
+			name:    "unsupported type",
+			di:      newFlagContainer(dino.Flag("hosts", "upstream hosts", []string{"a"})),
+			defined: "",
+			err:     dino.ErrInvalidInputValue,
+		},
+		{
+			name:    "nil default",
+			di:      newFlagContainer(dino.Flag("token", "API token", nil)),
+			defined: "",
+			err:     dino.ErrInvalidInputValue,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			fs := newFlagSet()
+			if tt.defined != "" {
+				fs.Bool(tt.defined, false, "")
+			}
+
+			if err := tt.di.BindFlags(fs, "app-"); !errors.Is(err, tt.err) {
+				t.Fatalf("expected %v, got %v", tt.err, err)
+			}
+
+			// Nothing is defined when a flag is rejected
+			if fs.Lookup("app-port") != nil {
+				t.Fatal("expected no flag to be defined")
+			}
+		})
+	}
+}
+
+func TestDino_BindFlagsNotParsed(t *testing.T) {
+	t.Parallel()
+
+	di := newFlagContainer()
+	fs := newFlagSet()
+
+	if err := di.BindFlags(fs, ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := dino.Resolve[int](di, "port"); !errors.Is(err, dino.ErrFlagsNotParsed) {
+		t.Fatalf("expected ErrFlagsNotParsed, got %v", err)
+	}
+
+	// The failed resolution caches nothing, the parsed value is resolved once available
+	if err := fs.Parse([]string{"-port", "7000"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	port, err := dino.Resolve[int](di, "port")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if port != 7000 {
+		t.Fatalf("expected the parsed port, got %d", port)
+	}
+}
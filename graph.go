@@ -0,0 +1,80 @@
+package dino
+
+import (
+	"reflect"
+	"slices"
+)
+
+// graph is the dependency graph of a container: a node per registered key or key a factory depends on,
+// and an edge from every factory result to each of its dependencies.
+type graph struct {
+	nodes []RegistryKey
+	edges []graphEdge
+	// cycle is ErrCircularDependency naming the nodes left unordered, if the factories form a cycle.
+	cycle error
+}
+
+// graphEdge is a dependency of the node at index from on the node at index to.
+type graphEdge struct {
+	from int
+	to   int
+}
+
+// graph builds the dependency graph of the container from the registry and the recorded factory
+// dependencies. Registered keys come first, sorted, followed by dependencies that are not registered,
+// e.g. auto-created structs. Factories are never called.
+func (d *Dino) graph() graph {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	var nodes, missing []RegistryKey
+
+	index := make(map[RegistryKey]int)
+
+	for key := range d.registry.All() {
+		nodes = append(nodes, key)
+		index[key] = -1
+	}
+
+	for _, key := range nodes {
+		for _, dep := range d.options.dependencies(key) {
+			if _, ok := index[dep]; !ok {
+				missing = append(missing, dep)
+				index[dep] = -1
+			}
+		}
+	}
+
+	sortKeys(nodes)
+	sortKeys(missing)
+
+	nodes = append(nodes, missing...)
+
+	for idx, key := range nodes {
+		index[key] = idx
+	}
+
+	var edges []graphEdge
+
+	for from, key := range nodes {
+		for _, dep := range d.options.dependencies(key) {
+			edge := graphEdge{from: from, to: index[dep]}
+			if !slices.Contains(edges, edge) {
+				edges = append(edges, edge)
+			}
+		}
+	}
+
+	_, cycle := topoOrder(nodes, d.options.dependencies)
+
+	return graph{
+		nodes: nodes,
+		edges: edges,
+		cycle: cycle,
+	}
+}
+
+// isInterfaceKey reports whether the key is bound to an interface type rather than a concrete one.
+func isInterfaceKey(key RegistryKey) bool {
+	return key.Type.Kind() == reflect.Interface
+}
@@ -0,0 +1,19 @@
+module github.com/yuppyweb/dino/grpcdino
+
+go 1.26.0
+
+require (
+	github.com/yuppyweb/dino v0.0.0-00010101000000-000000000000
+	google.golang.org/grpc v1.75.0
+	google.golang.org/protobuf v1.36.8
+)
+
+require (
+	golang.org/x/net v0.48.0 // indirect
+	golang.org/x/sync v0.19.0 // indirect
+	golang.org/x/sys v0.39.0 // indirect
+	golang.org/x/text v0.32.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20250818200422-3122310a409c // indirect
+)
+
+replace github.com/yuppyweb/dino => ../
@@ -0,0 +1,59 @@
+// Package grpcdino runs every gRPC call with a scope of a dino container, like dino.Dino.Middleware does
+// for HTTP requests. It is kept apart from the dino package so that only applications using it depend on gRPC:
+//
+//	server := grpc.NewServer(
+//		grpc.ChainUnaryInterceptor(grpcdino.UnaryScopeInterceptor(di)),
+//		grpc.ChainStreamInterceptor(grpcdino.StreamScopeInterceptor(di)),
+//	)
+package grpcdino
+
+import (
+	"context"
+
+	"github.com/yuppyweb/dino"
+	"google.golang.org/grpc"
+)
+
+// UnaryScopeInterceptor runs every unary call with a scope of the container, available to the handler
+// through dino.FromContext, and closes the scope once the handler returns, even if it panics.
+func UnaryScopeInterceptor(d *dino.Dino) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		scope := d.Scope()
+
+		defer func() {
+			_ = scope.CloseScope()
+		}()
+
+		return handler(dino.NewContext(ctx, scope), req)
+	}
+}
+
+// StreamScopeInterceptor runs every streaming call with a scope of the container, available to the
+// handler through dino.FromContext on the stream context, and closes the scope once the handler
+// returns, even if it panics.
+func StreamScopeInterceptor(d *dino.Dino) grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		scope := d.Scope()
+
+		defer func() {
+			_ = scope.CloseScope()
+		}()
+
+		return handler(srv, &scopedStream{
+			ServerStream: stream,
+			ctx:          dino.NewContext(stream.Context(), scope),
+		})
+	}
+}
+
+// scopedStream is a server stream whose context carries the scope of the call.
+type scopedStream struct {
+	grpc.ServerStream
+
+	ctx context.Context
+}
+
+// Context returns the stream context carrying the scope of the call.
+func (s *scopedStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,270 @@
+package grpcdino_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/yuppyweb/dino"
+	"github.com/yuppyweb/dino/grpcdino"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+const (
+	concurrentCalls = 8
+	streamedValues  = 3
+)
+
+var errNoScope = errors.New("no scope in context")
+
+// RequestID is created once per call by the scope of the call.
+type RequestID struct {
+	Value  int64
+	closed atomic.Bool
+}
+
+func (r *RequestID) Close() error {
+	r.closed.Store(true)
+
+	return nil
+}
+
+// requestID resolves the request ID from the scope stored in the context.
+func requestID(ctx context.Context) (*RequestID, error) {
+	scope, ok := dino.ScopeFromContext(ctx)
+	if !ok {
+		return nil, errNoScope
+	}
+
+	id, err := dino.Resolve[*RequestID](scope)
+	if err != nil {
+		return nil, fmt.Errorf("resolve request ID: %w", err)
+	}
+
+	return id, nil
+}
+
+// serviceDesc describes a service returning the request ID of the call, once by a unary call and
+// several times, resolved again for each message, by a streaming call.
+var serviceDesc = grpc.ServiceDesc{
+	ServiceName: "dino.test.RequestIDs",
+	HandlerType: (*any)(nil),
+	Methods: []grpc.MethodDesc{{
+		MethodName: "Get",
+		Handler: func(
+			srv any, ctx context.Context, dec func(any) error, intercept grpc.UnaryServerInterceptor,
+		) (any, error) {
+			if err := dec(&wrapperspb.StringValue{}); err != nil {
+				return nil, err
+			}
+
+			handler := func(ctx context.Context, _ any) (any, error) {
+				id, err := requestID(ctx)
+				if err != nil {
+					return nil, err
+				}
+
+				return wrapperspb.Int64(id.Value), nil
+			}
+
+			info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/dino.test.RequestIDs/Get"}
+
+			return intercept(ctx, nil, info, handler)
+		},
+	}},
+	Streams: []grpc.StreamDesc{{
+		StreamName:    "Watch",
+		ServerStreams: true,
+		Handler: func(_ any, stream grpc.ServerStream) error {
+			for range streamedValues {
+				id, err := requestID(stream.Context())
+				if err != nil {
+					return err
+				}
+
+				if err := stream.SendMsg(wrapperspb.Int64(id.Value)); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	}},
+	Metadata: "",
+}
+
+// newServer serves the service with scope interceptors over an in-memory connection.
+func newServer(t *testing.T, created *atomic.Int64, ids chan<- *RequestID) *grpc.ClientConn {
+	t.Helper()
+
+	di := dino.New()
+
+	if err := di.FactoryWith(func() *RequestID {
+		id := &RequestID{Value: created.Add(1)}
+		ids <- id
+
+		return id
+	}, dino.Scoped()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer(
+		grpc.UnaryInterceptor(grpcdino.UnaryScopeInterceptor(di)),
+		grpc.StreamInterceptor(grpcdino.StreamScopeInterceptor(di)),
+	)
+	server.RegisterService(&serviceDesc, nil)
+
+	go func() {
+		_ = server.Serve(listener)
+	}()
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	t.Cleanup(func() {
+		_ = conn.Close()
+
+		server.Stop()
+	})
+
+	return conn
+}
+
+func TestUnaryScopeInterceptor(t *testing.T) {
+	t.Parallel()
+
+	var created atomic.Int64
+
+	ids := make(chan *RequestID, concurrentCalls)
+	conn := newServer(t, &created, ids)
+
+	var wg sync.WaitGroup
+
+	seen := make(chan int64, concurrentCalls)
+
+	for range concurrentCalls {
+		wg.Go(func() {
+			out := &wrapperspb.Int64Value{}
+			if err := conn.Invoke(t.Context(), "/dino.test.RequestIDs/Get", wrapperspb.String(""), out); err != nil {
+				t.Errorf("unexpected error: %v", err)
+
+				return
+			}
+
+			seen <- out.GetValue()
+		})
+	}
+
+	wg.Wait()
+	close(seen)
+	close(ids)
+
+	distinct := make(map[int64]bool)
+	for value := range seen {
+		distinct[value] = true
+	}
+
+	if len(distinct) != concurrentCalls {
+		t.Fatalf("expected a distinct request ID per call, got %v", distinct)
+	}
+
+	// Every scope is closed once its call returns
+	for id := range ids {
+		if !id.closed.Load() {
+			t.Fatalf("expected request ID %d to be closed", id.Value)
+		}
+	}
+}
+
+func TestStreamScopeInterceptor(t *testing.T) {
+	t.Parallel()
+
+	var created atomic.Int64
+
+	ids := make(chan *RequestID, 2)
+	conn := newServer(t, &created, ids)
+
+	watch := func() map[int64]bool {
+		stream, err := conn.NewStream(t.Context(), &serviceDesc.Streams[0], "/dino.test.RequestIDs/Watch")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if err := stream.SendMsg(wrapperspb.String("")); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if err := stream.CloseSend(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		values := make(map[int64]bool)
+
+		for range streamedValues {
+			out := &wrapperspb.Int64Value{}
+			if err := stream.RecvMsg(out); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			values[out.GetValue()] = true
+		}
+
+		return values
+	}
+
+	first, second := watch(), watch()
+
+	// A stream sees the same instance for all its messages, another stream a new one
+	if len(first) != 1 || len(second) != 1 || fmt.Sprint(first) == fmt.Sprint(second) {
+		t.Fatalf("expected one request ID per stream, got %v and %v", first, second)
+	}
+}
+
+func TestUnaryScopeInterceptor_Panic(t *testing.T) {
+	t.Parallel()
+
+	di := dino.New()
+
+	if err := di.FactoryWith(func() *RequestID { return &RequestID{Value: 1} }, dino.Scoped()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var id *RequestID
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected the panic to be propagated")
+		}
+
+		if id == nil || !id.closed.Load() {
+			t.Fatal("expected the scope to be closed")
+		}
+	}()
+
+	_, _ = grpcdino.UnaryScopeInterceptor(di)(t.Context(), nil, &grpc.UnaryServerInfo{Server: nil, FullMethod: ""},
+		func(ctx context.Context, _ any) (any, error) {
+			var err error
+
+			id, err = requestID(ctx)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			panic("handler failed")
+		})
+}
@@ -0,0 +1,130 @@
+package dino
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+)
+
+// handlerParam is the source of a parameter of a function adapted with HandlerFunc.
+type handlerParam int
+
+const (
+	// handlerDependency parameters are resolved from the container.
+	handlerDependency handlerParam = iota
+	// handlerWriter parameters receive the response writer of the request.
+	handlerWriter
+	// handlerRequest parameters receive the request.
+	handlerRequest
+)
+
+// HandlerFunc adapts fn to an http.HandlerFunc. The parameters of fn may be an http.ResponseWriter,
+// an *http.Request and any dependency the container can resolve, in any order; fn may return
+// nothing or an error. Dependencies are resolved for every request from the container stored
+// in the request context by Middleware or NewContext, if any, or from d. If they cannot be resolved
+// or fn returns an error, the request fails with status 500 and the error is logged by the logger set
+// with WithLogger.
+// The signature of fn is checked once, here: HandlerFunc panics if fn is not such a function.
+func HandlerFunc(d *Dino, fn any) http.HandlerFunc {
+	rv := reflect.ValueOf(fn)
+
+	if isNil(rv) {
+		panic(fmt.Errorf("%w: handler function cannot be nil", ErrInvalidInputValue))
+	}
+
+	rt := rv.Type()
+
+	if !isFunction(rt) {
+		panic(fmt.Errorf("%w: handler expected a function, got %v", ErrInvalidInputValue, rt.Kind()))
+	}
+
+	if rt.NumOut() > 1 || (rt.NumOut() == 1 && rt.Out(0) != reflect.TypeFor[error]()) {
+		panic(fmt.Errorf("%w: handler %s must return nothing or an error", ErrInvalidInputValue, rt))
+	}
+
+	plan := make([]handlerParam, rt.NumIn())
+
+	for idx := range plan {
+		switch rt.In(idx) {
+		case reflect.TypeFor[http.ResponseWriter]():
+			plan[idx] = handlerWriter
+
+		case reflect.TypeFor[*http.Request]():
+			plan[idx] = handlerRequest
+
+		default:
+			plan[idx] = handlerDependency
+		}
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		container := d
+
+		if stored, ok := FromContext(r.Context()); ok {
+			container = stored
+		}
+
+		args := acquireArgs(len(plan))
+		defer releaseArgs(args)
+
+		if err := container.prepareHandler(rt, plan, *args, w, r); err != nil {
+			container.failHandler(w, r, fmt.Errorf("failed to prepare handler: %w", err))
+
+			return
+		}
+
+		out := rv.Call(*args)
+
+		if len(out) == 0 {
+			return
+		}
+
+		if err, ok := out[0].Interface().(error); ok && err != nil {
+			container.failHandler(w, r, err)
+		}
+	}
+}
+
+// prepareHandler fills args following the plan of the handler function type, resolving its dependencies.
+func (d *Dino) prepareHandler(
+	fn reflect.Type,
+	plan []handlerParam,
+	args []reflect.Value,
+	w http.ResponseWriter,
+	r *http.Request,
+) error {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	injector := newInjector(d.registry, d.options)
+
+	for idx, param := range plan {
+		switch param {
+		case handlerWriter:
+			args[idx] = reflect.ValueOf(&w).Elem()
+
+		case handlerRequest:
+			args[idx] = reflect.ValueOf(r)
+
+		case handlerDependency:
+			rv, err := injector.prepareArg(fn.In(idx))
+			if err != nil {
+				d.options.stats.resolutionError()
+
+				return fmt.Errorf("parameter %d of type %s: %w", idx, fn.In(idx), err)
+			}
+
+			args[idx] = rv
+		}
+	}
+
+	return nil
+}
+
+// failHandler responds to a request failed by a handler adapted with HandlerFunc with status 500,
+// logging the error.
+func (d *Dino) failHandler(w http.ResponseWriter, r *http.Request, err error) {
+	d.options.logger.handlerFailure(r, err)
+
+	http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+}
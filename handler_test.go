@@ -0,0 +1,179 @@
+package dino_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/yuppyweb/dino"
+)
+
+var errUserNotFound = errors.New("user not found")
+
+type UserService struct {
+	Name string
+}
+
+type UserRepository struct {
+	Failing bool
+}
+
+func handlerRequest(t *testing.T, handler http.Handler) *httptest.ResponseRecorder {
+	t.Helper()
+
+	request := httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/users/1", nil)
+	recorder := httptest.NewRecorder()
+
+	handler.ServeHTTP(recorder, request)
+
+	return recorder
+}
+
+func TestHandlerFunc(t *testing.T) {
+	t.Parallel()
+
+	di := dino.New()
+
+	if err := di.Singleton(&UserService{Name: "alice"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	handler := dino.HandlerFunc(di, func(r *http.Request, users *UserService, w http.ResponseWriter) {
+		_, _ = w.Write([]byte(r.URL.Path + " " + users.Name))
+	})
+
+	recorder := handlerRequest(t, handler)
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", recorder.Code)
+	}
+
+	if got := recorder.Body.String(); got != "/users/1 alice" {
+		t.Fatalf("expected the request path and user name, got %q", got)
+	}
+}
+
+func TestHandlerFunc_Error(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		fn   any
+		err  string
+	}{
+		{
+			name: "returned error",
+			fn: func(*http.Request, *UserService) error {
+				return errUserNotFound
+			},
+			err: errUserNotFound.Error(),
+		},
+		{
+			name: "unresolvable dependency",
+			fn: func(*http.Request, *UserRepository) error {
+				t.Error("expected the handler not to be called")
+
+				return nil
+			},
+			err: "*dino_test.UserRepository",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			logs := &captureHandler{}
+			di := dino.New(dino.WithLogger(slog.New(logs)))
+
+			if err := di.Factory(func() (*UserRepository, error) {
+				return nil, errUserNotFound
+			}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			recorder := handlerRequest(t, dino.HandlerFunc(di, tt.fn))
+
+			if recorder.Code != http.StatusInternalServerError {
+				t.Fatalf("expected status 500, got %d", recorder.Code)
+			}
+
+			// The error is logged, never written to the client
+			if strings.Contains(recorder.Body.String(), errUserNotFound.Error()) {
+				t.Fatalf("expected the error not to be exposed, got %q", recorder.Body.String())
+			}
+
+			logs.mutex.Lock()
+			defer logs.mutex.Unlock()
+
+			for _, record := range logs.records {
+				if record.msg == "dino: handler failed" {
+					if record.level != slog.LevelError || record.attrs["path"] != "/users/1" ||
+						!strings.Contains(record.attrs["error"], tt.err) {
+						t.Fatalf("unexpected record: %+v", record)
+					}
+
+					return
+				}
+			}
+
+			t.Fatalf("expected the failure to be logged, got %+v", logs.records)
+		})
+	}
+}
+
+func TestHandlerFunc_Scope(t *testing.T) {
+	t.Parallel()
+
+	di := dino.New()
+
+	if err := di.FactoryWith(func() *UserService { return &UserService{Name: "request"} }, dino.Scoped()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var users []*UserService
+
+	handler := di.Middleware(dino.HandlerFunc(di, func(service *UserService) {
+		users = append(users, service)
+	}))
+
+	handlerRequest(t, handler)
+	handlerRequest(t, handler)
+
+	if len(users) != 2 || users[0] == users[1] {
+		t.Fatalf("expected an instance per request scope, got %v", users)
+	}
+}
+
+func TestHandlerFunc_InvalidSignature(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		fn   any
+	}{
+		{name: "nil", fn: nil},
+		{name: "not a function", fn: &UserService{}},
+		{name: "non error result", fn: func(*http.Request) string { return "" }},
+		{name: "several results", fn: func(*http.Request) (string, error) { return "", nil }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			defer func() {
+				err, ok := recover().(error)
+				if !ok || !errors.Is(err, dino.ErrInvalidInputValue) {
+					t.Fatalf("expected a panic with ErrInvalidInputValue, got %v", err)
+				}
+			}()
+
+			dino.HandlerFunc(dino.New(), tt.fn)
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package dino
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// defaultHealthCheckTimeout bounds every health check unless WithHealthCheckTimeout is used.
+const defaultHealthCheckTimeout = 5 * time.Second
+
+var (
+	ErrHealthCheckTimeout = errors.New("health check timed out")
+	ErrHealthCheckPanic   = errors.New("health check panicked")
+)
+
+// HealthChecker is implemented by instances that can report whether they are healthy.
+type HealthChecker interface {
+	HealthCheck(ctx context.Context) error
+}
+
+// WithHealthCheckTimeout sets how long every health check run by Dino.Health may take, 5 seconds by default.
+func WithHealthCheckTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.healthCheckTimeout = timeout
+	}
+}
+
+// Health calls HealthCheck on every registered or cached instance implementing HealthChecker and returns
+// the results keyed by type name, followed by the tag in brackets for tagged instances. Healthy instances
+// map to nil. Factories that were not resolved yet are never called. Checks run concurrently, each bounded
+// by the health check timeout, and a panicking check is reported as ErrHealthCheckPanic.
+func (d *Dino) Health(ctx context.Context) map[string]error {
+	checkers := make(map[string]HealthChecker)
+
+	d.mutex.RLock()
+
+	for key, rv := range d.registry.All() {
+		if isFactory(key, rv) || !rv.CanInterface() {
+			continue
+		}
+
+		if checker, ok := rv.Interface().(HealthChecker); ok {
+			checkers[keyName(key)] = checker
+		}
+	}
+
+	d.mutex.RUnlock()
+
+	var (
+		wg      sync.WaitGroup
+		mutex   sync.Mutex
+		results = make(map[string]error, len(checkers))
+	)
+
+	for name, checker := range checkers {
+		wg.Go(func() {
+			err := d.healthCheck(ctx, checker)
+
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			results[name] = err
+		})
+	}
+
+	wg.Wait()
+
+	return results
+}
+
+// healthCheck runs a single check within the health check timeout.
+func (d *Dino) healthCheck(ctx context.Context, checker HealthChecker) error {
+	ctx, cancel := context.WithTimeout(ctx, d.options.healthCheckTimeout)
+	defer cancel()
+
+	done := make(chan error, 1)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- fmt.Errorf("%w: %v", ErrHealthCheckPanic, r)
+			}
+		}()
+
+		done <- checker.HealthCheck(ctx)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return fmt.Errorf("%w: %w", ErrHealthCheckTimeout, ctx.Err())
+	}
+}
+
+// HealthHandler returns an HTTP handler reporting the results of Health as a JSON object mapping every
+// checked instance to "ok" or its error message. It responds with 503 Service Unavailable if any check fails.
+func (d *Dino) HealthHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		report := make(map[string]string)
+		status := http.StatusOK
+
+		for name, err := range d.Health(r.Context()) {
+			if err != nil {
+				report[name] = err.Error()
+				status = http.StatusServiceUnavailable
+
+				continue
+			}
+
+			report[name] = "ok"
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+
+		_ = json.NewEncoder(w).Encode(report)
+	}
+}
@@ -0,0 +1,161 @@
+package dino_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/yuppyweb/dino"
+)
+
+type HealthyDatabase struct{}
+
+func (HealthyDatabase) HealthCheck(context.Context) error {
+	return nil
+}
+
+type FailingCache struct {
+	err error
+}
+
+func (c *FailingCache) HealthCheck(context.Context) error {
+	return c.err
+}
+
+type PanickingQueue struct{}
+
+func (*PanickingQueue) HealthCheck(context.Context) error {
+	panic("queue check failed")
+}
+
+type SlowBroker struct {
+	release chan struct{}
+}
+
+func (b *SlowBroker) HealthCheck(context.Context) error {
+	<-b.release
+
+	return nil
+}
+
+func TestDino_Health(t *testing.T) {
+	t.Parallel()
+
+	di := dino.New()
+
+	if err := di.Singleton(HealthyDatabase{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := di.Singleton(&FailingCache{err: errHookFailed}, "sessions"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := di.Singleton(&PanickingQueue{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	called := false
+
+	// Unresolved factories are never called to check their health
+	if err := di.Factory(func() *FailingCache {
+		called = true
+
+		return &FailingCache{err: nil}
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	results := di.Health(context.Background())
+
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results, got %v", results)
+	}
+
+	if err, ok := results["dino_test.HealthyDatabase"]; !ok || err != nil {
+		t.Fatalf("expected the database to be healthy, got %v", results)
+	}
+
+	if err := results["*dino_test.FailingCache[sessions]"]; !errors.Is(err, errHookFailed) {
+		t.Fatalf("expected the cache check to fail, got %v", err)
+	}
+
+	if err := results["*dino_test.PanickingQueue"]; !errors.Is(err, dino.ErrHealthCheckPanic) {
+		t.Fatalf("expected ErrHealthCheckPanic, got %v", err)
+	}
+
+	if called {
+		t.Fatalf("expected Health not to call factories")
+	}
+}
+
+func TestDino_HealthTimeout(t *testing.T) {
+	t.Parallel()
+
+	broker := &SlowBroker{release: make(chan struct{})}
+	defer close(broker.release)
+
+	di := dino.New(dino.WithHealthCheckTimeout(10 * time.Millisecond))
+
+	if err := di.Singleton(broker); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err := di.Health(context.Background())["*dino_test.SlowBroker"]
+	if !errors.Is(err, dino.ErrHealthCheckTimeout) {
+		t.Fatalf("expected ErrHealthCheckTimeout, got %v", err)
+	}
+}
+
+func TestDino_HealthHandler(t *testing.T) {
+	t.Parallel()
+
+	cache := &FailingCache{err: errHookFailed}
+
+	di := dino.New()
+
+	if err := di.Singleton(HealthyDatabase{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := di.Singleton(cache); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	request := httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/health", nil)
+
+	recorder := httptest.NewRecorder()
+	di.HealthHandler()(recorder, request)
+
+	if recorder.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected status 503, got %d", recorder.Code)
+	}
+
+	var report map[string]string
+	if err := json.Unmarshal(recorder.Body.Bytes(), &report); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := map[string]string{
+		"dino_test.HealthyDatabase": "ok",
+		"*dino_test.FailingCache":   errHookFailed.Error(),
+	}
+
+	if !maps.Equal(report, expected) {
+		t.Fatalf("expected report %v, got %v", expected, report)
+	}
+
+	cache.err = nil
+
+	recorder = httptest.NewRecorder()
+	di.HealthHandler()(recorder, request)
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected status 200 once healthy, got %d", recorder.Code)
+	}
+}
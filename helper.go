@@ -1,9 +1,18 @@
 package dino
 
 import (
+	"fmt"
 	"reflect"
+	"strconv"
+	"strings"
 )
 
+// maxTagDistance is the largest edit distance at which a registered tag is suggested for a missing one.
+const maxTagDistance = 2
+
+// maxProviderOuts is the number of results of a provider function returning an error alongside its value.
+const maxProviderOuts = 2
+
 // isStruct reports whether rt is a struct type.
 func isStruct(rt reflect.Type) bool {
 	return rt.Kind() == reflect.Struct
@@ -14,11 +23,80 @@ func isPointerToStruct(rt reflect.Type) bool {
 	return rt.Kind() == reflect.Pointer && isStruct(rt.Elem())
 }
 
+// structOf returns the struct type behind rt if rt is a pointer to struct, and rt itself otherwise.
+func structOf(rt reflect.Type) reflect.Type {
+	if isPointerToStruct(rt) {
+		return rt.Elem()
+	}
+
+	return rt
+}
+
 // isFunction reports whether rt is a function type.
 func isFunction(rt reflect.Type) bool {
 	return rt.Kind() == reflect.Func
 }
 
+// isFactory reports whether the value stored under the key is a factory function to call rather than
+// the value itself.
+func isFactory(key RegistryKey, rv reflect.Value) bool {
+	return rv.IsValid() && isFunction(rv.Type()) && rv.Type() != key.Type
+}
+
+// isAssignable reports whether a value of type vt can be stored under the key type kt:
+// either vt is assignable to kt, or vt is a factory function with a non-error output, or a field of
+// an Out result object, assignable to kt.
+func isAssignable(vt, kt reflect.Type) bool {
+	if vt.AssignableTo(kt) {
+		return true
+	}
+
+	if !isFunction(vt) {
+		return false
+	}
+
+	for outType := range vt.Outs() {
+		if isOutStruct(outType) {
+			for _, field := range outFields(outType) {
+				if field.typ.AssignableTo(kt) {
+					return true
+				}
+			}
+
+			continue
+		}
+
+		if !isError(outType) && outType.AssignableTo(kt) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isProvider reports whether rt is a lazy provider function type: func() T or func() (T, error).
+func isProvider(rt reflect.Type) bool {
+	if !isFunction(rt) || rt.NumIn() != 0 || rt.IsVariadic() {
+		return false
+	}
+
+	switch rt.NumOut() {
+	case 1:
+		return !isError(rt.Out(0))
+
+	case maxProviderOuts:
+		return !isError(rt.Out(0)) && rt.Out(1) == reflect.TypeFor[error]()
+
+	default:
+		return false
+	}
+}
+
+// isError reports whether rt implements the error interface.
+func isError(rt reflect.Type) bool {
+	return rt.Implements(reflect.TypeFor[error]())
+}
+
 // isNil reports whether rv is nil or invalid.
 func isNil(rv reflect.Value) bool {
 	if !rv.IsValid() {
@@ -35,7 +113,14 @@ func isNil(rv reflect.Value) bool {
 }
 
 // asError extracts an error from rv if it implements the error interface and is not nil.
+// An interface holding a nil pointer is not an error, and neither is a value read through
+// unexported fields, which cannot be interfaced.
 func asError(rv reflect.Value) error {
+	// Look at the dynamic value, calling Error on a typed nil would dereference it
+	for rv.IsValid() && rv.Kind() == reflect.Interface && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+
 	if isNil(rv) || !rv.CanInterface() {
 		return nil
 	}
@@ -46,3 +131,112 @@ func asError(rv reflect.Value) error {
 
 	return nil
 }
+
+// registeredTags returns the tags of the provided registry keys.
+func registeredTags(keys []RegistryKey) []string {
+	tags := make([]string, len(keys))
+
+	for idx, key := range keys {
+		tags[idx] = key.Tag
+	}
+
+	return tags
+}
+
+// quoteTags renders tags as a comma-separated list of quoted strings.
+func quoteTags(tags []string) string {
+	quoted := make([]string, len(tags))
+
+	for idx, tag := range tags {
+		quoted[idx] = strconv.Quote(tag)
+	}
+
+	return strings.Join(quoted, ", ")
+}
+
+// closestTag returns the tag from tags that most likely is a misspelling of tag.
+// A case-insensitive match wins, otherwise the tag with the smallest edit distance
+// not exceeding maxTagDistance is returned.
+func closestTag(tags []string, tag string) (string, bool) {
+	best, bestDist := "", maxTagDistance+1
+
+	for _, candidate := range tags {
+		if candidate == tag {
+			continue
+		}
+
+		if strings.EqualFold(candidate, tag) {
+			return candidate, true
+		}
+
+		if dist := editDistance(candidate, tag); dist < bestDist && dist < len(candidate) {
+			best, bestDist = candidate, dist
+		}
+	}
+
+	return best, bestDist <= maxTagDistance
+}
+
+// editDistance computes the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	src, dst := []rune(a), []rune(b)
+	prev := make([]int, len(dst)+1)
+	curr := make([]int, len(dst)+1)
+
+	for idx := range prev {
+		prev[idx] = idx
+	}
+
+	for i := 1; i <= len(src); i++ {
+		curr[0] = i
+
+		for j := 1; j <= len(dst); j++ {
+			cost := 1
+			if src[i-1] == dst[j-1] {
+				cost = 0
+			}
+
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+
+		prev, curr = curr, prev
+	}
+
+	return prev[len(dst)]
+}
+
+// keyName describes a registry key as its type name, followed by the tag in brackets and the qualifier
+// in parentheses if it has them.
+func keyName(key RegistryKey) string {
+	name := key.Type.String()
+
+	if key.Tag != "" {
+		name = fmt.Sprintf("%s[%s]", name, key.Tag)
+	}
+
+	if key.Qualifier != nil {
+		name = fmt.Sprintf("%s(%#v)", name, key.Qualifier)
+	}
+
+	return name
+}
+
+// taggedKeys returns the keys of the type with the qualifier under each tag, or the untagged key without tags.
+func taggedKeys(rt reflect.Type, qualifier any, tags []string) []RegistryKey {
+	if len(tags) == 0 {
+		tags = []string{""}
+	}
+
+	keys := make([]RegistryKey, len(tags))
+
+	for idx, tag := range tags {
+		keys[idx] = RegistryKey{
+			Tag:       tag,
+			Type:      rt,
+			Scope:     "",
+			Qualifier: qualifier,
+		}
+	}
+
+	return keys
+}
@@ -245,6 +245,134 @@ func TestHelper_IsFunction(t *testing.T) {
 	}
 }
 
+func TestHelper_IsAssignable(t *testing.T) {
+	t.Parallel()
+
+	type Service struct{}
+
+	testCases := []struct {
+		name      string
+		valueType reflect.Type
+		keyType   reflect.Type
+		expected  bool
+	}{
+		{
+			name:      "Same type",
+			valueType: reflect.TypeFor[*Service](),
+			keyType:   reflect.TypeFor[*Service](),
+			expected:  true,
+		},
+		{
+			name:      "Implements interface",
+			valueType: reflect.TypeFor[*customError](),
+			keyType:   reflect.TypeFor[error](),
+			expected:  true,
+		},
+		{
+			name:      "Different type",
+			valueType: reflect.TypeFor[int](),
+			keyType:   reflect.TypeFor[string](),
+			expected:  false,
+		},
+		{
+			name:      "Factory with matching output",
+			valueType: reflect.TypeFor[func() (*Service, error)](),
+			keyType:   reflect.TypeFor[*Service](),
+			expected:  true,
+		},
+		{
+			name:      "Factory with second matching output",
+			valueType: reflect.TypeFor[func() (int, *Service)](),
+			keyType:   reflect.TypeFor[*Service](),
+			expected:  true,
+		},
+		{
+			name:      "Factory with wrong output",
+			valueType: reflect.TypeFor[func() int](),
+			keyType:   reflect.TypeFor[*Service](),
+			expected:  false,
+		},
+		{
+			name:      "Error output does not count",
+			valueType: reflect.TypeFor[func() error](),
+			keyType:   reflect.TypeFor[error](),
+			expected:  false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			result := dino.MockIsAssignable(tc.valueType, tc.keyType)
+
+			if result != tc.expected {
+				t.Errorf("expected %v, got %v", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestHelper_IsProvider(t *testing.T) {
+	t.Parallel()
+
+	type Service struct{}
+
+	testCases := []struct {
+		name     string
+		input    reflect.Type
+		expected bool
+	}{
+		{
+			name:     "Value provider",
+			input:    reflect.TypeFor[func() *Service](),
+			expected: true,
+		},
+		{
+			name:     "Provider with error",
+			input:    reflect.TypeFor[func() (*Service, error)](),
+			expected: true,
+		},
+		{
+			name:     "Function with arguments",
+			input:    reflect.TypeFor[func(int) *Service](),
+			expected: false,
+		},
+		{
+			name:     "Function without results",
+			input:    reflect.TypeFor[func()](),
+			expected: false,
+		},
+		{
+			name:     "Error only",
+			input:    reflect.TypeFor[func() error](),
+			expected: false,
+		},
+		{
+			name:     "Second result is not an error",
+			input:    reflect.TypeFor[func() (*Service, int)](),
+			expected: false,
+		},
+		{
+			name:     "Not a function",
+			input:    reflect.TypeFor[*Service](),
+			expected: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			result := dino.MockIsProvider(tc.input)
+
+			if result != tc.expected {
+				t.Errorf("expected %v, got %v", tc.expected, result)
+			}
+		})
+	}
+}
+
 func TestHelper_IsNil(t *testing.T) {
 	t.Parallel()
 
@@ -414,6 +542,167 @@ func TestHelper_AsError(t *testing.T) {
 	}
 }
 
+func TestHelper_NilAndErrorValues(t *testing.T) {
+	t.Parallel()
+
+	var (
+		nilCustom   *customError
+		wrappedNil  error = nilCustom
+		wrappedErr  error = &customError{"wrapped"}
+		valueErr    error = valueError{"by value"}
+		unexported        = struct{ err error }{err: &customError{"hidden"}}
+		nilFunction func() error
+	)
+
+	testCases := []struct {
+		name  string
+		input reflect.Value
+		isNil bool
+		err   string
+	}{
+		{
+			name:  "Zero reflect.Value",
+			input: reflect.Value{},
+			isNil: true,
+			err:   "",
+		},
+		{
+			name:  "Nil custom error pointer",
+			input: reflect.ValueOf(nilCustom),
+			isNil: true,
+			err:   "",
+		},
+		{
+			name:  "Nil custom error pointer in an error interface",
+			input: reflect.ValueOf(&wrappedNil).Elem(),
+			isNil: false,
+			err:   "",
+		},
+		{
+			name:  "Custom error in an error interface",
+			input: reflect.ValueOf(&wrappedErr).Elem(),
+			isNil: false,
+			err:   "wrapped",
+		},
+		{
+			name:  "Custom error struct by value",
+			input: reflect.ValueOf(&valueErr).Elem(),
+			isNil: false,
+			err:   "by value",
+		},
+		{
+			name:  "Error in an unexported field",
+			input: reflect.ValueOf(unexported).Field(0),
+			isNil: false,
+			err:   "",
+		},
+		{
+			name:  "Nil error function",
+			input: reflect.ValueOf(nilFunction),
+			isNil: true,
+			err:   "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if result := dino.MockIsNil(tc.input); result != tc.isNil {
+				t.Errorf("expected isNil %v, got %v", tc.isNil, result)
+			}
+
+			result := dino.MockAsError(tc.input)
+
+			switch {
+			case tc.err == "" && result != nil:
+				t.Errorf("expected nil, got %v", result)
+
+			case tc.err != "" && (result == nil || result.Error() != tc.err):
+				t.Errorf("expected error %q, got %v", tc.err, result)
+			}
+		})
+	}
+}
+
+func TestHelper_ClosestTag(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		tags     []string
+		tag      string
+		expected string
+		found    bool
+	}{
+		{
+			name:     "Small edit distance",
+			tags:     []string{"primary", "replica"},
+			tag:      "primari",
+			expected: "primary",
+			found:    true,
+		},
+		{
+			name:     "Case-insensitive match",
+			tags:     []string{"replica", "Primary"},
+			tag:      "PRIMARY",
+			expected: "Primary",
+			found:    true,
+		},
+		{
+			name:     "Closest of several candidates",
+			tags:     []string{"cache", "cachex", "caches"},
+			tag:      "cahce",
+			expected: "cache",
+			found:    true,
+		},
+		{
+			name:     "Too far from any tag",
+			tags:     []string{"primary", "replica"},
+			tag:      "metrics",
+			expected: "",
+			found:    false,
+		},
+		{
+			name:     "Exact match is not a suggestion",
+			tags:     []string{"primary"},
+			tag:      "primary",
+			expected: "",
+			found:    false,
+		},
+		{
+			name:     "Empty tag is never suggested",
+			tags:     []string{""},
+			tag:      "x",
+			expected: "",
+			found:    false,
+		},
+		{
+			name:     "No tags",
+			tags:     nil,
+			tag:      "primary",
+			expected: "",
+			found:    false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			result, found := dino.MockClosestTag(tc.tags, tc.tag)
+
+			if found != tc.found {
+				t.Errorf("expected found to be %v, got %v", tc.found, found)
+			}
+
+			if result != tc.expected {
+				t.Errorf("expected suggestion '%s', got '%s'", tc.expected, result)
+			}
+		})
+	}
+}
+
 type customError struct {
 	message string
 }
@@ -421,3 +710,11 @@ type customError struct {
 func (c *customError) Error() string {
 	return c.message
 }
+
+type valueError struct {
+	message string
+}
+
+func (e valueError) Error() string {
+	return e.message
+}
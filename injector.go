@@ -1,62 +1,165 @@
 package dino
 
 import (
+	"cmp"
 	"errors"
 	"fmt"
+	"log/slog"
 	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
 )
 
 var (
-	ErrExpectedStruct     = errors.New("expected struct or pointer to struct")
-	ErrExpectedFunction   = errors.New("expected function")
-	ErrCircularDependency = errors.New("circular dependency detected")
+	ErrExpectedStruct          = errors.New("expected struct or pointer to struct")
+	ErrExpectedPointerToStruct = errors.New("expected pointer to struct")
+	ErrExpectedFunction        = errors.New("expected function")
+	ErrCircularDependency      = errors.New("circular dependency detected")
+	ErrNestedIn                = errors.New("nested dino.In structs are not supported")
+	ErrAutoCreateDepth         = errors.New("auto-creation depth exceeded")
 )
 
 // Injector is responsible for managing dependencies, injecting values into structs,
-// and invoking functions with resolved arguments.
+// and invoking functions with resolved arguments. An Injector is safe for concurrent use:
+// every Inject, Invoke, Resolve and Prepare call tracks its resolution state separately.
 type Injector struct {
 	registry Registry
+	options  *options
 	stack    map[RegistryKey]struct{}
+	creating map[reflect.Type]struct{}
+	path     []ResolutionStep
+	// caller is the injector whose function arguments this one resolves in parallel, if any.
+	caller *Injector
+	// report collects the outcomes of the injected fields when requested by InjectReport.
+	report *reportRecorder
+	// dryRun walks the injection for Preview without calling factories or setting fields.
+	dryRun bool
 }
 
-// NewInjector creates a new Injector with the provided registry.
+// NewInjector creates a new Injector with the provided registry and options.
 // If no registry is provided, it uses a default SyncMapRegistry.
-func NewInjector(registry Registry) *Injector {
+func NewInjector(registry Registry, opts ...Option) *Injector {
+	return newInjector(registry, newOptions(opts...))
+}
+
+// newInjector creates a new Injector sharing already built options.
+func newInjector(registry Registry, opts *options) *Injector {
 	if registry == nil {
 		registry = new(SyncMapRegistry)
 	}
 
+	// The resolution state is allocated on first use, most resolutions hit cached instances
 	return &Injector{
 		registry: registry,
-		stack:    make(map[RegistryKey]struct{}),
+		options:  opts,
+		stack:    nil,
+		creating: nil,
+		path:     nil,
+		caller:   nil,
+		report:   nil,
+		dryRun:   false,
 	}
 }
 
+// fork returns an injector sharing the registry and options with a fresh resolution state for a single call.
+func (i *Injector) fork() *Injector {
+	return newInjector(i.registry, i.options)
+}
+
 // Bind registers a value in the registry for the specified type and optional tags.
+// Binding under several tags is atomic: if one of the registrations fails, the keys already
+// written are restored to their previous state and nothing is registered.
 func (i *Injector) Bind(rt reflect.Type, rv reflect.Value, tags ...string) error {
-	if len(tags) == 0 {
-		tags = []string{""}
-	}
+	return i.bind(taggedKeys(rt, nil, tags), rv)
+}
 
-	for _, tag := range tags {
-		key := RegistryKey{
-			Tag:  tag,
-			Type: rt,
+// bind registers the value under every key, atomically like Bind.
+func (i *Injector) bind(keys []RegistryKey, rv reflect.Value) error {
+	// A single registration either happens or not, there is nothing to roll back
+	if len(keys) == 1 {
+		if err := i.registry.Register(keys[0], rv); err != nil {
+			return fmt.Errorf("bind value to registry: %w", err)
 		}
 
-		if err := i.registry.Register(key, rv); err != nil {
-			return fmt.Errorf("bind value to registry: %w", err)
+		return nil
+	}
+
+	written := make([]registration, 0, len(keys))
+
+	for _, key := range keys {
+		// Remember the previous value to restore it on rollback
+		prev, err := i.registry.Find(key)
+
+		if regErr := i.registry.Register(key, rv); regErr != nil {
+			return errors.Join(
+				fmt.Errorf("bind value to registry with tag '%s', nothing was registered: %w", key.Tag, regErr),
+				i.rollback(written),
+			)
 		}
+
+		written = append(written, registration{
+			key:   key,
+			prev:  prev,
+			found: err == nil,
+		})
 	}
 
 	return nil
 }
 
+// registration records a key written by Bind together with the value it replaced.
+type registration struct {
+	key   RegistryKey
+	prev  reflect.Value
+	found bool
+}
+
+// rollback undoes the registrations in reverse order, restoring replaced values and deleting new ones.
+func (i *Injector) rollback(written []registration) error {
+	var errs []error
+
+	for _, reg := range slices.Backward(written) {
+		var err error
+
+		if reg.found {
+			err = i.registry.Register(reg.key, reg.prev)
+		} else {
+			err = i.registry.Delete(reg.key)
+		}
+
+		if err != nil {
+			errs = append(errs, fmt.Errorf("roll back type %s with tag '%s': %w", reg.key.Type, reg.key.Tag, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
 // Inject resolves and sets dependencies on the provided struct value based on "inject" tags and registered values.
 func (i *Injector) Inject(rv reflect.Value) error {
+	return i.fork().injectTarget(rv)
+}
+
+// injectTarget injects the struct value within the resolution state of the current call,
+// recording it as the target on the resolution path.
+func (i *Injector) injectTarget(rv reflect.Value) error {
+	i.enter(RegistryKey{Tag: "", Type: rv.Type(), Scope: "", Qualifier: nil}, StepTarget)
+	defer i.leave()
+
+	return i.inject(rv)
+}
+
+// inject sets dependencies on the provided struct value without recording it as a target on the resolution path.
+func (i *Injector) inject(rv reflect.Value) error {
 	rt := rv.Type()
 
 	if isPointerToStruct(rt) {
+		if rv.IsNil() {
+			return fmt.Errorf("%w: got nil %s", ErrInvalidInputValue, rt)
+		}
+
 		// If pointer to struct, get struct value
 		rv = reflect.Indirect(rv)
 		rt = rv.Type()
@@ -66,54 +169,232 @@ func (i *Injector) Inject(rv reflect.Value) error {
 		return fmt.Errorf("%w: got %s", ErrExpectedStruct, rt.Kind())
 	}
 
+	// A struct passed by value is a copy: injected fields would never reach the caller
+	if !rv.CanAddr() {
+		return fmt.Errorf("%w: got %s, pass a pointer to it instead", ErrExpectedPointerToStruct, rt)
+	}
+
+	// Mark the struct type as being injected so that auto-creation does not recurse into it again
+	if i.creating == nil {
+		i.creating = make(map[reflect.Type]struct{})
+	}
+
+	i.creating[rt] = struct{}{}
+
+	defer delete(i.creating, rt)
+
+	// Parameter objects report every unresolvable field at once, like the parameters they stand for
+	aggregate := i.options.aggregateErrors && isInStruct(rt)
+
+	var errs []error
+
 	// Iterate over fields
 	for idx := range rv.NumField() {
 		field := rv.Field(idx)
 
-		// Skip unexported fields
-		if !field.CanSet() {
+		// Skip unexported fields and the dino.In marker
+		if !field.CanSet() || field.Type() == reflect.TypeFor[In]() {
+			if field.Type() != reflect.TypeFor[In]() {
+				i.record(rt.Field(idx).Name, fieldOutcome(field.Type(), FieldSkipped))
+			}
+
 			continue
 		}
 
-		fieldType := field.Type()
+		err := i.injectField(field, rt.Field(idx))
+		if err == nil {
+			continue
+		}
+
+		if !aggregate {
+			return err
+		}
+
 		fieldStruct := rt.Field(idx)
+		errs = append(errs, fmt.Errorf(
+			"field %s of type %s with tag '%s': %w",
+			fieldStruct.Name,
+			fieldStruct.Type,
+			parseInjectTag(fieldStruct.Tag.Get("inject")).name,
+			err,
+		))
+	}
 
-		// Get tag value for "inject"
-		tag := fieldStruct.Tag.Get("inject")
+	return errors.Join(errs...)
+}
 
-		key := RegistryKey{
-			Tag:  tag,
-			Type: fieldType,
-		}
+// injectField resolves and sets a single struct field based on its "inject" tag.
+func (i *Injector) injectField(field reflect.Value, fieldStruct reflect.StructField) error {
+	fieldType := field.Type()
 
-		val, err := i.Resolve(key)
-		if err == nil {
-			field.Set(val)
+	if isInStruct(structOf(fieldType)) {
+		return fmt.Errorf("%w: field %s of type %s", ErrNestedIn, fieldStruct.Name, fieldType)
+	}
 
-			continue
-		}
+	// Get tag value for "inject"
+	tag := parseInjectTag(fieldStruct.Tag.Get("inject"))
 
-		// If the error is not ErrValueNotFound, return it
-		if !errors.Is(err, ErrValueNotFound) {
-			return fmt.Errorf("resolve field %s: %w", fieldStruct.Name, err)
+	if tag.skip {
+		i.record(fieldStruct.Name, fieldOutcome(fieldType, FieldSkipped))
+
+		return nil
+	}
+
+	// Fields naming an environment variable are never resolved from the registry
+	if tag.env != "" {
+		return i.injectEnv(field, fieldStruct, tag)
+	}
+
+	key, err := i.fieldKey(fieldType, tag)
+	if err != nil {
+		return fmt.Errorf("resolve field %s: %w", fieldStruct.Name, err)
+	}
+
+	outcome := fieldOutcome(fieldType, FieldPreserved)
+	outcome.Key = key
+
+	// Fields set by a previous injection or by the caller are kept, which makes injection idempotent
+	if !field.IsZero() {
+		i.record(fieldStruct.Name, outcome)
+
+		i.enterField(fieldStruct.Name)
+		defer i.leaveField()
+
+		if err := i.revisit(field, key); err != nil {
+			return fmt.Errorf("inject field %s: %w", fieldStruct.Name, err)
 		}
 
-		// If value not found, create a new instance and inject it
-		val = i.Create(fieldType)
+		return nil
+	}
+
+	outcome.Outcome = FieldResolved
+	outcome.Factory = i.factoryOf(key)
 
-		// If the field is a struct or pointer to struct, inject dependencies into it
-		if err := i.Inject(val); err != nil {
-			if !errors.Is(err, ErrExpectedStruct) {
-				return fmt.Errorf("inject field %s: %w", fieldStruct.Name, err)
-			}
+	// Factories resolving the field inject their own dependencies, which are not fields of the target
+	report := i.report
+	i.report = nil
+
+	val, err := i.resolve(key)
+
+	i.report = report
+
+	if err == nil {
+		i.record(fieldStruct.Name, outcome)
+		i.set(field, val)
+
+		return nil
+	}
+
+	// If the error is not ErrValueNotFound, return it
+	if !errors.Is(err, ErrValueNotFound) {
+		return fmt.Errorf("resolve field %s: %w", fieldStruct.Name, err)
+	}
+
+	outcome.Outcome = FieldMissing
+	outcome.Factory = nil
+
+	// Optional fields missing from the registry are left untouched, like those autoCreate would not create
+	if ok, err := i.creatable(key); tag.optional || !ok {
+		if err != nil && !tag.optional {
+			return fmt.Errorf("inject field %s: %w", fieldStruct.Name, err)
 		}
 
-		field.Set(val)
+		i.record(fieldStruct.Name, outcome)
+
+		return nil
 	}
 
+	outcome.Outcome = FieldAutoCreated
+	i.record(fieldStruct.Name, outcome)
+
+	i.enterField(fieldStruct.Name)
+	defer i.leaveField()
+
+	// If value not found, create a new instance and inject it
+	val, err = i.autoCreate(key)
+	if err != nil {
+		return fmt.Errorf("inject field %s: %w", fieldStruct.Name, err)
+	}
+
+	i.set(field, val)
+
 	return nil
 }
 
+// set assigns the resolved value to the field, unless the injection is a dry run.
+func (i *Injector) set(field, val reflect.Value) {
+	if !i.dryRun {
+		field.Set(val)
+	}
+}
+
+// fieldKey returns the key a field of the type is resolved with, looking up the qualifier the tag names.
+func (i *Injector) fieldKey(rt reflect.Type, tag injectTag) (RegistryKey, error) {
+	key := RegistryKey{
+		Tag:       tag.name,
+		Type:      rt,
+		Scope:     "",
+		Qualifier: nil,
+	}
+
+	if tag.qualifier == "" {
+		return key, nil
+	}
+
+	qualifier, err := i.options.qualifierNamed(tag.qualifier)
+	if err != nil {
+		return key, err
+	}
+
+	key.Qualifier = qualifier
+
+	return key, nil
+}
+
+// factoryOf returns the type of the factory function that resolving the key calls, or nil if the key
+// is not registered or its factory already produced the value. An unregistered key resolving the only
+// binding appended for its type calls the factory function of that binding.
+func (i *Injector) factoryOf(key RegistryKey) reflect.Type {
+	rv, err := i.registry.Find(key)
+	if errors.Is(err, ErrValueNotFound) && key.Tag == "" && key.Qualifier == nil {
+		if keys := appendedKeys(i.registry, key.Type); len(keys) == 1 {
+			return i.factoryOf(keys[0])
+		}
+	}
+
+	if err != nil || !isFactory(key, rv) {
+		return nil
+	}
+
+	return rv.Type()
+}
+
+// revisit injects the unset fields of a struct that a field already holds, unless the struct is registered
+// for the key: registered values belong to the registry, while unregistered ones were auto-created by
+// a previous injection or built by the caller. A struct type already being injected is not revisited.
+func (i *Injector) revisit(field reflect.Value, key RegistryKey) error {
+	if !i.revisitable(key) {
+		return nil
+	}
+
+	return i.inject(field)
+}
+
+// revisitable reports whether revisit injects the struct held by a field of the key.
+func (i *Injector) revisitable(key RegistryKey) bool {
+	if !isStruct(key.Type) && !isPointerToStruct(key.Type) {
+		return false
+	}
+
+	if _, exists := i.creating[structOf(key.Type)]; exists {
+		return false
+	}
+
+	_, err := i.registry.Find(key)
+
+	return errors.Is(err, ErrValueNotFound)
+}
+
 // Invoke calls a function with arguments resolved from the registry. The function must be passed as a reflect.Value.
 func (i *Injector) Invoke(rv reflect.Value) ([]reflect.Value, error) {
 	rt := rv.Type()
@@ -122,96 +403,557 @@ func (i *Injector) Invoke(rv reflect.Value) ([]reflect.Value, error) {
 		return nil, fmt.Errorf("%w: got %s", ErrExpectedFunction, rt.Kind())
 	}
 
+	return i.fork().invoke(rv)
+}
+
+// invoke calls the function within the resolution state of the current call.
+func (i *Injector) invoke(rv reflect.Value) ([]reflect.Value, error) {
+	rt := rv.Type()
+
 	// Prepare arguments for the function call
-	args, err := i.Prepare(rt)
-	if err != nil {
+	args := acquireArgs(rt.NumIn())
+	defer releaseArgs(args)
+
+	if err := i.prepare(rt, *args); err != nil {
 		return nil, fmt.Errorf("prepare function execution arguments: %w", err)
 	}
 
-	return rv.Call(args), nil
+	return rv.Call(*args), nil
 }
 
 // Resolve looks up a value from the registry based on the provided key.
 // If the registered value is a factory function, it calls the function to get the actual value.
 func (i *Injector) Resolve(key RegistryKey) (reflect.Value, error) {
+	return i.fork().resolve(key)
+}
+
+// resolve looks up a value from the registry within the resolution state of the current call,
+// reporting the resolution to the trace hook, if any.
+func (i *Injector) resolve(key RegistryKey) (reflect.Value, error) {
+	if i.options.trace == nil || i.dryRun {
+		return i.lookup(key)
+	}
+
+	finish := i.options.trace.ResolveStart(key)
+
+	rv, err := i.lookup(key)
+
+	finish(err)
+
+	return rv, err
+}
+
+// lookup finds the value for the key in the registry, calling the factory registered for it if needed.
+func (i *Injector) lookup(key RegistryKey) (reflect.Value, error) {
 	rv, err := i.registry.Find(key)
+
+	// Plain values never resolve further dependencies, they skip the cycle bookkeeping entirely
+	if err == nil && rv.IsValid() && !isFunction(rv.Type()) && rv.Type().AssignableTo(key.Type) {
+		i.hit(key, false)
+
+		return rv, nil
+	}
+
+	if errors.Is(err, ErrValueNotFound) {
+		i.miss(key)
+
+		// An unregistered logger falls back to the default one
+		if i.options.defaultLogger != nil && key.Type == reflect.TypeFor[*slog.Logger]() && key.Tag == "" {
+			return reflect.ValueOf(i.options.defaultLogger), nil
+		}
+
+		// Appended types resolve their bindings, as a slice or as the only one appended
+		if rv, ok, err := i.lookupAppended(key); ok {
+			return rv, err
+		}
+
+		// Unregistered provider functions of registered types resolve them lazily, which lets cycles construct
+		if provides(i.registry, key) {
+			return i.provider(key), nil
+		}
+
+		return rv, &missingError{
+			key:      key,
+			err:      err,
+			registry: i.registry,
+			once:     sync.Once{},
+			message:  "",
+		}
+	}
+
 	if err != nil {
 		return rv, fmt.Errorf("resolve type %s with tag '%s': %w", key.Type, key.Tag, err)
 	}
 
+	i.hit(key, isFactory(key, rv))
+
 	resVal := reflect.Zero(key.Type)
 
 	// Detect circular dependencies
 	if _, exists := i.stack[key]; exists {
+		return resVal, i.cycle(key, StepFactory, func(step ResolutionStep) bool {
+			return step.Kind == StepFactory && step.Key == key
+		})
+	}
+
+	rt := rv.Type()
+
+	// If the registered value is a factory function, call it to get the actual value
+	if isFunction(rt) && rt != key.Type {
+		// A dry run predicts the factory call without making it
+		if i.dryRun {
+			return resVal, nil
+		}
+
+		// A factory is not called again while another resolution is caching its result
+		unlock, err := i.lockFactory(key)
+		if err != nil {
+			return resVal, err
+		}
+
+		defer unlock()
+
+		if cached, err := i.registry.Find(key); err == nil && !isFactory(key, cached) {
+			return cached, nil
+		}
+
+		// Mark as being resolved, only factories resolve further dependencies
+		if i.stack == nil {
+			i.stack = make(map[RegistryKey]struct{})
+		}
+
+		i.stack[key] = struct{}{}
+
+		defer func() {
+			// Unmark after resolution
+			delete(i.stack, key)
+		}()
+
+		i.enter(key, StepFactory)
+		defer i.leave()
+
+		i.options.stats.factoryCall(key)
+
+		start := i.options.logger.factoryStart(key)
+		timed := i.options.stats.start()
+
+		var res reflect.Value
+
+		// Factories registered with Provide are called without reflection
+		if typed, ok := i.options.typedFactory(key); ok {
+			res, err = i.callTyped(key, typed)
+		} else {
+			res, err = i.call(key, rv)
+		}
+
+		i.options.stats.factoryDone(key, timed, err)
+		i.options.logger.factoryDone(key, start, i.path, err)
+
+		return res, err
+	}
+
+	return rv, nil
+}
+
+// hit reports a registry hit for the key to the stats and the logger, unless the injection is a dry run.
+func (i *Injector) hit(key RegistryKey, factory bool) {
+	if !i.dryRun {
+		i.options.stats.hit(key, factory)
+		i.options.logger.hit(key, factory)
+	}
+}
+
+// miss reports a registry miss for the key to the stats and the logger, unless the injection is a dry run.
+func (i *Injector) miss(key RegistryKey) {
+	if !i.dryRun {
+		i.options.stats.miss(key)
+		i.options.logger.miss(key)
+	}
+}
+
+// providedKey returns the key the provider function type of the key resolves.
+func providedKey(key RegistryKey) RegistryKey {
+	return RegistryKey{
+		Tag:       key.Tag,
+		Type:      key.Type.Out(0),
+		Scope:     key.Scope,
+		Qualifier: key.Qualifier,
+	}
+}
+
+// provides reports whether the key is a provider function type whose result is registered. Providers
+// of unregistered types are not resolved lazily: they are created like any other missing dependency.
+func provides(registry Registry, key RegistryKey) bool {
+	if !isProvider(key.Type) {
+		return false
+	}
+
+	_, err := registry.Find(providedKey(key))
+
+	return err == nil
+}
+
+// provider creates a function of the key type that resolves its first result with the key tag on every call.
+// Called while the current resolution is still calling factories, e.g. by one of them, the function resolves
+// within it: requiring a dependency that is being created is reported as ErrCircularDependency. Called later,
+// it resolves with a fresh injector. A func() T provider panics if the resolution fails, a func() (T, error)
+// provider returns the error instead.
+func (i *Injector) provider(key RegistryKey) reflect.Value {
+	target := providedKey(key)
+
+	// The resolution state when the provider was created, never changed afterwards
+	caller := i.branch()
+
+	return reflect.MakeFunc(key.Type, func([]reflect.Value) []reflect.Value {
+		res := reflect.New(target.Type).Elem()
+
+		call := newInjector(caller.registry, caller.options)
+		if caller.options.holds(caller) {
+			call = caller.branch()
+		}
+
+		rv, err := call.resolve(target)
+		if err == nil {
+			res.Set(rv)
+		}
+
+		if key.Type.NumOut() == 1 {
+			if err != nil {
+				panic(fmt.Errorf("lazy provider for %s with tag '%s': %w", target.Type, target.Tag, err))
+			}
+
+			return []reflect.Value{res}
+		}
+
+		errVal := reflect.New(key.Type.Out(1)).Elem()
+		if err != nil {
+			errVal.Set(reflect.ValueOf(err))
+		}
+
+		return []reflect.Value{res, errVal}
+	})
+}
+
+// lockFactory waits until no concurrent resolution is calling the factory registered for the key and
+// returns the function releasing it to them again. If the resolution calling it waits for a factory
+// this one is calling, ErrCircularDependency is returned instead.
+func (i *Injector) lockFactory(key RegistryKey) (func(), error) {
+	_, opts := i.origin(key)
+
+	unlock, held, ok := opts.factoryLocks.lock(key, i)
+	if ok {
+		return unlock, nil
+	}
+
+	i.enter(key, StepFactory)
+	defer i.leave()
+
+	return nil, i.cycle(held, StepFactory, func(step ResolutionStep) bool {
+		return step.Kind == StepFactory && step.Key == held
+	})
+}
+
+// call invokes the factory function registered for the key, binds its results to the registry
+// for future resolutions and returns the result matching the key. Results are only bound once
+// every error result is known to be nil.
+func (i *Injector) call(key RegistryKey, fn reflect.Value) (reflect.Value, error) {
+	resVal := reflect.Zero(key.Type)
+
+	args := acquireArgs(fn.Type().NumIn())
+	defer releaseArgs(args)
+
+	if err := i.prepare(fn.Type(), *args); err != nil {
 		return resVal, fmt.Errorf(
-			"%w: type %s with tag '%s'",
-			ErrCircularDependency,
+			"prepare factory function arguments of type %s with tag '%s': %w",
 			key.Type,
 			key.Tag,
+			err,
 		)
 	}
 
-	// Mark as being resolved
-	i.stack[key] = struct{}{}
+	// Call the factory function
+	values := fn.Call(*args)
+
+	// A failed call binds none of its results, whatever the position of the error among them
+	for _, val := range values {
+		if err := asError(val); err != nil {
+			return resVal, fmt.Errorf(
+				"%w: factory for %s tag %q: %w",
+				ErrFactoryFailed,
+				key.Type,
+				key.Tag,
+				err,
+			)
+		}
+	}
+
+	matched := false
 
-	defer func() {
-		// Unmark after resolution
-		delete(i.stack, key)
-	}()
+	// Process the returned values from the factory function
+	for _, val := range values {
+		// Skip nil values
+		if isNil(val) {
+			continue
+		}
 
-	rt := rv.Type()
+		// Result objects are not bound themselves, each of their fields is
+		if isOutStruct(val.Type()) {
+			fieldVal, err := i.bindOut(key, val)
+			if err != nil {
+				return resVal, err
+			}
 
-	// If the registered value is a factory function, call it to get the actual value
-	if isFunction(rt) && rt != key.Type {
-		args, err := i.Prepare(rt)
-		if err != nil {
+			if fieldVal.IsValid() {
+				resVal = fieldVal
+				matched = true
+			}
+
+			continue
+		}
+
+		// Bind the returned value to the registry for future resolutions
+		if err := i.cache(RegistryKey{
+			Tag:       key.Tag,
+			Type:      val.Type(),
+			Scope:     "",
+			Qualifier: key.Qualifier,
+		}, val); err != nil {
 			return resVal, fmt.Errorf(
-				"prepare factory function arguments of type %s with tag '%s': %w",
-				key.Type,
+				"bind factory function return value of type %s with tag '%s': %w",
+				val.Type(),
 				key.Tag,
 				err,
 			)
 		}
 
-		// Call the factory function
-		values := rv.Call(args)
+		// Return matching type
+		if val.Type() == key.Type {
+			resVal = val
+			matched = true
+		}
+	}
 
-		// Process the returned values from the factory function
-		for _, val := range values {
-			if err := asError(val); err != nil {
-				return resVal, fmt.Errorf(
-					"factory function for type %s with tag '%s' returned error: %w",
-					key.Type,
-					key.Tag,
-					err,
-				)
+	if matched {
+		return resVal, nil
+	}
+
+	return i.bindAssignable(key, values)
+}
+
+// bindAssignable binds the first result of a factory function assignable to the key type under the key,
+// for factories registered directly in a registry under a type their results implement, e.g. an interface.
+// It returns the zero value of the key type if no result is assignable.
+func (i *Injector) bindAssignable(key RegistryKey, values []reflect.Value) (reflect.Value, error) {
+	for _, val := range values {
+		if isNil(val) || isError(val.Type()) {
+			continue
+		}
+
+		results := []reflect.Value{val}
+
+		if isOutStruct(val.Type()) {
+			results = results[:0]
+
+			for _, field := range outFields(val.Type()) {
+				results = append(results, val.Field(field.index))
 			}
+		}
 
-			// Skip nil values
-			if isNil(val) {
+		for _, res := range results {
+			if isNil(res) || !res.Type().AssignableTo(key.Type) {
 				continue
 			}
 
-			// Bind the returned value to the registry for future resolutions
-			if err := i.Bind(val.Type(), val, key.Tag); err != nil {
-				return resVal, fmt.Errorf(
+			if err := i.cache(RegistryKey{
+				Tag:       key.Tag,
+				Type:      key.Type,
+				Scope:     "",
+				Qualifier: key.Qualifier,
+			}, res); err != nil {
+				return reflect.Zero(key.Type), fmt.Errorf(
 					"bind factory function return value of type %s with tag '%s': %w",
-					val.Type(),
+					key.Type,
 					key.Tag,
 					err,
 				)
 			}
 
-			// Return matching type
-			if val.Type() == key.Type {
-				resVal = val
-			}
+			return res, nil
 		}
+	}
 
-		return resVal, nil
+	return reflect.Zero(key.Type), nil
+}
+
+// bindOut binds every field of a result object returned by a factory function under the field's own tag,
+// falling back to the tag of the resolved key, and the qualifier of the key. It returns the field matching
+// the key, if any.
+func (i *Injector) bindOut(key RegistryKey, out reflect.Value) (reflect.Value, error) {
+	var resVal reflect.Value
+
+	for _, field := range outFields(out.Type()) {
+		val := out.Field(field.index)
+
+		// Skip nil values
+		if isNil(val) {
+			continue
+		}
+
+		tag := cmp.Or(field.tag, key.Tag)
+
+		if err := i.cache(RegistryKey{
+			Tag:       tag,
+			Type:      field.typ,
+			Scope:     "",
+			Qualifier: key.Qualifier,
+		}, val); err != nil {
+			return resVal, fmt.Errorf(
+				"bind factory function result field %s of type %s with tag '%s': %w",
+				field.name,
+				field.typ,
+				tag,
+				err,
+			)
+		}
+
+		// Return matching field
+		if field.typ == key.Type && tag == key.Tag {
+			resVal = val
+		}
 	}
 
-	return rv, nil
+	return resVal, nil
+}
+
+// cache binds a factory function result under the key it was resolved with. With cache sharing enabled,
+// a tagged result is also bound under the empty tag, keeping its qualifier, unless something is already
+// registered there.
+func (i *Injector) cache(key RegistryKey, rv reflect.Value) error {
+	registry, opts := i.origin(key)
+	results := resultsOf(registry)
+
+	// Results of factories registered with a TTL expire and fall back to the factory
+	ttl, _ := opts.lifetime(key)
+
+	if err := results.store(key, rv, ttl); err != nil {
+		return err
+	}
+
+	if ttl > 0 {
+		opts.lifecycle.trackExpiring(key, rv)
+	} else {
+		opts.lifecycle.track(key, rv)
+	}
+
+	if !i.options.cacheSharing || key.Tag == "" {
+		return nil
+	}
+
+	untagged := RegistryKey{
+		Tag:       "",
+		Type:      key.Type,
+		Scope:     "",
+		Qualifier: key.Qualifier,
+	}
+
+	// An existing untagged registration takes precedence
+	if err := results.storeIfAbsent(untagged, rv); err != nil {
+		return fmt.Errorf("share value of type %s untagged: %w", key.Type, err)
+	}
+
+	return nil
+}
+
+// origin returns the registry and options of the container the factory of the key was registered with.
+// Results of factories registered with a container are cached and tracked there even when they are first
+// resolved within one of its scopes, which must not close them, unless they are registered with Scoped.
+func (i *Injector) origin(key RegistryKey) (Registry, *options) {
+	registry, opts := i.registry, i.options
+
+	if opts.scopedKey(key) {
+		return registry, opts
+	}
+
+	for opts.parent != nil {
+		if _, ok := opts.factories.Load(key); ok {
+			break
+		}
+
+		child, ok := registry.(*ChildRegistry)
+		if !ok {
+			break
+		}
+
+		registry, opts = child.Parent(), opts.parent
+	}
+
+	return registry, opts
+}
+
+// resultsOf returns where factory results are cached in the registry: a frozen registry keeps them
+// apart from its registrations, any other registry stores them like registered values.
+func resultsOf(registry Registry) resultStore {
+	if frozen, ok := registry.(*FrozenRegistry); ok {
+		return frozen
+	}
+
+	return registryResults{registry: registry}
+}
+
+// missingError reports a key missing from the registry. Its message, which describes where the type is
+// registered instead, is only built once read: most misses end in auto-creation or skip an optional field.
+type missingError struct {
+	key      RegistryKey
+	err      error
+	registry Registry
+	once     sync.Once
+	message  string
+}
+
+// Error returns the message of the registry error followed by the hint for the key.
+func (e *missingError) Error() string {
+	e.once.Do(func() {
+		e.message = fmt.Sprintf("%s for %s (tag %q)%s", e.err, e.key.Type, e.key.Tag, hint(e.registry, e.key))
+	})
+
+	return e.message
+}
+
+// Unwrap returns the registry error, ErrValueNotFound.
+func (e *missingError) Unwrap() error {
+	return e.err
+}
+
+// hint describes the tags under which the requested type, or its pointer/element twin, is registered.
+// If one of the tags looks like a misspelling of the requested one, it is suggested.
+func hint(registry Registry, key RegistryKey) string {
+	var sb strings.Builder
+
+	tags := registeredTags(registry.FindByType(key.Type))
+	if len(tags) > 0 {
+		sb.WriteString("; registered tags: ")
+		sb.WriteString(quoteTags(tags))
+	}
+
+	twin := reflect.PointerTo(key.Type)
+	if key.Type.Kind() == reflect.Pointer {
+		twin = key.Type.Elem()
+	}
+
+	if twinTags := registeredTags(registry.FindByType(twin)); len(twinTags) > 0 {
+		sb.WriteString("; registered tags for ")
+		sb.WriteString(twin.String())
+		sb.WriteString(": ")
+		sb.WriteString(quoteTags(twinTags))
+	}
+
+	if tag, ok := closestTag(tags, key.Tag); ok {
+		sb.WriteString("; did you mean ")
+		sb.WriteString(strconv.Quote(tag))
+		sb.WriteString("?")
+	}
+
+	return sb.String()
 }
 
 // Prepare builds the arguments for a function call by resolving them from the registry
@@ -221,46 +963,203 @@ func (i *Injector) Prepare(fn reflect.Type) ([]reflect.Value, error) {
 		return nil, fmt.Errorf("%w: got %s", ErrExpectedFunction, fn.Kind())
 	}
 
-	// Prepare arguments
-	num := fn.NumIn()
-	arg := make([]reflect.Value, num)
+	args := make([]reflect.Value, fn.NumIn())
+
+	if err := i.fork().prepare(fn, args); err != nil {
+		return nil, err
+	}
+
+	return args, nil
+}
+
+// prepare resolves the arguments of the function type into args within the resolution state of the current call.
+// The args slice must have one element per function parameter.
+func (i *Injector) prepare(fn reflect.Type, args []reflect.Value) error {
+	if i.options.parallelArgs > 1 && fn.NumIn() > 1 {
+		return i.prepareParallel(fn, args)
+	}
+
+	var errs []error
 
 	// Iterate over function parameters
-	for idx := range num {
+	for idx := range fn.NumIn() {
 		rt := fn.In(idx)
 
-		key := RegistryKey{
-			Tag:  "",
-			Type: rt,
-		}
-
-		// Try to resolve the argument from the registry
-		rv, err := i.Resolve(key)
+		rv, err := i.prepareArg(rt)
 		if err == nil {
-			arg[idx] = rv
+			args[idx] = rv
 
 			continue
 		}
 
-		// If the error is not ErrValueNotFound, return it
-		if !errors.Is(err, ErrValueNotFound) {
-			return nil, fmt.Errorf("resolve argument of type %s: %w", rt, err)
+		if !i.options.aggregateErrors {
+			return err
 		}
 
-		// If value not found, create a new instance and inject it
-		rv = i.Create(rt)
+		// Keep going to report every unresolvable parameter at once
+		errs = append(errs, fmt.Errorf("parameter %d of type %s: %w", idx, rt, err))
+	}
 
-		// If the argument is a struct or pointer to struct, inject dependencies into it
-		if err := i.Inject(rv); err != nil {
-			if !errors.Is(err, ErrExpectedStruct) {
-				return nil, fmt.Errorf("inject argument of type %s: %w", rt, err)
-			}
+	return errors.Join(errs...)
+}
+
+// prepareArg resolves the value of a single function parameter of the specified type.
+func (i *Injector) prepareArg(rt reflect.Type) (reflect.Value, error) {
+	key := RegistryKey{
+		Tag:       "",
+		Type:      rt,
+		Scope:     "",
+		Qualifier: nil,
+	}
+
+	// Factories append their hooks to the lifecycle shared by the container
+	if rt == reflect.TypeFor[*Lifecycle]() {
+		return reflect.ValueOf(&Lifecycle{lifecycle: i.options.lifecycle}), nil
+	}
+
+	// Parameter objects are never resolved from the registry, their fields are injected instead
+	if isInStruct(rt) {
+		rv, err := i.autoCreate(key)
+		if err != nil {
+			return rv, fmt.Errorf("inject parameter object of type %s: %w", rt, err)
+		}
+
+		return rv, nil
+	}
+
+	// Try to resolve the argument from the registry
+	rv, err := i.resolve(key)
+	if err == nil {
+		return rv, nil
+	}
+
+	// If the error is not ErrValueNotFound, return it
+	if !errors.Is(err, ErrValueNotFound) {
+		return rv, fmt.Errorf("resolve argument of type %s: %w", rt, err)
+	}
+
+	// If value not found, create a new instance and inject it
+	rv, err = i.autoCreate(key)
+	if err != nil {
+		return rv, fmt.Errorf("inject argument of type %s: %w", rt, err)
+	}
+
+	return rv, nil
+}
+
+// autoCreate creates a new instance of the key type for a dependency missing from the registry.
+// If the instance is a struct or pointer to struct, its dependencies are injected as well.
+// Dependencies that creatable rules out are left at their zero value or reported.
+func (i *Injector) autoCreate(key RegistryKey) (reflect.Value, error) {
+	if ok, err := i.creatable(key); !ok {
+		return reflect.Zero(key.Type), err
+	}
+
+	if !i.dryRun {
+		i.options.logger.autoCreate(key)
+	}
+
+	i.enter(key, StepAutoCreated)
+	defer i.leave()
+
+	rv := i.Create(key.Type)
+
+	// Struct values are created addressable so that their fields can be injected
+	if isStruct(key.Type) {
+		rv = reflect.New(key.Type).Elem()
+	}
+
+	// If the value is a struct or pointer to struct, inject dependencies into it
+	if err := i.inject(rv); err != nil && !errors.Is(err, ErrExpectedStruct) {
+		if !i.dryRun {
+			i.options.logger.failure(key, i.path, err)
+		}
+
+		return rv, err
+	}
+
+	return rv, nil
+}
+
+// creatable reports whether autoCreate creates the dependency of the key. Dependencies nested deeper than
+// WithAutoCreateDepth allows are not created, which is reported as ErrAutoCreateDepth with StrictAutoCreate.
+// A struct type that is already being injected further up the chain is not created again either:
+// this is reported as ErrCircularDependency, unless SkipCyclicFields is set.
+func (i *Injector) creatable(key RegistryKey) (bool, error) {
+	structType := structOf(key.Type)
+
+	if depth := i.autoCreateDepth() + 1; !isInStruct(structType) && !i.options.allowsAutoCreate(depth) {
+		if !i.options.strictAutoCreate {
+			return false, nil
 		}
 
-		arg[idx] = rv
+		i.enter(key, StepAutoCreated)
+		defer i.leave()
+
+		return false, &ResolutionError{
+			Path: slices.Clone(i.path),
+			Err:  fmt.Errorf("%w: %s at depth %d", ErrAutoCreateDepth, key.Type, depth),
+		}
 	}
 
-	return arg, nil
+	if _, exists := i.creating[structType]; exists {
+		if i.options.skipCyclicFields {
+			return false, nil
+		}
+
+		return false, i.cycle(key, StepAutoCreated, func(step ResolutionStep) bool {
+			return step.Kind != StepFactory && structOf(step.Key.Type) == structType
+		})
+	}
+
+	return true, nil
+}
+
+// autoCreateDepth returns the number of dependencies being auto-created along the resolution path.
+// Parameter objects are not dependencies of their own and are not counted.
+func (i *Injector) autoCreateDepth() int {
+	depth := 0
+
+	for _, step := range i.path {
+		if step.Kind == StepAutoCreated && !isInStruct(structOf(step.Key.Type)) {
+			depth++
+		}
+	}
+
+	return depth
+}
+
+// enter appends a step to the resolution path.
+func (i *Injector) enter(key RegistryKey, kind StepKind) {
+	i.path = append(i.path, ResolutionStep{
+		Key:  key,
+		Kind: kind,
+	})
+}
+
+// leave removes the last step from the resolution path.
+func (i *Injector) leave() {
+	i.path = i.path[:len(i.path)-1]
+}
+
+// cycle builds a circular dependency error whose path starts at the first step matching the repeated
+// dependency and ends with the step that closes the cycle.
+func (i *Injector) cycle(key RegistryKey, kind StepKind, repeats func(ResolutionStep) bool) error {
+	start := slices.IndexFunc(i.path, repeats)
+	if start < 0 {
+		start = len(i.path)
+	}
+
+	path := slices.Clone(i.path[start:])
+	path = append(path, ResolutionStep{
+		Key:  key,
+		Kind: kind,
+	})
+
+	return &ResolutionError{
+		Path: path,
+		Err:  ErrCircularDependency,
+	}
 }
 
 // Create returns a new instance of the specified type.
@@ -3,10 +3,14 @@ package dino_test
 import (
 	"errors"
 	"reflect"
+	"slices"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/yuppyweb/dino"
+	"github.com/yuppyweb/dino/dinotest"
 )
 
 func TestInjector_WithDefaultRegistry(t *testing.T) {
@@ -62,6 +66,103 @@ func TestInjector_BindRegisterError(t *testing.T) {
 	}
 }
 
+func TestInjector_BindTypeMismatch(t *testing.T) {
+	t.Parallel()
+
+	type Service struct{}
+
+	injector := dino.NewInjector(nil)
+
+	err := injector.Bind(reflect.TypeFor[*Service](), reflect.ValueOf("not a service"))
+	if !errors.Is(err, dino.ErrTypeMismatch) {
+		t.Fatalf("expected ErrTypeMismatch, got %v", err)
+	}
+
+	if !strings.Contains(err.Error(), "string is not assignable to *dino_test.Service") {
+		t.Fatalf("expected error message to name both types, got '%s'", err.Error())
+	}
+}
+
+func TestInjector_BindRollsBackOnFailure(t *testing.T) {
+	t.Parallel()
+
+	errRegister := errors.New("register failed")
+
+	registry := dinotest.NewMockRegistry()
+	registry.RegisterOut = []error{nil, nil, errRegister}
+
+	injector := dino.NewInjector(registry)
+
+	err := injector.Bind(reflect.TypeFor[int](), reflect.ValueOf(42), "a", "b", "c", "d", "e")
+	if !errors.Is(err, errRegister) {
+		t.Fatalf("expected register error, got %v", err)
+	}
+
+	if !strings.Contains(err.Error(), "tag 'c', nothing was registered") {
+		t.Fatalf("expected error message to name the failing tag, got '%s'", err.Error())
+	}
+
+	if len(registry.RegisterOn) != 3 {
+		t.Fatalf("expected registration to stop at the failing tag, got %d calls", len(registry.RegisterOn))
+	}
+
+	if len(registry.DeleteOn) != 2 {
+		t.Fatalf("expected 2 rolled back keys, got %d", len(registry.DeleteOn))
+	}
+
+	if registry.DeleteOn[0].Tag != "b" || registry.DeleteOn[1].Tag != "a" {
+		t.Fatalf("expected keys to be rolled back in reverse order, got %v", registry.DeleteOn)
+	}
+}
+
+type failingTagRegistry struct {
+	*dino.SyncMapRegistry
+
+	tag string
+}
+
+func (r *failingTagRegistry) Register(key dino.RegistryKey, rv reflect.Value) error {
+	if key.Tag == r.tag {
+		return errors.New("tag is reserved")
+	}
+
+	return r.SyncMapRegistry.Register(key, rv)
+}
+
+func TestInjector_BindLeavesNoPartialState(t *testing.T) {
+	t.Parallel()
+
+	registry := &failingTagRegistry{
+		SyncMapRegistry: new(dino.SyncMapRegistry),
+		tag:             "reserved",
+	}
+
+	injector := dino.NewInjector(registry)
+
+	if err := injector.Bind(reflect.TypeFor[int](), reflect.ValueOf(1), "first"); err != nil {
+		t.Fatalf("failed to bind initial value: %v", err)
+	}
+
+	err := injector.Bind(reflect.TypeFor[int](), reflect.ValueOf(2), "first", "second", "reserved", "fourth")
+	if err == nil {
+		t.Fatal("expected error binding a reserved tag")
+	}
+
+	keys := registry.FindByType(reflect.TypeFor[int]())
+	if len(keys) != 1 || keys[0].Tag != "first" {
+		t.Fatalf("expected only the initial key to remain, got %v", keys)
+	}
+
+	rv, err := registry.Find(keys[0])
+	if err != nil {
+		t.Fatalf("failed to find initial value: %v", err)
+	}
+
+	if rv.Int() != 1 {
+		t.Fatalf("expected replaced value to be restored, got %v", rv.Int())
+	}
+}
+
 func TestInjector_InjectSimpleFields(t *testing.T) {
 	t.Parallel()
 
@@ -205,6 +306,43 @@ func TestInjector_InjectWithTag(t *testing.T) {
 	}
 }
 
+func TestInjector_InjectOptionalField(t *testing.T) {
+	t.Parallel()
+
+	type Cache struct {
+		Size int
+	}
+
+	type TargetStruct struct {
+		Cache    *Cache `inject:",optional"`
+		Replicas *Cache `inject:"replica,optional"`
+	}
+
+	replica := &Cache{
+		Size: 2,
+	}
+
+	injector := dino.NewInjector(nil)
+
+	if err := injector.Bind(reflect.TypeOf(replica), reflect.ValueOf(replica), "replica"); err != nil {
+		t.Fatalf("failed to bind replica cache: %v", err)
+	}
+
+	target := new(TargetStruct)
+
+	if err := injector.Inject(reflect.ValueOf(target)); err != nil {
+		t.Fatalf("failed to inject dependencies: %v", err)
+	}
+
+	if target.Cache != nil {
+		t.Fatalf("expected missing optional field to stay nil, got %v", target.Cache)
+	}
+
+	if target.Replicas != replica {
+		t.Fatalf("expected registered optional field to be injected, got %v", target.Replicas)
+	}
+}
+
 func TestInjector_InjectSkipsPrivateFields(t *testing.T) {
 	t.Parallel()
 
@@ -278,7 +416,11 @@ func TestInjector_InjectErrorResolveFields(t *testing.T) {
 		t.Fatalf("expected factory error, got %v", err)
 	}
 
-	errMsg := "resolve field Service: factory function for type *dino_test.SimpleService with tag '' returned error:"
+	if !errors.Is(err, dino.ErrFactoryFailed) {
+		t.Fatalf("expected ErrFactoryFailed, got %v", err)
+	}
+
+	errMsg := "resolve field Service: factory function failed: factory for *dino_test.SimpleService tag \"\":"
 
 	if !strings.Contains(err.Error(), errMsg) {
 		t.Fatalf("expected error message to contain '%s', got '%s'", errMsg, err.Error())
@@ -322,14 +464,246 @@ func TestInjector_InjectErrorNestedInject(t *testing.T) {
 		t.Fatalf("expected factory error, got %v", err)
 	}
 
-	errMsg := "inject field Service: resolve field Service: factory function " +
-		"for type *dino_test.NestedService with tag '' returned error: service factory failed"
+	if !errors.Is(err, dino.ErrFactoryFailed) {
+		t.Fatalf("expected ErrFactoryFailed, got %v", err)
+	}
+
+	errMsg := "inject field Service: resolve field Service: factory function failed: " +
+		"factory for *dino_test.NestedService tag \"\": service factory failed"
+
+	if !strings.Contains(err.Error(), errMsg) {
+		t.Fatalf("expected error message to contain '%s', got '%s'", errMsg, err.Error())
+	}
+}
+
+func TestInjector_InjectSelfReferencingStruct(t *testing.T) {
+	t.Parallel()
+
+	type Node struct {
+		Value string
+		Next  *Node
+	}
+
+	target := new(Node)
+	injector := dino.NewInjector(nil)
+
+	err := injector.Inject(reflect.ValueOf(target))
+	if !errors.Is(err, dino.ErrCircularDependency) {
+		t.Fatalf("expected ErrCircularDependency, got %v", err)
+	}
+
+	errMsg := "inject field Next: circular dependency detected: " +
+		"*dino_test.Node (target) -> *dino_test.Node (auto-created)"
+
+	if !strings.Contains(err.Error(), errMsg) {
+		t.Fatalf("expected error message to contain '%s', got '%s'", errMsg, err.Error())
+	}
+
+	if target.Next != nil {
+		t.Fatalf("expected Next to remain nil, got %v", target.Next)
+	}
+}
+
+type RecursiveA struct {
+	B *RecursiveB
+}
+
+type RecursiveB struct {
+	A *RecursiveA
+}
+
+func TestInjector_InjectMutuallyRecursiveStructs(t *testing.T) {
+	t.Parallel()
+
+	target := new(RecursiveA)
+	injector := dino.NewInjector(nil)
+
+	err := injector.Inject(reflect.ValueOf(target))
+	if !errors.Is(err, dino.ErrCircularDependency) {
+		t.Fatalf("expected ErrCircularDependency, got %v", err)
+	}
+
+	errMsg := "inject field B: inject field A: circular dependency detected: " +
+		"*dino_test.RecursiveA (target) -> *dino_test.RecursiveB (auto-created) -> " +
+		"*dino_test.RecursiveA (auto-created)"
+
+	if !strings.Contains(err.Error(), errMsg) {
+		t.Fatalf("expected error message to contain '%s', got '%s'", errMsg, err.Error())
+	}
+}
+
+func TestInjector_ResolveMixedCycle(t *testing.T) {
+	t.Parallel()
+
+	factoryA := func(b *RecursiveB) *RecursiveA {
+		return &RecursiveA{
+			B: b,
+		}
+	}
+
+	keyA := dino.RegistryKey{
+		Tag:   "",
+		Type:  reflect.TypeOf(new(RecursiveA)),
+		Scope: "",
+	}
+
+	injector := dino.NewInjector(nil)
+
+	if err := injector.Bind(keyA.Type, reflect.ValueOf(factoryA)); err != nil {
+		t.Fatalf("failed to bind factoryA: %v", err)
+	}
+
+	_, err := injector.Resolve(keyA)
+	if !errors.Is(err, dino.ErrCircularDependency) {
+		t.Fatalf("expected ErrCircularDependency, got %v", err)
+	}
+
+	var resErr *dino.ResolutionError
+	if !errors.As(err, &resErr) {
+		t.Fatalf("expected ResolutionError, got %T", err)
+	}
+
+	expected := []dino.ResolutionStep{
+		{Key: keyA, Kind: dino.StepFactory},
+		{Key: dino.RegistryKey{Tag: "", Type: reflect.TypeOf(new(RecursiveB)), Scope: ""}, Kind: dino.StepAutoCreated},
+		{Key: keyA, Kind: dino.StepFactory},
+	}
+
+	if !reflect.DeepEqual(resErr.Path, expected) {
+		t.Fatalf("expected path %v, got %v", expected, resErr.Path)
+	}
+
+	errMsg := "circular dependency detected: *dino_test.RecursiveA (factory) -> " +
+		"*dino_test.RecursiveB (auto-created) -> *dino_test.RecursiveA (factory)"
 
 	if !strings.Contains(err.Error(), errMsg) {
 		t.Fatalf("expected error message to contain '%s', got '%s'", errMsg, err.Error())
 	}
 }
 
+func TestInjector_InjectSkipCyclicFields(t *testing.T) {
+	t.Parallel()
+
+	type Node struct {
+		Value string
+		Next  *Node
+	}
+
+	type Consumer struct {
+		Head *Node
+	}
+
+	target := new(Consumer)
+	injector := dino.NewInjector(nil, dino.SkipCyclicFields())
+
+	if err := injector.Inject(reflect.ValueOf(target)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if target.Head == nil {
+		t.Fatalf("expected Head to be auto-created")
+	}
+
+	if target.Head.Next != nil {
+		t.Fatalf("expected Head.Next to be left nil, got %v", target.Head.Next)
+	}
+}
+
+func TestInjector_PrepareSelfReferencingArgument(t *testing.T) {
+	t.Parallel()
+
+	type Node struct {
+		Next *Node
+	}
+
+	injector := dino.NewInjector(nil)
+
+	_, err := injector.Prepare(reflect.TypeOf(func(*Node) {}))
+	if !errors.Is(err, dino.ErrCircularDependency) {
+		t.Fatalf("expected ErrCircularDependency, got %v", err)
+	}
+}
+
+func TestInjector_InjectStructByValue(t *testing.T) {
+	t.Parallel()
+
+	type TargetStruct struct {
+		Value string
+	}
+
+	injector := dino.NewInjector(nil)
+
+	err := injector.Inject(reflect.ValueOf(TargetStruct{}))
+	if !errors.Is(err, dino.ErrExpectedPointerToStruct) {
+		t.Fatalf("expected ErrExpectedPointerToStruct, got %v", err)
+	}
+
+	if !strings.Contains(err.Error(), "pass a pointer to it instead") {
+		t.Fatalf(
+			"expected error message to contain 'pass a pointer to it instead', got '%s'",
+			err.Error(),
+		)
+	}
+}
+
+func TestInjector_InjectNilPointerToStruct(t *testing.T) {
+	t.Parallel()
+
+	type TargetStruct struct {
+		Value string
+	}
+
+	injector := dino.NewInjector(nil)
+
+	err := injector.Inject(reflect.ValueOf((*TargetStruct)(nil)))
+	if !errors.Is(err, dino.ErrInvalidInputValue) {
+		t.Fatalf("expected ErrInvalidInputValue, got %v", err)
+	}
+
+	if !strings.Contains(err.Error(), "got nil *dino_test.TargetStruct") {
+		t.Fatalf(
+			"expected error message to contain 'got nil *dino_test.TargetStruct', got '%s'",
+			err.Error(),
+		)
+	}
+}
+
+func TestInjector_InjectAddressableStruct(t *testing.T) {
+	t.Parallel()
+
+	type DatabaseConnection struct {
+		Host string
+	}
+
+	type Repository struct {
+		DB *DatabaseConnection
+	}
+
+	type TargetStruct struct {
+		Repo Repository
+	}
+
+	dbVal := &DatabaseConnection{
+		Host: "localhost",
+	}
+
+	injector := dino.NewInjector(nil)
+
+	if err := injector.Bind(reflect.TypeOf(dbVal), reflect.ValueOf(dbVal)); err != nil {
+		t.Fatalf("failed to bind database connection: %v", err)
+	}
+
+	target := TargetStruct{}
+
+	if err := injector.Inject(reflect.ValueOf(&target).Elem()); err != nil {
+		t.Fatalf("failed to inject dependencies: %v", err)
+	}
+
+	if target.Repo.DB != dbVal {
+		t.Fatalf("expected nested struct value field to be injected, got %v", target.Repo.DB)
+	}
+}
+
 func TestInjector_InvokeSimpleFunction(t *testing.T) {
 	t.Parallel()
 
@@ -662,8 +1036,9 @@ func TestInjector_ResolveSimpleFactory(t *testing.T) {
 	}
 
 	key := dino.RegistryKey{
-		Tag:  "",
-		Type: reflect.TypeOf(srv),
+		Tag:   "",
+		Type:  reflect.TypeOf(srv),
+		Scope: "",
 	}
 
 	injector := dino.NewInjector(nil)
@@ -691,33 +1066,75 @@ func TestInjector_ResolveSimpleFactory(t *testing.T) {
 	}
 }
 
-func TestInjector_ResolveFactoryWithDependencies(t *testing.T) {
+func TestInjector_ResolveFactoryUnderInterface(t *testing.T) {
 	t.Parallel()
 
-	type DatabaseConnection struct {
-		Host string
-	}
+	calls := 0
 
-	type SimpleService struct {
-		Value string
-	}
+	factory := func() *TypedConsoleLogger {
+		calls++
 
-	dbVal := &DatabaseConnection{
-		Host: "localhost4",
+		return &TypedConsoleLogger{prefix: "console"}
 	}
 
-	factory := func(db *DatabaseConnection) *SimpleService {
-		return &SimpleService{
-			Value: db.Host,
-		}
+	key := dino.RegistryKey{
+		Tag:   "",
+		Type:  reflect.TypeFor[TypedLogger](),
+		Scope: "",
 	}
 
-	srvKey := dino.RegistryKey{
-		Tag:  "",
-		Type: reflect.TypeOf(new(SimpleService)),
+	registry := new(dino.SyncMapRegistry)
+
+	if err := registry.Register(key, reflect.ValueOf(factory)); err != nil {
+		t.Fatalf("failed to register factory: %v", err)
 	}
 
-	injector := dino.NewInjector(nil)
+	injector := dino.NewInjector(registry)
+
+	for range 2 {
+		val, err := injector.Resolve(key)
+		if err != nil {
+			t.Fatalf("failed to resolve factory: %v", err)
+		}
+
+		if logger, ok := val.Interface().(*TypedConsoleLogger); !ok || logger.prefix != "console" {
+			t.Fatalf("expected the factory result, got %v", val)
+		}
+	}
+
+	if calls != 1 {
+		t.Fatalf("expected the result to be cached under the interface, got %d calls", calls)
+	}
+}
+
+func TestInjector_ResolveFactoryWithDependencies(t *testing.T) {
+	t.Parallel()
+
+	type DatabaseConnection struct {
+		Host string
+	}
+
+	type SimpleService struct {
+		Value string
+	}
+
+	dbVal := &DatabaseConnection{
+		Host: "localhost4",
+	}
+
+	factory := func(db *DatabaseConnection) *SimpleService {
+		return &SimpleService{
+			Value: db.Host,
+		}
+	}
+
+	srvKey := dino.RegistryKey{
+		Tag:   "",
+		Type:  reflect.TypeOf(new(SimpleService)),
+		Scope: "",
+	}
+
+	injector := dino.NewInjector(nil)
 
 	if err := injector.Bind(reflect.TypeOf(dbVal), reflect.ValueOf(dbVal)); err != nil {
 		t.Fatalf("failed to bind database connection: %v", err)
@@ -760,8 +1177,9 @@ func TestInjector_ResolveFactoryReturningError(t *testing.T) {
 	}
 
 	key := dino.RegistryKey{
-		Tag:  "",
-		Type: reflect.TypeOf(new(SimpleService)),
+		Tag:   "",
+		Type:  reflect.TypeOf(new(SimpleService)),
+		Scope: "",
 	}
 
 	injector := dino.NewInjector(nil)
@@ -775,13 +1193,16 @@ func TestInjector_ResolveFactoryReturningError(t *testing.T) {
 		t.Fatalf("expected factory error, got %v", err)
 	}
 
+	if !errors.Is(err, dino.ErrFactoryFailed) {
+		t.Fatalf("expected ErrFactoryFailed, got %v", err)
+	}
+
 	if !strings.Contains(
 		err.Error(),
-		"factory function for type *dino_test.SimpleService with tag '' returned error:",
+		"factory function failed: factory for *dino_test.SimpleService tag \"\":",
 	) {
 		t.Fatalf(
-			"expected error message to contain 'factory function for type *dino_test.SimpleService with tag "+
-				"'' returned error:', got '%s'",
+			"expected error message to contain the factory error, got '%s'",
 			err.Error(),
 		)
 	}
@@ -799,8 +1220,9 @@ func TestInjector_ResolveUnboundKey(t *testing.T) {
 	}
 
 	key := dino.RegistryKey{
-		Tag:  "missing",
-		Type: reflect.TypeOf(new(SimpleService)),
+		Tag:   "missing",
+		Type:  reflect.TypeOf(new(SimpleService)),
+		Scope: "",
 	}
 
 	injector := dino.NewInjector(nil)
@@ -815,6 +1237,133 @@ func TestInjector_ResolveUnboundKey(t *testing.T) {
 	}
 }
 
+func TestInjector_ResolveRegisteredTag(t *testing.T) {
+	t.Parallel()
+
+	type Database struct {
+		Name string
+	}
+
+	primary := &Database{
+		Name: "primary",
+	}
+
+	injector := dino.NewInjector(nil)
+
+	if err := injector.Bind(reflect.TypeOf(primary), reflect.ValueOf(primary), "primary"); err != nil {
+		t.Fatalf("failed to bind database: %v", err)
+	}
+
+	key := dino.RegistryKey{
+		Tag:   "primary",
+		Type:  reflect.TypeOf(primary),
+		Scope: "",
+	}
+
+	val, err := injector.Resolve(key)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if val.Interface() != primary {
+		t.Fatalf("expected resolved value to be %v, got %v", primary, val)
+	}
+}
+
+func TestInjector_ResolveMissingTagSuggestions(t *testing.T) {
+	t.Parallel()
+
+	type Database struct {
+		Name string
+	}
+
+	injector := dino.NewInjector(nil)
+	dbType := reflect.TypeOf(new(Database))
+
+	for _, tag := range []string{"replica", "primary"} {
+		if err := injector.Bind(dbType, reflect.ValueOf(&Database{Name: tag}), tag); err != nil {
+			t.Fatalf("failed to bind database: %v", err)
+		}
+	}
+
+	if err := injector.Bind(dbType.Elem(), reflect.ValueOf(Database{Name: "plain"}), "plain"); err != nil {
+		t.Fatalf("failed to bind database value: %v", err)
+	}
+
+	key := dino.RegistryKey{
+		Tag:   "primari",
+		Type:  dbType,
+		Scope: "",
+	}
+
+	_, err := injector.Resolve(key)
+	if !errors.Is(err, dino.ErrValueNotFound) {
+		t.Fatalf("expected ErrValueNotFound, got %v", err)
+	}
+
+	errMsg := `value not found in registry for *dino_test.Database (tag "primari"); ` +
+		`registered tags: "primary", "replica"; ` +
+		`registered tags for dino_test.Database: "plain"; ` +
+		`did you mean "primary"?`
+
+	if err.Error() != errMsg {
+		t.Fatalf("expected error message '%s', got '%s'", errMsg, err.Error())
+	}
+}
+
+func TestInjector_InjectOptionalMissSkipsHint(t *testing.T) {
+	t.Parallel()
+
+	type Database struct {
+		Name string
+	}
+
+	type Service struct {
+		DB *Database `inject:",optional"`
+	}
+
+	registry := dinotest.NewMockRegistry()
+	injector := dino.NewInjector(registry)
+
+	var svc Service
+
+	if err := injector.Inject(reflect.ValueOf(&svc)); err != nil {
+		t.Fatalf("failed to inject: %v", err)
+	}
+
+	// The hint looks up the pointer twin of the missing type, only when the miss is reported
+	if slices.Contains(registry.FindByTypeOn, reflect.TypeFor[Database]()) {
+		t.Fatalf("expected no hint for a skipped optional field, got lookups %v", registry.FindByTypeOn)
+	}
+}
+
+func TestInjector_ResolveMissingTypeWithoutTags(t *testing.T) {
+	t.Parallel()
+
+	type Database struct {
+		Name string
+	}
+
+	key := dino.RegistryKey{
+		Tag:   "primary",
+		Type:  reflect.TypeOf(new(Database)),
+		Scope: "",
+	}
+
+	injector := dino.NewInjector(nil)
+
+	_, err := injector.Resolve(key)
+	if !errors.Is(err, dino.ErrValueNotFound) {
+		t.Fatalf("expected ErrValueNotFound, got %v", err)
+	}
+
+	errMsg := `value not found in registry for *dino_test.Database (tag "primary")`
+
+	if err.Error() != errMsg {
+		t.Fatalf("expected error message '%s', got '%s'", errMsg, err.Error())
+	}
+}
+
 func TestInjector_ResolveInvalidStoredValue(t *testing.T) {
 	t.Parallel()
 
@@ -823,8 +1372,9 @@ func TestInjector_ResolveInvalidStoredValue(t *testing.T) {
 	}
 
 	key := dino.RegistryKey{
-		Tag:  "invalid",
-		Type: reflect.TypeOf(new(SimpleService)),
+		Tag:   "invalid",
+		Type:  reflect.TypeOf(new(SimpleService)),
+		Scope: "",
 	}
 
 	registry := &dino.SyncMapRegistry{}
@@ -842,6 +1392,45 @@ func TestInjector_ResolveInvalidStoredValue(t *testing.T) {
 	}
 }
 
+func TestInjector_ResolveMismatchedStoredValue(t *testing.T) {
+	t.Parallel()
+
+	type SimpleService struct {
+		Value string
+	}
+
+	registry := dinotest.NewMockRegistry()
+	registry.FindOut = append(registry.FindOut, struct {
+		Value reflect.Value
+		Err   error
+	}{
+		Value: reflect.ValueOf(42),
+		Err:   nil,
+	})
+
+	injector := dino.NewInjector(registry)
+
+	key := dino.RegistryKey{
+		Tag:   "",
+		Type:  reflect.TypeOf(new(SimpleService)),
+		Scope: "",
+	}
+
+	// A value not assignable to the key type bypasses the fast path and is returned as stored
+	val, err := injector.Resolve(key)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if val.Type() != reflect.TypeFor[int]() || val.Int() != 42 {
+		t.Fatalf("expected the stored int to be returned, got %v", val)
+	}
+
+	if len(registry.FindOn) != 1 {
+		t.Fatalf("expected 1 Find call, got %d", len(registry.FindOn))
+	}
+}
+
 func TestInjector_ResolveBindValueError(t *testing.T) {
 	t.Parallel()
 
@@ -856,25 +1445,27 @@ func TestInjector_ResolveBindValueError(t *testing.T) {
 		return service
 	}
 
-	findOut := []struct {
-		Value reflect.Value
-		Err   error
-	}{
+	findOut := []dinotest.FindResult{
+		{
+			Value: reflect.ValueOf(factory),
+			Err:   nil,
+		},
 		{
 			Value: reflect.ValueOf(factory),
 			Err:   nil,
 		},
 	}
 
-	registry := NewMockRegistry()
+	registry := dinotest.NewMockRegistry()
 	registry.FindOut = append(registry.FindOut, findOut...)
 	registry.RegisterOut = append(registry.RegisterOut, []error{dino.ErrKeyTypeNil}...)
 
 	injector := dino.NewInjector(registry)
 
 	key := dino.RegistryKey{
-		Tag:  "",
-		Type: reflect.TypeOf(service),
+		Tag:   "",
+		Type:  reflect.TypeOf(service),
+		Scope: "",
 	}
 
 	_, err := injector.Resolve(key)
@@ -889,12 +1480,15 @@ func TestInjector_ResolveBindValueError(t *testing.T) {
 		)
 	}
 
-	if len(registry.FindOn) != 1 {
-		t.Fatalf("expected 1 Find call, got %d", len(registry.FindOn))
+	// The factory is looked up again before it is called, it may have been resolved concurrently
+	if len(registry.FindOn) != 2 {
+		t.Fatalf("expected 2 Find calls, got %d", len(registry.FindOn))
 	}
 
-	if registry.FindOn[0] != key {
-		t.Fatalf("expected Find call with key %v, got %v", key, registry.FindOn[0])
+	for _, findKey := range registry.FindOn {
+		if findKey != key {
+			t.Fatalf("expected Find call with key %v, got %v", key, findKey)
+		}
 	}
 
 	if len(registry.RegisterOn) != 1 {
@@ -936,8 +1530,9 @@ func TestInjector_ResolveCircularDependency(t *testing.T) {
 	}
 
 	keyA := dino.RegistryKey{
-		Tag:  "",
-		Type: reflect.TypeOf(new(ServiceA)),
+		Tag:   "",
+		Type:  reflect.TypeOf(new(ServiceA)),
+		Scope: "",
 	}
 
 	injector := dino.NewInjector(nil)
@@ -955,11 +1550,452 @@ func TestInjector_ResolveCircularDependency(t *testing.T) {
 		t.Fatalf("expected ErrCircularDependency, got %v", err)
 	}
 
+	errMsg := "circular dependency detected: *dino_test.ServiceA (factory) -> " +
+		"*dino_test.ServiceB (factory) -> *dino_test.ServiceA (factory)"
+
+	if !strings.Contains(err.Error(), errMsg) {
+		t.Fatalf("expected error message to contain '%s', got '%s'", errMsg, err.Error())
+	}
+
 	if val != reflect.Zero(keyA.Type) {
 		t.Fatalf("expected returned value to be zero, got %v", val)
 	}
 }
 
+func TestInjector_ResolveCircularDependencyWithProvider(t *testing.T) {
+	t.Parallel()
+
+	type ServiceB struct {
+		Name string
+	}
+
+	type ServiceA struct {
+		B *ServiceB
+	}
+
+	factoryA := func(b *ServiceB) *ServiceA {
+		return &ServiceA{
+			B: b,
+		}
+	}
+
+	var lazyA func() *ServiceA
+
+	factoryB := func(a func() *ServiceA) *ServiceB {
+		lazyA = a
+
+		return &ServiceB{
+			Name: "B",
+		}
+	}
+
+	keyA := dino.RegistryKey{
+		Tag:   "",
+		Type:  reflect.TypeOf(new(ServiceA)),
+		Scope: "",
+	}
+
+	injector := dino.NewInjector(nil)
+
+	if err := injector.Bind(keyA.Type, reflect.ValueOf(factoryA)); err != nil {
+		t.Fatalf("failed to bind factoryA: %v", err)
+	}
+
+	if err := injector.Bind(reflect.TypeOf(new(ServiceB)), reflect.ValueOf(factoryB)); err != nil {
+		t.Fatalf("failed to bind factoryB: %v", err)
+	}
+
+	val, err := injector.Resolve(keyA)
+	if err != nil {
+		t.Fatalf("unexpected error resolving cycle through provider: %v", err)
+	}
+
+	serviceA, ok := val.Interface().(*ServiceA)
+	if !ok {
+		t.Fatalf("expected *ServiceA, got %v", val.Type())
+	}
+
+	if serviceA.B == nil || serviceA.B.Name != "B" {
+		t.Fatalf("expected ServiceA to receive ServiceB, got %v", serviceA.B)
+	}
+
+	if lazyA == nil {
+		t.Fatal("expected ServiceB to receive a provider for ServiceA")
+	}
+
+	if lazyA() != serviceA {
+		t.Fatalf("expected provider to return the resolved ServiceA")
+	}
+}
+
+func TestInjector_ResolveProviderCalledByFactory(t *testing.T) {
+	t.Parallel()
+
+	type Config struct {
+		Name string
+	}
+
+	type Service struct {
+		Config *Config
+	}
+
+	injector := dino.NewInjector(nil)
+
+	if err := injector.Bind(reflect.TypeFor[*Config](), reflect.ValueOf(func() *Config {
+		return &Config{Name: "config"}
+	})); err != nil {
+		t.Fatalf("failed to bind config factory: %v", err)
+	}
+
+	// The provider calls the config factory while the service factory is being called
+	if err := injector.Bind(reflect.TypeFor[*Service](), reflect.ValueOf(func(config func() *Config) *Service {
+		return &Service{Config: config()}
+	})); err != nil {
+		t.Fatalf("failed to bind service factory: %v", err)
+	}
+
+	val, err := injector.Resolve(dino.RegistryKey{
+		Tag:   "",
+		Type:  reflect.TypeFor[*Service](),
+		Scope: "",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	service, ok := val.Interface().(*Service)
+	if !ok || service.Config == nil || service.Config.Name != "config" {
+		t.Fatalf("expected the service to get the config from the provider, got %v", val)
+	}
+}
+
+func TestInjector_ResolveProviderCalledByOwnFactory(t *testing.T) {
+	t.Parallel()
+
+	type Node struct {
+		Parent *Node
+	}
+
+	injector := dino.NewInjector(nil)
+
+	// The provider requires the node while its factory is being called
+	factory := func(parent func() (*Node, error)) (*Node, error) {
+		node, err := parent()
+		if err != nil {
+			return nil, err
+		}
+
+		return &Node{Parent: node}, nil
+	}
+
+	if err := injector.Bind(reflect.TypeFor[*Node](), reflect.ValueOf(factory)); err != nil {
+		t.Fatalf("failed to bind node factory: %v", err)
+	}
+
+	_, err := injector.Resolve(dino.RegistryKey{
+		Tag:   "",
+		Type:  reflect.TypeFor[*Node](),
+		Scope: "",
+	})
+	if !errors.Is(err, dino.ErrCircularDependency) {
+		t.Fatalf("expected ErrCircularDependency, got %v", err)
+	}
+
+	var resErr *dino.ResolutionError
+	if !errors.As(err, &resErr) || len(resErr.Path) < 2 {
+		t.Fatalf("expected the resolution path of the cycle, got %v", err)
+	}
+}
+
+func TestInjector_ResolveProviderCalledConcurrentlyByOwnFactory(t *testing.T) {
+	t.Parallel()
+
+	type Node struct {
+		Parent *Node
+	}
+
+	injector := dino.NewInjector(nil)
+
+	// The factory waits for a goroutine requiring the node through the provider
+	factory := func(parent func() (*Node, error)) (*Node, error) {
+		errs := make(chan error, 1)
+
+		go func() {
+			_, err := parent()
+			errs <- err
+		}()
+
+		select {
+		case err := <-errs:
+			return nil, err
+
+		case <-time.After(time.Second):
+			return nil, errors.New("provider is waiting for the factory calling it")
+		}
+	}
+
+	if err := injector.Bind(reflect.TypeFor[*Node](), reflect.ValueOf(factory)); err != nil {
+		t.Fatalf("failed to bind node factory: %v", err)
+	}
+
+	_, err := injector.Resolve(dino.RegistryKey{
+		Tag:   "",
+		Type:  reflect.TypeFor[*Node](),
+		Scope: "",
+	})
+	if !errors.Is(err, dino.ErrCircularDependency) {
+		t.Fatalf("expected ErrCircularDependency, got %v", err)
+	}
+}
+
+func TestInjector_ResolveProviderWithError(t *testing.T) {
+	t.Parallel()
+
+	type Service struct{}
+
+	errUnavailable := errors.New("service unavailable")
+
+	injector := dino.NewInjector(nil)
+
+	available := false
+
+	if err := injector.Bind(reflect.TypeFor[*Service](), reflect.ValueOf(func() (*Service, error) {
+		if !available {
+			return nil, errUnavailable
+		}
+
+		return &Service{}, nil
+	}), "flaky"); err != nil {
+		t.Fatalf("failed to bind service factory: %v", err)
+	}
+
+	val, err := injector.Resolve(dino.RegistryKey{
+		Tag:   "flaky",
+		Type:  reflect.TypeFor[func() (*Service, error)](),
+		Scope: "",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error resolving provider: %v", err)
+	}
+
+	provider, ok := val.Interface().(func() (*Service, error))
+	if !ok {
+		t.Fatalf("expected provider function, got %v", val.Type())
+	}
+
+	service, err := provider()
+	if !errors.Is(err, errUnavailable) {
+		t.Fatalf("expected factory error from provider, got %v", err)
+	}
+
+	if service != nil {
+		t.Fatalf("expected nil service from failing provider, got %v", service)
+	}
+
+	available = true
+
+	service, err = provider()
+	if err != nil {
+		t.Fatalf("unexpected error from provider once the factory succeeds: %v", err)
+	}
+
+	if service == nil {
+		t.Fatal("expected provider to resolve the service")
+	}
+}
+
+func TestInjector_ResolveProviderPanics(t *testing.T) {
+	t.Parallel()
+
+	type Service struct{}
+
+	errUnavailable := errors.New("service unavailable")
+
+	injector := dino.NewInjector(nil)
+
+	if err := injector.Bind(reflect.TypeFor[*Service](), reflect.ValueOf(func() (*Service, error) {
+		return nil, errUnavailable
+	})); err != nil {
+		t.Fatalf("failed to bind service factory: %v", err)
+	}
+
+	val, err := injector.Resolve(dino.RegistryKey{
+		Tag:   "",
+		Type:  reflect.TypeFor[func() *Service](),
+		Scope: "",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error resolving provider: %v", err)
+	}
+
+	provider, ok := val.Interface().(func() *Service)
+	if !ok {
+		t.Fatalf("expected provider function, got %v", val.Type())
+	}
+
+	defer func() {
+		rec := recover()
+
+		err, ok := rec.(error)
+		if !ok || !errors.Is(err, errUnavailable) {
+			t.Fatalf("expected provider to panic with the factory error, got %v", rec)
+		}
+	}()
+
+	provider()
+}
+
+func TestInjector_InjectUnregisteredProvider(t *testing.T) {
+	t.Parallel()
+
+	type Service struct{}
+
+	type Target struct {
+		NewService func() *Service
+	}
+
+	var target Target
+
+	if err := dino.NewInjector(nil).Inject(reflect.ValueOf(&target)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if target.NewService == nil {
+		t.Fatal("expected the provider field to be created")
+	}
+
+	// A provider of an unregistered type creates its result instead of resolving it
+	if service := target.NewService(); service == nil {
+		t.Fatal("expected the created provider to return a new service")
+	}
+}
+
+func TestInjector_PrepareAggregateErrors(t *testing.T) {
+	t.Parallel()
+
+	type Database struct{}
+
+	type Cache struct{}
+
+	injector := dino.NewInjector(nil, dino.AggregateErrors())
+
+	failing := func() (*Database, *Cache, error) { return nil, nil, errors.New("unavailable") }
+
+	if err := injector.Bind(reflect.TypeFor[*Database](), reflect.ValueOf(failing)); err != nil {
+		t.Fatalf("failed to bind database factory: %v", err)
+	}
+
+	if err := injector.Bind(reflect.TypeFor[*Cache](), reflect.ValueOf(failing)); err != nil {
+		t.Fatalf("failed to bind cache factory: %v", err)
+	}
+
+	args, err := injector.Prepare(reflect.TypeOf(func(*Database, int, *Cache) {}))
+	if err == nil {
+		t.Fatal("expected error preparing arguments")
+	}
+
+	if args != nil {
+		t.Fatalf("expected no arguments, got %v", args)
+	}
+
+	for _, msg := range []string{
+		"parameter 0 of type *dino_test.Database",
+		"parameter 2 of type *dino_test.Cache",
+	} {
+		if !strings.Contains(err.Error(), msg) {
+			t.Fatalf("expected error message to contain '%s', got '%s'", msg, err.Error())
+		}
+	}
+
+	if strings.Contains(err.Error(), "parameter 1") {
+		t.Fatalf("expected resolvable parameter not to be reported, got '%s'", err.Error())
+	}
+}
+
+func TestInjector_PrepareAggregateErrorsInFields(t *testing.T) {
+	t.Parallel()
+
+	type Database struct{}
+
+	type Params struct {
+		dino.In
+
+		Primary *Database `inject:"primary"`
+		Replica *Database `inject:"replica"`
+	}
+
+	// Missing dependencies fail instead of being auto-created
+	injector := dino.NewInjector(nil, dino.AggregateErrors(), dino.WithAutoCreateDepth(0), dino.StrictAutoCreate())
+
+	_, err := injector.Prepare(reflect.TypeOf(func(Params) {}))
+	if !errors.Is(err, dino.ErrAutoCreateDepth) {
+		t.Fatalf("expected ErrAutoCreateDepth, got %v", err)
+	}
+
+	for _, msg := range []string{
+		"parameter 0 of type dino_test.Params",
+		"field Primary of type *dino_test.Database with tag 'primary'",
+		"field Replica of type *dino_test.Database with tag 'replica'",
+	} {
+		if !strings.Contains(err.Error(), msg) {
+			t.Fatalf("expected error message to contain '%s', got '%s'", msg, err.Error())
+		}
+	}
+}
+
+func TestInjector_ConcurrentAccess(t *testing.T) {
+	t.Parallel()
+
+	const goroutines = 100
+
+	type Shared struct {
+		ID int
+	}
+
+	type Broken struct{}
+
+	errBroken := errors.New("broken dependency")
+
+	injector := dino.NewInjector(nil)
+
+	shared := func() *Shared { return &Shared{ID: 1} }
+	broken := func(*Shared) (*Broken, error) { return nil, errBroken }
+
+	if err := injector.Bind(reflect.TypeFor[*Shared](), reflect.ValueOf(shared)); err != nil {
+		t.Fatalf("failed to bind shared factory: %v", err)
+	}
+
+	if err := injector.Bind(reflect.TypeFor[*Broken](), reflect.ValueOf(broken)); err != nil {
+		t.Fatalf("failed to bind broken factory: %v", err)
+	}
+
+	var wg sync.WaitGroup
+
+	for idx := range goroutines {
+		wg.Go(func() {
+			if idx%2 == 0 {
+				_, err := injector.Invoke(reflect.ValueOf(func(*Shared) {}))
+				if err != nil {
+					t.Errorf("goroutine %d: unexpected error: %v", idx, err)
+				}
+
+				return
+			}
+
+			_, err := injector.Invoke(reflect.ValueOf(func(*Shared, *Broken) {}))
+			if !errors.Is(err, errBroken) {
+				t.Errorf("goroutine %d: expected broken dependency error, got %v", idx, err)
+			}
+
+			if errors.Is(err, dino.ErrCircularDependency) {
+				t.Errorf("goroutine %d: resolution state leaked between calls: %v", idx, err)
+			}
+		})
+	}
+
+	wg.Wait()
+}
+
 func TestInjector_PrepareArguments(t *testing.T) {
 	t.Parallel()
 
@@ -1159,8 +2195,12 @@ func TestInjector_PrepareArgumentsInjectError(t *testing.T) {
 		t.Fatalf("expected factory error, got %v", err)
 	}
 
+	if !errors.Is(err, dino.ErrFactoryFailed) {
+		t.Fatalf("expected ErrFactoryFailed, got %v", err)
+	}
+
 	errMsg := "inject argument of type *dino_test.SimpleService: resolve field Service: " +
-		"factory function for type *dino_test.NestedService with tag '' returned error: service factory failed"
+		"factory function failed: factory for *dino_test.NestedService tag \"\": service factory failed"
 
 	if !strings.Contains(err.Error(), errMsg) {
 		t.Fatalf("expected error message to contain '%s', got '%s'", errMsg, err.Error())
@@ -1322,3 +2362,129 @@ func TestInjector_CreateAnyType(t *testing.T) {
 		})
 	}
 }
+
+// The six-level chain of examples/04_dependency_chain, with only its leaf registered.
+type (
+	DepthPool struct {
+		MaxConnections int
+	}
+
+	DepthDatabase struct {
+		Pool *DepthPool
+	}
+
+	DepthCache struct {
+		Database *DepthDatabase
+	}
+
+	DepthRepository struct {
+		Cache *DepthCache
+	}
+
+	DepthService struct {
+		Repository *DepthRepository
+	}
+
+	DepthHandler struct {
+		Service *DepthService
+	}
+
+	DepthTarget struct {
+		Handler *DepthHandler
+	}
+)
+
+func TestInjector_AutoCreateDepth(t *testing.T) {
+	t.Parallel()
+
+	pool := &DepthPool{MaxConnections: 10}
+
+	testCases := []struct {
+		name  string
+		opts  []dino.Option
+		check func(target DepthTarget) bool
+	}{
+		{
+			name: "Depth 0 creates nothing",
+			opts: []dino.Option{dino.WithAutoCreateDepth(0)},
+			check: func(target DepthTarget) bool {
+				return target.Handler == nil
+			},
+		},
+		{
+			name: "Depth 1 creates the referenced struct only",
+			opts: []dino.Option{dino.WithAutoCreateDepth(1)},
+			check: func(target DepthTarget) bool {
+				return target.Handler != nil && target.Handler.Service == nil
+			},
+		},
+		{
+			name: "Depth 2 creates its children",
+			opts: []dino.Option{dino.WithAutoCreateDepth(2)},
+			check: func(target DepthTarget) bool {
+				return target.Handler != nil && target.Handler.Service != nil &&
+					target.Handler.Service.Repository == nil
+			},
+		},
+		{
+			name: "Unlimited by default",
+			opts: nil,
+			check: func(target DepthTarget) bool {
+				return target.Handler.Service.Repository.Cache.Database.Pool == pool
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			di := dino.New(tc.opts...)
+
+			if err := di.Singleton(pool); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var target DepthTarget
+
+			if err := di.Inject(&target); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !tc.check(target) {
+				t.Errorf("unexpected injection: %+v", target)
+			}
+		})
+	}
+}
+
+func TestInjector_AutoCreateDepthStrict(t *testing.T) {
+	t.Parallel()
+
+	// Injectors share the setting of containers
+	lenient := dino.NewInjector(nil, dino.WithAutoCreateDepth(2))
+
+	results, err := lenient.Invoke(reflect.ValueOf(func(svc *DepthService) *DepthService {
+		return svc
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if svc, _ := results[0].Interface().(*DepthService); svc.Repository == nil || svc.Repository.Cache != nil {
+		t.Fatalf("unexpected invocation: %+v", svc)
+	}
+
+	var target DepthTarget
+
+	injector := dino.NewInjector(nil, dino.WithAutoCreateDepth(2), dino.StrictAutoCreate())
+
+	err = injector.Inject(reflect.ValueOf(&target))
+	if !errors.Is(err, dino.ErrAutoCreateDepth) {
+		t.Fatalf("expected ErrAutoCreateDepth, got %v", err)
+	}
+
+	if !strings.Contains(err.Error(), "*dino_test.DepthRepository at depth 3") {
+		t.Errorf("expected the error to name the type and depth, got %v", err)
+	}
+}
@@ -0,0 +1,87 @@
+package dino
+
+import (
+	"cmp"
+	"reflect"
+)
+
+// In marks a struct as a parameter object. When a function invoked or used as a factory by the
+// container takes a struct embedding In, the struct is not resolved from the registry: it is
+// created and its exported fields are injected individually, honoring their "inject" tags.
+//
+//	type ServiceParams struct {
+//		dino.In
+//
+//		DB    *Database `inject:"primary"`
+//		Cache *Cache    `inject:",optional"`
+//	}
+type In struct{}
+
+// isInStruct reports whether rt is a struct type embedding In.
+func isInStruct(rt reflect.Type) bool {
+	return embeds(rt, reflect.TypeFor[In]())
+}
+
+// Out marks a struct as a result object. When a factory function returns a struct embedding Out,
+// the struct itself is not registered: each of its exported fields is registered individually
+// under the tag given by its "inject" or "name" struct tag.
+//
+//	type Databases struct {
+//		dino.Out
+//
+//		Primary *Database `inject:"primary"`
+//		Replica *Database `name:"replica"`
+//	}
+type Out struct{}
+
+// outField describes an exported field of a result object.
+type outField struct {
+	index int
+	name  string
+	typ   reflect.Type
+	tag   string
+}
+
+// isOutStruct reports whether rt is a struct type embedding Out.
+func isOutStruct(rt reflect.Type) bool {
+	return embeds(rt, reflect.TypeFor[Out]())
+}
+
+// outFields returns the fields of a result object that are registered in the container.
+// Unexported fields, error fields and the Out marker itself are skipped.
+func outFields(rt reflect.Type) []outField {
+	fields := make([]outField, 0, rt.NumField())
+
+	for idx := range rt.NumField() {
+		field := rt.Field(idx)
+
+		if !field.IsExported() || field.Type == reflect.TypeFor[Out]() || isError(field.Type) {
+			continue
+		}
+
+		fields = append(fields, outField{
+			index: idx,
+			name:  field.Name,
+			typ:   field.Type,
+			tag:   cmp.Or(parseInjectTag(field.Tag.Get("inject")).name, field.Tag.Get("name")),
+		})
+	}
+
+	return fields
+}
+
+// embeds reports whether rt is a struct type with an embedded field of the marker type.
+func embeds(rt, marker reflect.Type) bool {
+	if !isStruct(rt) {
+		return false
+	}
+
+	// Fields are walked by index, the range iterator allocates on every call of this hot path
+	for idx := range rt.NumField() {
+		if field := rt.Field(idx); field.Anonymous && field.Type == marker {
+			return true
+		}
+	}
+
+	return false
+}
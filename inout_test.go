@@ -0,0 +1,242 @@
+package dino_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/yuppyweb/dino"
+)
+
+type InDatabase struct {
+	Name string
+}
+
+type InCache struct {
+	Size int
+}
+
+type InServiceParams struct {
+	dino.In
+
+	DB    *InDatabase `inject:"primary"`
+	Cache *InCache    `inject:",optional"`
+}
+
+type InService struct {
+	DB    *InDatabase
+	Cache *InCache
+}
+
+func TestInOut_FactoryWithInParams(t *testing.T) {
+	t.Parallel()
+
+	primary := &InDatabase{
+		Name: "primary",
+	}
+
+	di := dino.New()
+
+	if err := di.Singleton(primary, "primary"); err != nil {
+		t.Fatalf("unexpected error during singleton registration: %v", err)
+	}
+
+	if err := di.Singleton(&InDatabase{Name: "untagged"}); err != nil {
+		t.Fatalf("unexpected error during singleton registration: %v", err)
+	}
+
+	err := di.Factory(func(p InServiceParams) *InService {
+		return &InService{
+			DB:    p.DB,
+			Cache: p.Cache,
+		}
+	})
+	if err != nil {
+		t.Fatalf("unexpected error during factory registration: %v", err)
+	}
+
+	results, err := di.Invoke(func(srv *InService) *InService { return srv })
+	if err != nil {
+		t.Fatalf("unexpected error from Invoke: %v", err)
+	}
+
+	srv, ok := results[0].(*InService)
+	if !ok {
+		t.Fatalf("expected result to be of type *InService, got %T", results[0])
+	}
+
+	if srv.DB != primary {
+		t.Fatalf("expected tagged primary database, got %v", srv.DB)
+	}
+
+	if srv.Cache != nil {
+		t.Fatalf("expected optional cache to be nil, got %v", srv.Cache)
+	}
+}
+
+func TestInOut_InvokeWithInParamsOptionalPresent(t *testing.T) {
+	t.Parallel()
+
+	cache := &InCache{
+		Size: 10,
+	}
+
+	di := dino.New()
+
+	if err := di.Singleton(cache); err != nil {
+		t.Fatalf("unexpected error during singleton registration: %v", err)
+	}
+
+	results, err := di.Invoke(func(p InServiceParams) (*InDatabase, *InCache) {
+		return p.DB, p.Cache
+	})
+	if err != nil {
+		t.Fatalf("unexpected error from Invoke: %v", err)
+	}
+
+	if db, ok := results[0].(*InDatabase); !ok || db == nil {
+		t.Fatalf("expected missing required database to be auto-created, got %v", results[0])
+	}
+
+	if results[1] != cache {
+		t.Fatalf("expected optional cache to be %v, got %v", cache, results[1])
+	}
+}
+
+func TestInOut_InParamsAreNotResolvedFromRegistry(t *testing.T) {
+	t.Parallel()
+
+	injector := dino.NewInjector(nil)
+
+	registered := InServiceParams{
+		In:    dino.In{},
+		DB:    &InDatabase{Name: "registered"},
+		Cache: nil,
+	}
+
+	if err := injector.Bind(reflect.TypeOf(registered), reflect.ValueOf(registered)); err != nil {
+		t.Fatalf("failed to bind parameter object: %v", err)
+	}
+
+	args, err := injector.Prepare(reflect.TypeOf(func(InServiceParams) {}))
+	if err != nil {
+		t.Fatalf("failed to prepare arguments: %v", err)
+	}
+
+	params, ok := args[0].Interface().(InServiceParams)
+	if !ok {
+		t.Fatalf("expected argument of type InServiceParams, got %v", args[0].Type())
+	}
+
+	if params.DB == registered.DB {
+		t.Fatalf("expected parameter object to be built field by field")
+	}
+}
+
+func TestInOut_NestedInParams(t *testing.T) {
+	t.Parallel()
+
+	type OuterParams struct {
+		dino.In
+
+		Inner InServiceParams
+	}
+
+	injector := dino.NewInjector(nil)
+
+	_, err := injector.Prepare(reflect.TypeOf(func(OuterParams) {}))
+	if !errors.Is(err, dino.ErrNestedIn) {
+		t.Fatalf("expected ErrNestedIn, got %v", err)
+	}
+
+	if !strings.Contains(err.Error(), "field Inner of type dino_test.InServiceParams") {
+		t.Fatalf("expected error message to name the nested field, got '%s'", err.Error())
+	}
+}
+
+type OutDatabases struct {
+	dino.Out
+
+	Primary *InDatabase `inject:"primary"`
+	Replica *InDatabase `name:"replica"`
+	Err     error
+	cache   *InCache
+}
+
+type OutApp struct {
+	PrimaryDB *InDatabase `inject:"primary"`
+	ReplicaDB *InDatabase `inject:"replica"`
+}
+
+func TestInOut_FactoryWithOutResult(t *testing.T) {
+	t.Parallel()
+
+	calls := 0
+
+	di := dino.New()
+
+	err := di.Factory(func() OutDatabases {
+		calls++
+
+		return OutDatabases{
+			Primary: &InDatabase{Name: "primary"},
+			Replica: &InDatabase{Name: "replica"},
+			cache:   &InCache{Size: 1},
+		}
+	})
+	if err != nil {
+		t.Fatalf("unexpected error during factory registration: %v", err)
+	}
+
+	app := &OutApp{}
+
+	if err := di.Inject(app); err != nil {
+		t.Fatalf("unexpected error from Inject: %v", err)
+	}
+
+	if app.PrimaryDB == nil || app.PrimaryDB.Name != "primary" {
+		t.Fatalf("expected primary database, got %v", app.PrimaryDB)
+	}
+
+	if app.ReplicaDB == nil || app.ReplicaDB.Name != "replica" {
+		t.Fatalf("expected replica database, got %v", app.ReplicaDB)
+	}
+
+	if calls != 1 {
+		t.Fatalf("expected factory to be called once, got %d", calls)
+	}
+
+	if _, err := di.Resolve(reflect.TypeFor[OutDatabases]()); !errors.Is(err, dino.ErrValueNotFound) {
+		t.Fatalf("expected result object itself not to be registered, got %v", err)
+	}
+
+	if _, err := di.Resolve(reflect.TypeFor[*InCache]()); !errors.Is(err, dino.ErrValueNotFound) {
+		t.Fatalf("expected unexported field not to be registered, got %v", err)
+	}
+}
+
+func TestInOut_FactoryWithUntaggedOutFields(t *testing.T) {
+	t.Parallel()
+
+	type Result struct {
+		dino.Out
+
+		Cache *InCache
+	}
+
+	di := dino.New()
+
+	if err := di.Factory(func() Result { return Result{Cache: &InCache{Size: 8}} }, "hot"); err != nil {
+		t.Fatalf("unexpected error during factory registration: %v", err)
+	}
+
+	val, err := di.Resolve(reflect.TypeFor[*InCache](), "hot")
+	if err != nil {
+		t.Fatalf("unexpected error from Resolve: %v", err)
+	}
+
+	if cache, ok := val.(*InCache); !ok || cache.Size != 8 {
+		t.Fatalf("expected cache registered under the factory tag, got %v", val)
+	}
+}
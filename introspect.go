@@ -0,0 +1,99 @@
+package dino
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+// fieldDependencies returns the registry keys Inject resolves for the fields of a struct type,
+// or of the struct a pointer type points to.
+func fieldDependencies(rt reflect.Type) []RegistryKey {
+	rt = structOf(rt)
+	if !isStruct(rt) {
+		return nil
+	}
+
+	var deps []RegistryKey
+
+	for field := range rt.Fields() {
+		if !field.IsExported() || field.Type == reflect.TypeFor[In]() {
+			continue
+		}
+
+		deps = append(deps, RegistryKey{
+			Tag:       parseInjectTag(field.Tag.Get("inject")).name,
+			Type:      field.Type,
+			Scope:     "",
+			Qualifier: nil,
+		})
+	}
+
+	return deps
+}
+
+// DependenciesOf returns the keys the dependency registered for the type and tag depends on, in
+// declaration order: the parameters of its factory, even once its result is cached, or the fields
+// Inject would resolve for a struct instance registered as a singleton. Nothing is resolved or called.
+// It returns ErrValueNotFound if nothing is registered for the type and tag.
+func (d *Dino) DependenciesOf(rt reflect.Type, tag string) ([]RegistryKey, error) {
+	if rt == nil {
+		return nil, fmt.Errorf("%w: type cannot be nil", ErrInvalidInputValue)
+	}
+
+	key := RegistryKey{
+		Tag:       tag,
+		Type:      rt,
+		Scope:     "",
+		Qualifier: nil,
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	rv, err := d.registry.Find(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find dependency %s: %w", keyName(key), err)
+	}
+
+	if isFactory(key, rv) {
+		return slices.Clone(factoryDependencies(rv.Type())), nil
+	}
+
+	if deps := d.options.dependencies(key); len(deps) > 0 {
+		return slices.Clone(deps), nil
+	}
+
+	return fieldDependencies(rv.Type()), nil
+}
+
+// DependentsOf returns the registered keys whose factories take the type with the tag as a parameter,
+// or as a field of a parameter object, sorted by type name and tag. Nothing is resolved or called.
+func (d *Dino) DependentsOf(rt reflect.Type, tag string) []RegistryKey {
+	key := RegistryKey{
+		Tag:       tag,
+		Type:      rt,
+		Scope:     "",
+		Qualifier: nil,
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	var dependents []RegistryKey
+
+	for candidate, rv := range d.registry.All() {
+		deps := d.options.dependencies(candidate)
+		if isFactory(candidate, rv) {
+			deps = factoryDependencies(rv.Type())
+		}
+
+		if slices.Contains(deps, key) {
+			dependents = append(dependents, candidate)
+		}
+	}
+
+	sortKeys(dependents)
+
+	return dependents
+}
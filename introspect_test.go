@@ -0,0 +1,131 @@
+package dino_test
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/yuppyweb/dino"
+)
+
+type AppConfig struct {
+	DSN string
+}
+
+type AppDatabase struct{}
+
+type AppLogger struct{}
+
+type AppUserRepository struct{}
+
+type AppUserService struct{}
+
+type AppUserHandler struct{}
+
+type AppServer struct {
+	Handler *AppUserHandler
+	Logger  *AppLogger `inject:"access"`
+	port    int
+}
+
+// newAppGraph registers the examples/07 dependency graph.
+func newAppGraph(t *testing.T) *dino.Dino {
+	t.Helper()
+
+	di := dino.New()
+
+	singletons := []any{&AppConfig{DSN: "postgres://localhost"}, &AppDatabase{}, &AppLogger{}, &AppServer{port: 8080}}
+
+	for _, singleton := range singletons {
+		if err := di.Singleton(singleton); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	factories := []any{
+		func(*AppDatabase, *AppLogger) *AppUserRepository { return &AppUserRepository{} },
+		func(*AppUserRepository, *AppLogger) *AppUserService { return &AppUserService{} },
+		func(*AppUserService, *AppLogger) *AppUserHandler { return &AppUserHandler{} },
+	}
+
+	for _, factory := range factories {
+		if err := di.Factory(factory); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	return di
+}
+
+func TestDino_DependentsOf(t *testing.T) {
+	t.Parallel()
+
+	di := newAppGraph(t)
+
+	dependents := di.DependentsOf(reflect.TypeFor[*AppLogger](), "")
+
+	expected := []dino.RegistryKey{
+		orderKey[*AppUserHandler](),
+		orderKey[*AppUserRepository](),
+		orderKey[*AppUserService](),
+	}
+
+	if !slices.Equal(dependents, expected) {
+		t.Errorf("expected dependents %v, got %v", expected, dependents)
+	}
+
+	// Cached factory results keep depending on the parameters of their factory
+	if _, err := di.Resolve(reflect.TypeFor[*AppUserHandler]()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if dependents := di.DependentsOf(reflect.TypeFor[*AppLogger](), ""); !slices.Equal(dependents, expected) {
+		t.Errorf("expected dependents %v after resolving, got %v", expected, dependents)
+	}
+}
+
+func TestDino_DependenciesOf(t *testing.T) {
+	t.Parallel()
+
+	di := newAppGraph(t)
+
+	deps, err := di.DependenciesOf(reflect.TypeFor[*AppUserService](), "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []dino.RegistryKey{orderKey[*AppUserRepository](), orderKey[*AppLogger]()}
+	if !slices.Equal(deps, expected) {
+		t.Errorf("expected dependencies %v, got %v", expected, deps)
+	}
+
+	if deps, err := di.DependenciesOf(reflect.TypeFor[*AppLogger](), ""); err != nil || len(deps) != 0 {
+		t.Errorf("expected the logger to have no dependencies, got %v, %v", deps, err)
+	}
+
+	// Struct instances depend on the fields Inject resolves
+	deps, err = di.DependenciesOf(reflect.TypeFor[*AppServer](), "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected = []dino.RegistryKey{
+		orderKey[*AppUserHandler](),
+		{Tag: "access", Type: reflect.TypeFor[*AppLogger](), Scope: ""},
+	}
+
+	if !slices.Equal(deps, expected) {
+		t.Errorf("expected field dependencies %v, got %v", expected, deps)
+	}
+}
+
+func TestDino_DependenciesOfMissing(t *testing.T) {
+	t.Parallel()
+
+	di := newAppGraph(t)
+
+	if _, err := di.DependenciesOf(reflect.TypeFor[*AppLogger](), "audit"); !errors.Is(err, dino.ErrValueNotFound) {
+		t.Fatalf("expected ErrValueNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,536 @@
+package dino
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"reflect"
+	"slices"
+	"sync"
+)
+
+var (
+	// ErrShutdownTimeout is returned when the context passed to Shutdown is done before every instance is closed.
+	ErrShutdownTimeout = errors.New("shutdown deadline exceeded")
+	// ErrHookPanic is returned when a close hook panics.
+	ErrHookPanic = errors.New("lifecycle hook panicked")
+)
+
+// ContextShutdowner is implemented by instances that release their resources with a context bounding
+// how long they may take. It is preferred over io.Closer and Shutdowner.
+type ContextShutdowner interface {
+	ShutdownCtx(ctx context.Context) error
+}
+
+// Shutdowner is implemented by instances that release their resources with Shutdown.
+// Instances implementing io.Closer are closed with Close instead.
+type Shutdowner interface {
+	Shutdown() error
+}
+
+// Starter is implemented by instances that need an explicit start once the dependency graph is built,
+// e.g. listeners and consumers.
+type Starter interface {
+	Start(ctx context.Context) error
+}
+
+// Hook is a pair of functions run by Dino.Start and Dino.Shutdown. Either may be nil.
+type Hook struct {
+	OnStart func(ctx context.Context) error
+	OnStop  func(ctx context.Context) error
+}
+
+// Lifecycle lets factories register start and stop hooks for the values they create without those values
+// implementing Starter or io.Closer. Factories receive it by declaring a *Lifecycle parameter.
+type Lifecycle struct {
+	lifecycle *lifecycle
+}
+
+// Fail reports a failure detected after starting, e.g. by a background listener, making Dino.Run shut
+// the container down and return the error. Only the first failure is reported.
+func (l *Lifecycle) Fail(err error) {
+	select {
+	case l.lifecycle.failures <- err:
+	default:
+	}
+}
+
+// Append adds a hook. OnStart hooks run in append order by Dino.Start, OnStop hooks in reverse order by
+// Dino.Shutdown, interleaved with the instances created before and after them.
+func (l *Lifecycle) Append(hook Hook) {
+	l.lifecycle.appendHook(hook)
+}
+
+// instance is a value created by or given to the container, or a hook appended to its Lifecycle.
+type instance struct {
+	key     RegistryKey
+	value   reflect.Value
+	hook    *Hook
+	started bool
+}
+
+// lifecycle records the instances of a container in the order they were created.
+type lifecycle struct {
+	mutex     sync.Mutex
+	instances []*instance
+	tracked   map[any]struct{}
+	expiring  map[RegistryKey]reflect.Value
+	failures  chan error
+}
+
+// newLifecycle creates an empty lifecycle.
+func newLifecycle() *lifecycle {
+	return &lifecycle{
+		mutex:     sync.Mutex{},
+		instances: nil,
+		tracked:   make(map[any]struct{}),
+		expiring:  make(map[RegistryKey]reflect.Value),
+		failures:  make(chan error, 1),
+	}
+}
+
+// track appends the value created for the key, unless the same instance is already tracked,
+// e.g. because it is registered under several tags.
+func (l *lifecycle) track(key RegistryKey, rv reflect.Value) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if rv.Comparable() {
+		identity := rv.Interface()
+		if _, ok := l.tracked[identity]; ok {
+			return
+		}
+
+		l.tracked[identity] = struct{}{}
+	}
+
+	l.instances = append(l.instances, &instance{
+		key:     key,
+		value:   rv,
+		hook:    nil,
+		started: false,
+	})
+}
+
+// trackExpiring tracks the value created for a key whose results expire, replacing the value created
+// for it before: a result refreshed after expiring is no longer closed by Shutdown.
+func (l *lifecycle) trackExpiring(key RegistryKey, rv reflect.Value) {
+	l.mutex.Lock()
+	prev, ok := l.expiring[key]
+	l.expiring[key] = rv
+	l.mutex.Unlock()
+
+	if ok && !sameValue(prev, rv) {
+		l.untrack(prev)
+	}
+
+	l.track(key, rv)
+}
+
+// evict stops tracking the results of expiring keys the registry no longer holds, e.g. because
+// they expired and the factory was registered again in their place.
+func (l *lifecycle) evict(registry Registry) {
+	l.mutex.Lock()
+	expiring := maps.Clone(l.expiring)
+	l.mutex.Unlock()
+
+	for key, rv := range expiring {
+		if current, err := registry.Find(key); err == nil && sameValue(current, rv) {
+			continue
+		}
+
+		l.mutex.Lock()
+		if sameValue(l.expiring[key], rv) {
+			delete(l.expiring, key)
+		}
+		l.mutex.Unlock()
+
+		l.untrack(rv)
+	}
+}
+
+// sameValue reports whether both values hold the same comparable instance.
+func sameValue(a, b reflect.Value) bool {
+	return a.IsValid() && b.IsValid() && a.Comparable() && b.Comparable() && a.Equal(b)
+}
+
+// lifecycleSnapshot records the instances a lifecycle tracks at a point in time.
+type lifecycleSnapshot struct {
+	source    *lifecycle
+	instances map[*instance]struct{}
+	tracked   map[any]struct{}
+	expiring  map[RegistryKey]reflect.Value
+}
+
+// snapshot records the instances tracked so far.
+func (l *lifecycle) snapshot() *lifecycleSnapshot {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	instances := make(map[*instance]struct{}, len(l.instances))
+	for _, inst := range l.instances {
+		instances[inst] = struct{}{}
+	}
+
+	return &lifecycleSnapshot{
+		source:    l,
+		instances: instances,
+		tracked:   maps.Clone(l.tracked),
+		expiring:  maps.Clone(l.expiring),
+	}
+}
+
+// restore stops tracking the instances and hooks added since the snapshot, which are no longer closed
+// by Shutdown. Instances released or forgotten since are not tracked again. Snapshots of another
+// lifecycle are ignored.
+func (l *lifecycle) restore(snapshot *lifecycleSnapshot) {
+	if snapshot == nil || snapshot.source != l {
+		return
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.instances = slices.DeleteFunc(l.instances, func(inst *instance) bool {
+		_, ok := snapshot.instances[inst]
+
+		return !ok
+	})
+
+	maps.DeleteFunc(l.tracked, func(identity any, _ struct{}) bool {
+		_, ok := snapshot.tracked[identity]
+
+		return !ok
+	})
+
+	l.expiring = maps.Clone(snapshot.expiring)
+}
+
+// appendHook appends a lifecycle hook.
+func (l *lifecycle) appendHook(hook Hook) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.instances = append(l.instances, &instance{
+		key:     RegistryKey{Tag: "", Type: reflect.TypeFor[Hook](), Scope: "", Qualifier: nil},
+		value:   reflect.Value{},
+		hook:    &hook,
+		started: false,
+	})
+}
+
+// release removes the tracked instances and returns them in creation order.
+// Released instances are never returned again, even if they are created once more.
+func (l *lifecycle) release() []*instance {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	released := l.instances
+	l.instances = nil
+
+	return released
+}
+
+// keys returns the keys of the tracked instances in creation order, leaving out hooks.
+func (l *lifecycle) keys() []RegistryKey {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	keys := make([]RegistryKey, 0, len(l.instances))
+
+	for _, inst := range l.instances {
+		if inst.hook == nil && !slices.Contains(keys, inst.key) {
+			keys = append(keys, inst.key)
+		}
+	}
+
+	return keys
+}
+
+// requeue returns instances left unclosed to the lifecycle, ahead of instances created since.
+// The instances are expected in teardown order, most recently created first.
+func (l *lifecycle) requeue(instances []*instance) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	requeued := slices.Clone(instances)
+	slices.Reverse(requeued)
+
+	l.instances = append(requeued, l.instances...)
+}
+
+// unstarted returns the tracked instances that were not started yet, in creation order.
+func (l *lifecycle) unstarted() []*instance {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	var pending []*instance
+
+	for _, inst := range l.instances {
+		if !inst.started {
+			pending = append(pending, inst)
+		}
+	}
+
+	return pending
+}
+
+// markStarted records that the instance was started.
+func (l *lifecycle) markStarted(inst *instance) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	inst.started = true
+}
+
+// forget stops tracking the instances, e.g. after closing them outside of Shutdown.
+func (l *lifecycle) forget(instances []*instance) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.instances = slices.DeleteFunc(l.instances, func(inst *instance) bool {
+		return slices.Contains(instances, inst)
+	})
+}
+
+// untrack stops tracking the instance holding the value, e.g. after it was replaced, so that it is
+// no longer closed by Shutdown. Values that are not comparable cannot be told apart and stay tracked.
+func (l *lifecycle) untrack(rv reflect.Value) {
+	if !rv.Comparable() {
+		return
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	identity := rv.Interface()
+
+	delete(l.tracked, identity)
+
+	l.instances = slices.DeleteFunc(l.instances, func(inst *instance) bool {
+		return inst.hook == nil && inst.value.Comparable() && inst.value.Interface() == identity
+	})
+}
+
+// name describes the instance in errors by its type and tag.
+func (inst *instance) name() string {
+	if inst.hook != nil {
+		return "lifecycle hook"
+	}
+
+	return keyName(inst.key)
+}
+
+// startHook returns the function starting the instance, or nil if it needs no start.
+func (inst *instance) startHook() func(ctx context.Context) error {
+	if inst.hook != nil {
+		return inst.hook.OnStart
+	}
+
+	if !inst.value.CanInterface() {
+		return nil
+	}
+
+	if starter, ok := inst.value.Interface().(Starter); ok {
+		return starter.Start
+	}
+
+	return nil
+}
+
+// stopHook returns the function releasing the instance, preferring ShutdownCtx over Close and Shutdown,
+// or nil if the instance implements none of them.
+func (inst *instance) stopHook() func(ctx context.Context) error {
+	if inst.hook != nil {
+		return inst.hook.OnStop
+	}
+
+	if !inst.value.CanInterface() {
+		return nil
+	}
+
+	switch closer := inst.value.Interface().(type) {
+	case ContextShutdowner:
+		return closer.ShutdownCtx
+	case io.Closer:
+		return func(context.Context) error { return closer.Close() }
+	case Shutdowner:
+		return func(context.Context) error { return closer.Shutdown() }
+	default:
+		return nil
+	}
+}
+
+// closeInstance runs the close hook of the instance. A hook still running when the context is done
+// is abandoned to finish in the background and reported as ErrShutdownTimeout, a panicking hook is
+// reported as ErrHookPanic.
+func closeInstance(ctx context.Context, inst *instance) error {
+	hook := inst.stopHook()
+	if hook == nil {
+		return nil
+	}
+
+	done := make(chan error, 1)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- fmt.Errorf("%w: %v", ErrHookPanic, r)
+			}
+		}()
+
+		done <- hook(ctx)
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			return fmt.Errorf("close %s: %w", inst.name(), err)
+		}
+
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("close %s: %w: %w", inst.name(), ErrShutdownTimeout, ctx.Err())
+	}
+}
+
+// Close releases every instance the container created or was given, in reverse creation order,
+// see Shutdown. It waits for every hook to return.
+func (d *Dino) Close() error {
+	return d.Shutdown(context.Background())
+}
+
+// Shutdown releases every instance the container created or was given, in the reverse of Order.
+// Instances implementing ContextShutdowner get the context passed to ShutdownCtx, otherwise Close is
+// called on those implementing io.Closer and Shutdown on those implementing Shutdowner. Singletons count
+// as created when they are registered. Every instance is closed at most once, even if it is registered
+// under several tags, and calling Shutdown again only closes instances created since or left open.
+//
+// Every hook is attempted even if earlier ones fail or panic. The failures are returned together with
+// errors.Join in the order the hooks ran, each naming the type and tag of its instance. Once the context
+// is done, no further hooks are started: a hook still running is abandoned and reported as
+// ErrShutdownTimeout, and the instances not yet closed stay tracked for a later call.
+func (d *Dino) Shutdown(ctx context.Context) error {
+	d.mutex.Lock()
+	d.options.lifecycle.evict(d.registry)
+	instances, err := d.options.orderInstances(d.options.lifecycle.release())
+	d.mutex.Unlock()
+
+	// Without a valid order, instances are still closed in reverse creation order
+	errs := make([]error, 0, len(instances)+1)
+	errs = append(errs, err)
+
+	slices.Reverse(instances)
+
+	for idx, inst := range instances {
+		if ctx.Err() != nil {
+			d.options.lifecycle.requeue(instances[idx:])
+
+			errs = append(errs, fmt.Errorf(
+				"%w: %d instances left open: %w",
+				ErrShutdownTimeout,
+				len(instances)-idx,
+				ctx.Err(),
+			))
+
+			break
+		}
+
+		if err := closeInstance(ctx, inst); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Build eagerly resolves every factory registered in the container, caching their results,
+// so that misconfigured dependencies surface before the application starts, then calls the runners
+// registered since the last build. With WithBuildParallelism, factories that do not depend on each
+// other are called concurrently.
+func (d *Dino) Build() error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	var keys []RegistryKey
+
+	for key, rv := range d.registry.All() {
+		if isFactory(key, rv) {
+			keys = append(keys, key)
+		}
+	}
+
+	sortKeys(keys)
+
+	if d.options.buildParallelism > 1 {
+		if err := d.buildParallel(keys, d.options.buildParallelism); err != nil {
+			return err
+		}
+
+		return d.run()
+	}
+
+	injector := newInjector(d.registry, d.options)
+
+	for _, key := range keys {
+		if _, err := injector.Resolve(key); err != nil {
+			return fmt.Errorf("failed to build dependency %s with tag '%s': %w", key.Type, key.Tag, err)
+		}
+
+		d.options.stats.build(key)
+	}
+
+	return d.run()
+}
+
+// Start builds the container, then calls Start on every instance implementing Starter and runs the
+// OnStart hooks appended to its Lifecycle, dependencies before their dependents as reported by Order.
+// Instances created and hooks appended while starting are started as well. Every instance is started
+// at most once, so calling Start again only starts instances created since. If an instance fails to
+// start, the instances started by this call are closed in reverse order and the error is returned
+// together with any failure to close them. Use Parallel to start independent instances concurrently.
+func (d *Dino) Start(ctx context.Context, opts ...StartOption) error {
+	if err := d.Build(); err != nil {
+		return fmt.Errorf("failed to start: %w", err)
+	}
+
+	o := newStartOptions(opts...)
+
+	var started []*instance
+
+	for pending := d.options.lifecycle.unstarted(); len(pending) > 0; pending = d.options.lifecycle.unstarted() {
+		ordered, err := d.options.orderInstances(pending)
+		if err != nil {
+			return errors.Join(fmt.Errorf("failed to start: %w", err), d.rollback(ctx, started))
+		}
+
+		if o.parallel > 1 {
+			started, err = d.startInParallel(ctx, pending, started, o.parallel)
+		} else {
+			started, err = d.startInOrder(ctx, ordered, started)
+		}
+
+		if err != nil {
+			return errors.Join(err, d.rollback(ctx, started))
+		}
+	}
+
+	return nil
+}
+
+// rollback closes the started instances in reverse order and stops tracking them.
+func (d *Dino) rollback(ctx context.Context, started []*instance) error {
+	d.options.lifecycle.forget(started)
+
+	errs := make([]error, 0, len(started))
+
+	for _, inst := range slices.Backward(started) {
+		if err := closeInstance(ctx, inst); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,573 @@
+package dino_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yuppyweb/dino"
+)
+
+var errHookFailed = errors.New("lifecycle hook failed")
+
+type closeLog struct {
+	closed []string
+}
+
+type LifecycleConfig struct {
+	log *closeLog
+}
+
+func (c *LifecycleConfig) Close() error {
+	c.log.closed = append(c.log.closed, "config")
+
+	return nil
+}
+
+type LifecycleDatabase struct {
+	log *closeLog
+}
+
+func (d *LifecycleDatabase) Shutdown() error {
+	d.log.closed = append(d.log.closed, "database")
+
+	return nil
+}
+
+type LifecycleRepository struct {
+	log *closeLog
+	err error
+}
+
+func (r *LifecycleRepository) Close() error {
+	r.log.closed = append(r.log.closed, "repository")
+
+	return r.err
+}
+
+func newLifecycleChain(t *testing.T, repoErr error) (*dino.Dino, *closeLog) {
+	t.Helper()
+
+	log := new(closeLog)
+	di := dino.New()
+
+	if err := di.Singleton(&LifecycleConfig{log: log}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := di.Factory(func(cfg *LifecycleConfig) *LifecycleDatabase {
+		return &LifecycleDatabase{log: cfg.log}
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := di.Factory(func(db *LifecycleDatabase) *LifecycleRepository {
+		return &LifecycleRepository{log: db.log, err: repoErr}
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := di.Resolve(reflect.TypeFor[*LifecycleRepository]()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	return di, log
+}
+
+func TestDino_CloseReverseCreationOrder(t *testing.T) {
+	t.Parallel()
+
+	di, log := newLifecycleChain(t, nil)
+
+	if err := di.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{"repository", "database", "config"}
+	if strings.Join(log.closed, ",") != strings.Join(expected, ",") {
+		t.Fatalf("expected close order %v, got %v", expected, log.closed)
+	}
+
+	// Closing again does not close anything twice
+	if err := di.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(log.closed) != len(expected) {
+		t.Fatalf("expected Close to be idempotent, got %v", log.closed)
+	}
+}
+
+func TestDino_CloseAggregatesErrors(t *testing.T) {
+	t.Parallel()
+
+	di, log := newLifecycleChain(t, errHookFailed)
+
+	err := di.Close()
+	if !errors.Is(err, errHookFailed) {
+		t.Fatalf("expected errHookFailed, got %v", err)
+	}
+
+	if !strings.Contains(err.Error(), "*dino_test.LifecycleRepository") {
+		t.Fatalf("expected error to name the failing type, got '%s'", err.Error())
+	}
+
+	if len(log.closed) != 3 {
+		t.Fatalf("expected every instance to be closed despite the failure, got %v", log.closed)
+	}
+}
+
+func TestDino_CloseOnceAcrossTags(t *testing.T) {
+	t.Parallel()
+
+	log := new(closeLog)
+	di := dino.New()
+
+	if err := di.Singleton(&LifecycleConfig{log: log}, "primary", "replica"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := di.Factory(func() *LifecycleDatabase {
+		return &LifecycleDatabase{log: log}
+	}, "primary", "replica"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, tag := range []string{"primary", "replica"} {
+		if _, err := di.Resolve(reflect.TypeFor[*LifecycleDatabase](), tag); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if err := di.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The factory ran once per tag, the singleton is closed once
+	expected := []string{"database", "database", "config"}
+	if strings.Join(log.closed, ",") != strings.Join(expected, ",") {
+		t.Fatalf("expected close order %v, got %v", expected, log.closed)
+	}
+}
+
+type SlowCloser struct {
+	release chan struct{}
+}
+
+func (s *SlowCloser) Close() error {
+	<-s.release
+
+	return nil
+}
+
+type shutdownReasonKey struct{}
+
+type ContextCloser struct {
+	log    *closeLog
+	reason any
+}
+
+func (c *ContextCloser) ShutdownCtx(ctx context.Context) error {
+	c.reason = ctx.Value(shutdownReasonKey{})
+	c.log.closed = append(c.log.closed, "context")
+
+	return nil
+}
+
+func (c *ContextCloser) Close() error {
+	c.log.closed = append(c.log.closed, "close")
+
+	return nil
+}
+
+func TestDino_ShutdownTimeout(t *testing.T) {
+	t.Parallel()
+
+	log := new(closeLog)
+	slow := &SlowCloser{release: make(chan struct{})}
+
+	defer close(slow.release)
+
+	di := dino.New()
+
+	if err := di.Singleton(&LifecycleConfig{log: log}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := di.Singleton(slow); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := di.Singleton(&LifecycleRepository{log: log, err: nil}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	err := di.Shutdown(ctx)
+	if !errors.Is(err, dino.ErrShutdownTimeout) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected ErrShutdownTimeout, got %v", err)
+	}
+
+	if !strings.Contains(err.Error(), "close *dino_test.SlowCloser") {
+		t.Fatalf("expected error to name the slow type, got '%s'", err.Error())
+	}
+
+	// The instance created last was closed before the slow one, the first one was never started
+	if strings.Join(log.closed, ",") != "repository" {
+		t.Fatalf("expected only the repository to be closed, got %v", log.closed)
+	}
+
+	if err := di.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if strings.Join(log.closed, ",") != "repository,config" {
+		t.Fatalf("expected the instance left open to be closed later, got %v", log.closed)
+	}
+}
+
+func TestDino_ShutdownPassesContext(t *testing.T) {
+	t.Parallel()
+
+	closer := &ContextCloser{log: new(closeLog), reason: nil}
+
+	di := dino.New()
+
+	if err := di.Singleton(closer); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx := context.WithValue(context.Background(), shutdownReasonKey{}, "shutdown")
+
+	if err := di.Shutdown(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if strings.Join(closer.log.closed, ",") != "context" {
+		t.Fatalf("expected ShutdownCtx to be preferred over Close, got %v", closer.log.closed)
+	}
+
+	if closer.reason != "shutdown" {
+		t.Fatalf("expected the shutdown context to be passed to the hook")
+	}
+}
+
+type startLog struct {
+	events []string
+}
+
+type StartConfig struct {
+	log *startLog
+}
+
+func (c *StartConfig) Start(context.Context) error {
+	c.log.events = append(c.log.events, "start config")
+
+	return nil
+}
+
+func (c *StartConfig) Close() error {
+	c.log.events = append(c.log.events, "close config")
+
+	return nil
+}
+
+type StartPool struct {
+	log *startLog
+}
+
+func (p *StartPool) Start(context.Context) error {
+	p.log.events = append(p.log.events, "start pool")
+
+	return nil
+}
+
+func (p *StartPool) Close() error {
+	p.log.events = append(p.log.events, "close pool")
+
+	return nil
+}
+
+type StartDatabase struct {
+	log *startLog
+	err error
+}
+
+func (d *StartDatabase) Start(context.Context) error {
+	d.log.events = append(d.log.events, "start database")
+
+	return d.err
+}
+
+func (d *StartDatabase) Close() error {
+	d.log.events = append(d.log.events, "close database")
+
+	return nil
+}
+
+type StartHandler struct {
+	log *startLog
+}
+
+func (h *StartHandler) Start(context.Context) error {
+	h.log.events = append(h.log.events, "start handler")
+
+	return nil
+}
+
+func newStartChain(t *testing.T, dbErr error) (*dino.Dino, *startLog) {
+	t.Helper()
+
+	log := new(startLog)
+	di := dino.New()
+
+	// Registered in reverse dependency order to make sure Start does not follow registration order
+	factories := []any{
+		func(db *StartDatabase) *StartHandler { return &StartHandler{log: db.log} },
+		func(pool *StartPool) *StartDatabase { return &StartDatabase{log: pool.log, err: dbErr} },
+		func(cfg *StartConfig) *StartPool { return &StartPool{log: cfg.log} },
+	}
+
+	for _, factory := range factories {
+		if err := di.Factory(factory); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if err := di.Singleton(&StartConfig{log: log}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	return di, log
+}
+
+func TestDino_StartDependencyOrder(t *testing.T) {
+	t.Parallel()
+
+	di, log := newStartChain(t, nil)
+
+	if err := di.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Starting again does not start anything twice
+	if err := di.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "start config,start pool,start database,start handler"
+	if strings.Join(log.events, ",") != expected {
+		t.Fatalf("expected %s, got %v", expected, log.events)
+	}
+
+	if err := di.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected += ",close database,close pool,close config"
+	if strings.Join(log.events, ",") != expected {
+		t.Fatalf("expected %s, got %v", expected, log.events)
+	}
+}
+
+func TestDino_StartRollback(t *testing.T) {
+	t.Parallel()
+
+	di, log := newStartChain(t, errHookFailed)
+
+	if err := di.Start(context.Background()); !errors.Is(err, errHookFailed) {
+		t.Fatalf("expected the start error, got %v", err)
+	}
+
+	expected := "start config,start pool,start database,close pool,close config"
+	if strings.Join(log.events, ",") != expected {
+		t.Fatalf("expected %s, got %v", expected, log.events)
+	}
+
+	// Rolled back instances are not closed again
+	if err := di.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected += ",close database"
+	if strings.Join(log.events, ",") != expected {
+		t.Fatalf("expected %s, got %v", expected, log.events)
+	}
+}
+
+func TestDino_StartBuildError(t *testing.T) {
+	t.Parallel()
+
+	di := dino.New()
+
+	if err := di.Factory(func() (*StartPool, error) { return nil, errHookFailed }); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := di.Start(context.Background()); !errors.Is(err, errHookFailed) {
+		t.Fatalf("expected the factory error, got %v", err)
+	}
+}
+
+type HookedServer struct{}
+
+type HookedWorker struct{}
+
+func TestDino_LifecycleHooks(t *testing.T) {
+	t.Parallel()
+
+	log := new(startLog)
+	di := dino.New()
+
+	hook := func(name string) dino.Hook {
+		return dino.Hook{
+			OnStart: func(context.Context) error {
+				log.events = append(log.events, "start "+name)
+
+				return nil
+			},
+			OnStop: func(context.Context) error {
+				log.events = append(log.events, "stop "+name)
+
+				return nil
+			},
+		}
+	}
+
+	if err := di.Factory(func(lc *dino.Lifecycle) *HookedServer {
+		lc.Append(hook("server listener"))
+		lc.Append(hook("server metrics"))
+
+		return &HookedServer{}
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := di.Factory(func(_ *HookedServer, lc *dino.Lifecycle) *HookedWorker {
+		lc.Append(hook("worker"))
+
+		return &HookedWorker{}
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := di.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := di.Shutdown(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{
+		"start server listener",
+		"start server metrics",
+		"start worker",
+		"stop worker",
+		"stop server metrics",
+		"stop server listener",
+	}
+
+	if strings.Join(log.events, ",") != strings.Join(expected, ",") {
+		t.Fatalf("expected %v, got %v", expected, log.events)
+	}
+}
+
+func TestDino_LifecycleHooksAppendedWhileStarting(t *testing.T) {
+	t.Parallel()
+
+	log := new(startLog)
+	di := dino.New()
+
+	if _, err := di.Invoke(func(lc *dino.Lifecycle) {
+		lc.Append(dino.Hook{
+			OnStart: func(context.Context) error {
+				log.events = append(log.events, "start server")
+
+				// Hooks appended while starting, e.g. by factories called lazily, are started too
+				lc.Append(dino.Hook{
+					OnStart: func(context.Context) error {
+						log.events = append(log.events, "start worker")
+
+						return nil
+					},
+					OnStop: nil,
+				})
+
+				return nil
+			},
+			OnStop: nil,
+		})
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := di.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if strings.Join(log.events, ",") != "start server,start worker" {
+		t.Fatalf("expected the hook appended while starting to start, got %v", log.events)
+	}
+}
+
+type namedCloser struct {
+	name string
+	log  *closeLog
+	err  error
+}
+
+func (c *namedCloser) Close() error {
+	c.log.closed = append(c.log.closed, c.name)
+
+	if c.name == "panicking" {
+		panic("close panicked")
+	}
+
+	return c.err
+}
+
+func TestDino_ShutdownContinuesOnError(t *testing.T) {
+	t.Parallel()
+
+	log := new(closeLog)
+	di := dino.New()
+
+	closers := []*namedCloser{
+		{name: "first", log: log, err: nil},
+		{name: "middle", log: log, err: errHookFailed},
+		{name: "panicking", log: log, err: nil},
+		{name: "last", log: log, err: errHookFailed},
+	}
+
+	for _, closer := range closers {
+		if err := di.Singleton(closer, closer.name); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	err := di.Close()
+
+	if strings.Join(log.closed, ",") != "last,panicking,middle,first" {
+		t.Fatalf("expected every closer to run, got %v", log.closed)
+	}
+
+	if !errors.Is(err, errHookFailed) || !errors.Is(err, dino.ErrHookPanic) {
+		t.Fatalf("expected the failures and the panic, got %v", err)
+	}
+
+	expected := []string{
+		"close *dino_test.namedCloser[last]: lifecycle hook failed",
+		"close *dino_test.namedCloser[panicking]: lifecycle hook panicked: close panicked",
+		"close *dino_test.namedCloser[middle]: lifecycle hook failed",
+	}
+
+	if err.Error() != strings.Join(expected, "\n") {
+		t.Fatalf("expected errors in teardown order:\n%s\ngot:\n%s", strings.Join(expected, "\n"), err.Error())
+	}
+}
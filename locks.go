@@ -0,0 +1,112 @@
+package dino
+
+import "sync"
+
+// keyLocks serializes the factory calls for each key, so that concurrent resolutions call a factory
+// once, while factories for different keys run concurrently. A resolution waiting for a key held by
+// a resolution that in turn waits for one of its own keys would wait forever: such circular waits are
+// detected and reported instead. Providers called while their resolution holds keys, from any goroutine,
+// resolve within it and never wait for the keys it holds.
+type keyLocks struct {
+	mutex   sync.Mutex
+	cond    *sync.Cond
+	owners  map[RegistryKey]*Injector
+	waiting map[*Injector]RegistryKey
+}
+
+// newKeyLocks creates the factory locks of a container.
+func newKeyLocks() *keyLocks {
+	locks := &keyLocks{
+		mutex:   sync.Mutex{},
+		cond:    nil,
+		owners:  make(map[RegistryKey]*Injector),
+		waiting: make(map[*Injector]RegistryKey),
+	}
+
+	locks.cond = sync.NewCond(&locks.mutex)
+
+	return locks
+}
+
+// lock acquires the lock of the key for the injector and returns the function releasing it.
+// If waiting for the key would never end, it returns false with the key of the injector
+// the chain of waiting resolutions leads back to.
+func (l *keyLocks) lock(key RegistryKey, owner *Injector) (func(), RegistryKey, bool) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	for {
+		if _, held := l.owners[key]; !held {
+			l.owners[key] = owner
+
+			return func() { l.unlock(key) }, key, true
+		}
+
+		if blocked, ok := l.blocking(key, owner); ok {
+			return nil, blocked, false
+		}
+
+		l.waiting[owner] = key
+		l.cond.Wait()
+		delete(l.waiting, owner)
+	}
+}
+
+// blocking follows the chain from the key to the resolution holding it, the key that resolution waits
+// for, and so on. If the chain reaches a key held by the owner, waiting would never end: that key is returned.
+func (l *keyLocks) blocking(key RegistryKey, owner *Injector) (RegistryKey, bool) {
+	for range len(l.waiting) + 1 {
+		holder, held := l.owners[key]
+		if !held {
+			break
+		}
+
+		if owner.within(holder) {
+			return key, true
+		}
+
+		next, waits := l.waiting[holder]
+		if !waits {
+			break
+		}
+
+		key = next
+	}
+
+	return RegistryKey{Tag: "", Type: nil, Scope: "", Qualifier: nil}, false
+}
+
+// holds reports whether the resolution of the injector, or one it resolves arguments for, holds a key.
+func (l *keyLocks) holds(owner *Injector) bool {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	for _, holder := range l.owners {
+		if owner.within(holder) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// holds reports whether the resolution of the injector holds a factory lock of the container
+// or of one of the containers it is a scope of.
+func (o *options) holds(owner *Injector) bool {
+	for opts := o; opts != nil; opts = opts.parent {
+		if opts.factoryLocks.holds(owner) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// unlock releases the lock of the key and wakes the resolutions waiting for a key.
+func (l *keyLocks) unlock(key RegistryKey) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	delete(l.owners, key)
+	l.cond.Broadcast()
+}
@@ -0,0 +1,155 @@
+package dino
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// resolutionLogger logs the decisions made while resolving dependencies at debug level. A nil logger
+// ignores every call, so containers without a logger only pay for a nil check.
+type resolutionLogger struct {
+	logger *slog.Logger
+}
+
+// WithLogger makes the container log every resolution decision at debug level: registry hits and misses,
+// factory calls with their duration, auto-created dependencies and failures with their resolution path.
+// Requests failed by handlers adapted with HandlerFunc are logged at error level.
+func WithLogger(logger *slog.Logger) Option {
+	return func(o *options) {
+		if logger == nil {
+			o.logger = nil
+
+			return
+		}
+
+		o.logger = &resolutionLogger{logger: logger}
+	}
+}
+
+// WithDefaultLogger makes *slog.Logger resolve to a working logger unless one is registered: the logger
+// set with WithLogger if any, otherwise a text logger writing to os.Stderr with the handler options, which
+// may be nil. The container logs its resolution decisions and the failures of HandlerFunc to the same logger.
+func WithDefaultLogger(opts *slog.HandlerOptions) Option {
+	return func(o *options) {
+		o.defaultLogger = slog.New(slog.NewTextHandler(os.Stderr, opts))
+	}
+}
+
+// hit logs a lookup that found a cached instance or a factory for the key.
+func (l *resolutionLogger) hit(key RegistryKey, factory bool) {
+	if l == nil {
+		return
+	}
+
+	source := "cached"
+	if factory {
+		source = "factory"
+	}
+
+	l.debug("dino: registry hit", keyAttrs(key, slog.String("source", source))...)
+}
+
+// miss logs a lookup that found nothing for the key.
+func (l *resolutionLogger) miss(key RegistryKey) {
+	if l == nil {
+		return
+	}
+
+	l.debug("dino: registry miss", keyAttrs(key)...)
+}
+
+// factoryStart logs the start of a factory call for the key and returns when it started.
+func (l *resolutionLogger) factoryStart(key RegistryKey) time.Time {
+	if l == nil {
+		return time.Time{}
+	}
+
+	l.debug("dino: factory call started", keyAttrs(key)...)
+
+	return time.Now()
+}
+
+// factoryDone logs the end of a factory call for the key started at start, with the resolution path on failure.
+func (l *resolutionLogger) factoryDone(key RegistryKey, start time.Time, path []ResolutionStep, err error) {
+	if l == nil {
+		return
+	}
+
+	attrs := keyAttrs(key, slog.Duration("duration", time.Since(start)))
+
+	if err != nil {
+		l.debug("dino: factory call failed", append(attrs, errorAttrs(path, err)...)...)
+
+		return
+	}
+
+	l.debug("dino: factory call finished", attrs...)
+}
+
+// autoCreate logs a dependency created automatically for the key and why: parameter objects are always
+// created, other dependencies only when nothing is registered for them.
+func (l *resolutionLogger) autoCreate(key RegistryKey) {
+	if l == nil {
+		return
+	}
+
+	reason := "not registered"
+	if isInStruct(key.Type) {
+		reason = "parameter object"
+	}
+
+	l.debug("dino: auto-creating dependency", keyAttrs(key, slog.String("reason", reason))...)
+}
+
+// failure logs a failed resolution of the key with its resolution path.
+func (l *resolutionLogger) failure(key RegistryKey, path []ResolutionStep, err error) {
+	if l == nil {
+		return
+	}
+
+	l.debug("dino: resolution failed", append(keyAttrs(key), errorAttrs(path, err)...)...)
+}
+
+// handlerFailure logs a request failed by a handler adapted with HandlerFunc at error level.
+func (l *resolutionLogger) handlerFailure(r *http.Request, err error) {
+	if l == nil {
+		return
+	}
+
+	l.logger.LogAttrs(r.Context(), slog.LevelError, "dino: handler failed",
+		slog.String("method", r.Method),
+		slog.String("path", r.URL.Path),
+		slog.Any("error", err),
+	)
+}
+
+// debug writes a debug record with the attributes.
+func (l *resolutionLogger) debug(msg string, attrs ...slog.Attr) {
+	l.logger.LogAttrs(context.Background(), slog.LevelDebug, msg, attrs...)
+}
+
+// keyAttrs returns the attributes describing the key, followed by the extra ones.
+func keyAttrs(key RegistryKey, extra ...slog.Attr) []slog.Attr {
+	attrs := make([]slog.Attr, 0, 2+len(extra))
+	attrs = append(attrs, slog.String("type", key.Type.String()), slog.String("tag", key.Tag))
+
+	return append(attrs, extra...)
+}
+
+// errorAttrs returns the attributes describing a failure and the resolution path leading to it.
+func errorAttrs(path []ResolutionStep, err error) []slog.Attr {
+	steps := make([]string, len(path))
+
+	for idx, step := range path {
+		steps[idx] = step.String()
+	}
+
+	return []slog.Attr{
+		slog.String("path", strings.Join(steps, " -> ")),
+		slog.Any("error", err),
+	}
+}
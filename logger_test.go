@@ -0,0 +1,315 @@
+package dino_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/yuppyweb/dino"
+)
+
+type LoggedDatabase struct{}
+
+type LoggedService struct {
+	DB *LoggedDatabase
+}
+
+type LoggedHandler struct {
+	Service *LoggedService
+}
+
+// captureHandler records every log record with its attributes.
+type captureHandler struct {
+	mutex   sync.Mutex
+	records []capturedRecord
+}
+
+type capturedRecord struct {
+	level slog.Level
+	msg   string
+	attrs map[string]string
+}
+
+func (h *captureHandler) Enabled(context.Context, slog.Level) bool {
+	return true
+}
+
+func (h *captureHandler) Handle(_ context.Context, record slog.Record) error {
+	attrs := make(map[string]string)
+
+	record.Attrs(func(attr slog.Attr) bool {
+		attrs[attr.Key] = attr.Value.String()
+
+		return true
+	})
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	h.records = append(h.records, capturedRecord{level: record.Level, msg: record.Message, attrs: attrs})
+
+	return nil
+}
+
+func (h *captureHandler) WithAttrs([]slog.Attr) slog.Handler {
+	return h
+}
+
+func (h *captureHandler) WithGroup(string) slog.Handler {
+	return h
+}
+
+// find returns the first record with the message whose type attribute matches.
+func (h *captureHandler) find(msg string, typ reflect.Type) (capturedRecord, bool) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	for _, record := range h.records {
+		if record.msg == msg && record.attrs["type"] == typ.String() {
+			return record, true
+		}
+	}
+
+	return capturedRecord{}, false
+}
+
+func TestWithLogger_Factory(t *testing.T) {
+	t.Parallel()
+
+	handler := new(captureHandler)
+	di := dino.New(dino.WithLogger(slog.New(handler)))
+
+	if err := di.Factory(func() *LoggedDatabase { return &LoggedDatabase{} }, "primary"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for range 2 {
+		if _, err := di.Resolve(reflect.TypeFor[*LoggedDatabase](), "primary"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	typ := reflect.TypeFor[*LoggedDatabase]()
+
+	hit, ok := handler.find("dino: registry hit", typ)
+	if !ok {
+		t.Fatal("expected a registry hit to be logged")
+	}
+
+	if hit.level != slog.LevelDebug || hit.attrs["tag"] != "primary" || hit.attrs["source"] != "factory" {
+		t.Errorf("unexpected registry hit record: %+v", hit)
+	}
+
+	if _, ok := handler.find("dino: factory call started", typ); !ok {
+		t.Error("expected the factory call start to be logged")
+	}
+
+	done, ok := handler.find("dino: factory call finished", typ)
+	if !ok {
+		t.Fatal("expected the factory call end to be logged")
+	}
+
+	if _, ok := done.attrs["duration"]; !ok {
+		t.Errorf("expected the factory call duration, got %+v", done.attrs)
+	}
+
+	cached := 0
+
+	for _, record := range handler.records {
+		if record.msg == "dino: registry hit" && record.attrs["source"] == "cached" {
+			cached++
+		}
+	}
+
+	if cached != 1 {
+		t.Errorf("expected the second resolution to hit the cached instance, got %d cached hits", cached)
+	}
+}
+
+func TestWithLogger_AutoCreate(t *testing.T) {
+	t.Parallel()
+
+	handler := new(captureHandler)
+	di := dino.New(dino.WithLogger(slog.New(handler)))
+
+	var target LoggedHandler
+	if err := di.Inject(&target); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	miss, ok := handler.find("dino: registry miss", reflect.TypeFor[*LoggedService]())
+	if !ok || miss.attrs["tag"] != "" {
+		t.Errorf("expected a registry miss to be logged, got %+v", miss)
+	}
+
+	for _, typ := range []reflect.Type{reflect.TypeFor[*LoggedService](), reflect.TypeFor[*LoggedDatabase]()} {
+		record, ok := handler.find("dino: auto-creating dependency", typ)
+		if !ok {
+			t.Fatalf("expected auto-creation of %s to be logged", typ)
+		}
+
+		if record.attrs["reason"] != "not registered" {
+			t.Errorf("unexpected auto-creation reason: %q", record.attrs["reason"])
+		}
+	}
+}
+
+func TestWithLogger_FactoryError(t *testing.T) {
+	t.Parallel()
+
+	handler := new(captureHandler)
+	di := dino.New(dino.WithLogger(slog.New(handler)))
+
+	if err := di.Factory(func() (*LoggedDatabase, error) { return nil, errHookFailed }); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := di.Factory(func(db *LoggedDatabase) *LoggedService { return &LoggedService{DB: db} }); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := di.Resolve(reflect.TypeFor[*LoggedService]()); !errors.Is(err, errHookFailed) {
+		t.Fatalf("expected factory error, got %v", err)
+	}
+
+	record, ok := handler.find("dino: factory call failed", reflect.TypeFor[*LoggedDatabase]())
+	if !ok {
+		t.Fatal("expected the factory failure to be logged")
+	}
+
+	expected := "*dino_test.LoggedService (factory) -> *dino_test.LoggedDatabase (factory)"
+	if record.attrs["path"] != expected {
+		t.Errorf("expected path %q, got %q", expected, record.attrs["path"])
+	}
+
+	if record.attrs["error"] == "" {
+		t.Error("expected the error to be logged")
+	}
+}
+
+type LoggingService struct {
+	Logger *slog.Logger
+}
+
+func TestWithDefaultLogger(t *testing.T) {
+	t.Parallel()
+
+	di := dino.New(dino.WithDefaultLogger(&slog.HandlerOptions{Level: slog.LevelError}))
+
+	service := new(LoggingService)
+	if err := di.Inject(service); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if service.Logger == nil || service.Logger.Handler() == nil {
+		t.Fatal("expected a working default logger")
+	}
+
+	service.Logger.Info("ignored below the error level")
+
+	logger, err := dino.Resolve[*slog.Logger](di)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if logger != service.Logger {
+		t.Fatal("expected every resolution to share the default logger")
+	}
+}
+
+func TestWithDefaultLogger_Precedence(t *testing.T) {
+	t.Parallel()
+
+	explicit := slog.New(new(captureHandler))
+	container := slog.New(new(captureHandler))
+
+	tests := []struct {
+		name     string
+		opts     []dino.Option
+		register bool
+		expected *slog.Logger
+	}{
+		{
+			name:     "explicit registration",
+			opts:     []dino.Option{dino.WithDefaultLogger(nil)},
+			register: true,
+			expected: explicit,
+		},
+		{
+			name:     "container logger set before",
+			opts:     []dino.Option{dino.WithLogger(container), dino.WithDefaultLogger(nil)},
+			register: false,
+			expected: container,
+		},
+		{
+			name:     "container logger set after",
+			opts:     []dino.Option{dino.WithDefaultLogger(nil), dino.WithLogger(container)},
+			register: false,
+			expected: container,
+		},
+		{
+			name:     "explicit registration over container logger",
+			opts:     []dino.Option{dino.WithLogger(container), dino.WithDefaultLogger(nil)},
+			register: true,
+			expected: explicit,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			di := dino.New(tt.opts...)
+
+			if tt.register {
+				if err := di.Singleton(explicit); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			}
+
+			logger, err := dino.Resolve[*slog.Logger](di.Scope())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if logger != tt.expected {
+				t.Fatal("expected the logger with the highest precedence")
+			}
+		})
+	}
+}
+
+func TestWithDefaultLogger_SharedWithHandlerFunc(t *testing.T) {
+	t.Parallel()
+
+	di := dino.New(dino.WithDefaultLogger(nil))
+
+	logger, err := dino.Resolve[*slog.Logger](di)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Swap the handler behind the shared instance to observe what the container logs
+	logs := new(captureHandler)
+	*logger = *slog.New(logs)
+
+	recorder := handlerRequest(t, dino.HandlerFunc(di, func(*http.Request) error { return errUserNotFound }))
+	if recorder.Code != http.StatusInternalServerError {
+		t.Fatalf("expected status 500, got %d", recorder.Code)
+	}
+
+	logs.mutex.Lock()
+	defer logs.mutex.Unlock()
+
+	for _, record := range logs.records {
+		if record.msg == "dino: handler failed" {
+			return
+		}
+	}
+
+	t.Fatalf("expected the failure to be logged to the default logger, got %+v", logs.records)
+}
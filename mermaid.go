@@ -0,0 +1,53 @@
+package dino
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// WriteMermaid writes the dependency graph of the container as a Mermaid flowchart, e.g. for
+// documentation. Every registered key, and every key a factory depends on, is a node labelled with its
+// type: interfaces are drawn as rounded nodes, concrete types as rectangles. Edges point from a factory
+// result to its dependencies, labelled with the dependency tag, if any. Dependencies forming a cycle
+// are still drawn, preceded by a comment naming them. Factories are never called.
+func (d *Dino) WriteMermaid(w io.Writer) error {
+	g := d.graph()
+
+	var out strings.Builder
+
+	out.WriteString("graph TD\n")
+
+	if g.cycle != nil {
+		fmt.Fprintf(&out, "    %%%% %s\n", g.cycle)
+	}
+
+	for idx, key := range g.nodes {
+		label := mermaidEscape(key.Type.String())
+
+		if isInterfaceKey(key) {
+			fmt.Fprintf(&out, "    n%d([\"%s\"])\n", idx, label)
+		} else {
+			fmt.Fprintf(&out, "    n%d[\"%s\"]\n", idx, label)
+		}
+	}
+
+	for _, edge := range g.edges {
+		if tag := g.nodes[edge.to].Tag; tag != "" {
+			fmt.Fprintf(&out, "    n%d -->|\"%s\"| n%d\n", edge.from, mermaidEscape(tag), edge.to)
+		} else {
+			fmt.Fprintf(&out, "    n%d --> n%d\n", edge.from, edge.to)
+		}
+	}
+
+	if _, err := io.WriteString(w, out.String()); err != nil {
+		return fmt.Errorf("write mermaid graph: %w", err)
+	}
+
+	return nil
+}
+
+// mermaidEscape replaces the characters that end a quoted Mermaid label with entity codes.
+func mermaidEscape(text string) string {
+	return strings.NewReplacer(`"`, "#quot;", "|", "#124;").Replace(text)
+}
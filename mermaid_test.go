@@ -0,0 +1,118 @@
+package dino_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/yuppyweb/dino"
+)
+
+type MermaidLogger interface {
+	Log(msg string)
+}
+
+type MermaidConfig struct{}
+
+type MermaidRepository struct{}
+
+type MermaidService struct{}
+
+type MermaidParams struct {
+	dino.In
+
+	Config *MermaidConfig `inject:"primary"`
+	Logger MermaidLogger
+}
+
+type MermaidA struct{}
+
+type MermaidB struct{}
+
+func TestDino_WriteMermaid(t *testing.T) {
+	t.Parallel()
+
+	di := dino.New()
+
+	if err := di.Singleton(&MermaidConfig{}, "primary"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	factories := []any{
+		func() MermaidLogger { return nil },
+		func(MermaidParams) *MermaidRepository { return &MermaidRepository{} },
+		func(*MermaidRepository, MermaidLogger) *MermaidService { return &MermaidService{} },
+	}
+
+	for _, factory := range factories {
+		if err := di.Factory(factory); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	var out strings.Builder
+
+	if err := di.WriteMermaid(&out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := `graph TD
+    n0["*dino_test.MermaidConfig"]
+    n1["*dino_test.MermaidRepository"]
+    n2["*dino_test.MermaidService"]
+    n3(["dino_test.MermaidLogger"])
+    n1 -->|"primary"| n0
+    n1 --> n3
+    n2 --> n1
+    n2 --> n3
+`
+
+	if out.String() != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, out.String())
+	}
+}
+
+func TestDino_WriteMermaidCycle(t *testing.T) {
+	t.Parallel()
+
+	di := dino.New()
+
+	if err := di.Factory(func(*MermaidB) *MermaidA { return &MermaidA{} }); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := di.Factory(func(*MermaidA) *MermaidB { return &MermaidB{} }); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var out strings.Builder
+
+	if err := di.WriteMermaid(&out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := `graph TD
+    %% circular dependency detected between *dino_test.MermaidA, *dino_test.MermaidB
+    n0["*dino_test.MermaidA"]
+    n1["*dino_test.MermaidB"]
+    n0 --> n1
+    n1 --> n0
+`
+
+	if out.String() != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, out.String())
+	}
+}
+
+func TestDino_WriteMermaidWriteError(t *testing.T) {
+	t.Parallel()
+
+	di := dino.New()
+
+	if err := di.Singleton(&MermaidConfig{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := di.WriteMermaid(failingWriter{}); err == nil {
+		t.Fatalf("expected write error, got %v", err)
+	}
+}
@@ -0,0 +1,156 @@
+package dino
+
+import (
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// unlimitedAutoCreateDepth is the auto-creation depth of containers not limited with WithAutoCreateDepth.
+const unlimitedAutoCreateDepth = -1
+
+// Option configures a Dino container and the injectors it creates.
+type Option func(*options)
+
+// options holds the settings shared by a Dino container and its injectors.
+type options struct {
+	skipCyclicFields   bool
+	cacheSharing       bool
+	aggregateErrors    bool
+	registry           Registry
+	ttls               sync.Map
+	perScope           sync.Map
+	deps               sync.Map
+	factories          sync.Map
+	typedFactories     sync.Map
+	appended           sync.Map
+	stats              *statsCollector
+	lifecycle          *lifecycle
+	healthCheckTimeout time.Duration
+	errorHandler       func(error)
+	logger             *resolutionLogger
+	trace              TraceHook
+	expvarName         string
+	parent             *options
+	factoryLocks       *keyLocks
+	buildParallelism   int
+	parallelArgs       int
+	envLookup          func(string) (string, bool)
+	flags              []flagSpec
+	defaultLogger      *slog.Logger
+	qualifiers         map[string]any
+	maxAutoCreateDepth int
+	strictAutoCreate   bool
+}
+
+// newOptions builds the settings from the provided options, starting from the defaults.
+func newOptions(opts ...Option) *options {
+	o := &options{
+		skipCyclicFields:   false,
+		cacheSharing:       false,
+		aggregateErrors:    false,
+		registry:           nil,
+		ttls:               sync.Map{},
+		perScope:           sync.Map{},
+		deps:               sync.Map{},
+		factories:          sync.Map{},
+		typedFactories:     sync.Map{},
+		appended:           sync.Map{},
+		stats:              nil,
+		lifecycle:          newLifecycle(),
+		healthCheckTimeout: defaultHealthCheckTimeout,
+		errorHandler:       nil,
+		logger:             nil,
+		trace:              nil,
+		expvarName:         "",
+		parent:             nil,
+		factoryLocks:       newKeyLocks(),
+		buildParallelism:   0,
+		parallelArgs:       0,
+		envLookup:          nil,
+		flags:              nil,
+		defaultLogger:      nil,
+		qualifiers:         nil,
+		maxAutoCreateDepth: unlimitedAutoCreateDepth,
+		strictAutoCreate:   false,
+	}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	// The default logger is the one the container logs with, whatever the order of the options
+	if o.defaultLogger != nil {
+		if o.logger != nil {
+			o.defaultLogger = o.logger.logger
+		} else {
+			o.logger = &resolutionLogger{logger: o.defaultLogger}
+		}
+	}
+
+	return o
+}
+
+// SkipCyclicFields leaves a field at its zero value instead of returning ErrCircularDependency
+// when auto-creating it would instantiate a struct type that is already being injected,
+// e.g. the Next field of type Node struct { Next *Node }.
+func SkipCyclicFields() Option {
+	return func(o *options) {
+		o.skipCyclicFields = true
+	}
+}
+
+// WithCacheSharing makes the result of a factory resolved under a tag also available untagged.
+// When a tagged factory result is cached, it is registered under the empty tag as well, but only
+// if nothing is registered untagged for its type at that moment. The precedence rules are:
+//   - an untagged registration made before the tagged result is cached is never replaced by it;
+//   - an untagged registration made after it replaces the shared result;
+//   - the first tagged result cached for a type wins over results cached later under other tags.
+func WithCacheSharing() Option {
+	return func(o *options) {
+		o.cacheSharing = true
+	}
+}
+
+// AggregateErrors makes function argument preparation attempt every parameter instead of stopping
+// at the first failure. The errors of all unresolvable parameters are joined with errors.Join,
+// each naming the parameter index and type, and the function is not called. The fields of parameter
+// objects embedding In are attempted the same way, each error naming the field, its type and tag.
+func AggregateErrors() Option {
+	return func(o *options) {
+		o.aggregateErrors = true
+	}
+}
+
+// WithAutoCreateDepth limits how deep dependencies missing from the registry are auto-created: 0 disables
+// auto-creation, 1 creates the structs directly referenced by the injected target or function but not their
+// own missing dependencies, and so on. Dependencies beyond the depth are left at their zero value, or fail
+// with ErrAutoCreateDepth with StrictAutoCreate. A negative depth, the default, is unlimited.
+// Parameter objects embedding dino.In are not dependencies of their own and do not count.
+func WithAutoCreateDepth(depth int) Option {
+	return func(o *options) {
+		o.maxAutoCreateDepth = depth
+	}
+}
+
+// StrictAutoCreate makes dependencies beyond the depth set by WithAutoCreateDepth fail with ErrAutoCreateDepth
+// instead of being left at their zero value.
+func StrictAutoCreate() Option {
+	return func(o *options) {
+		o.strictAutoCreate = true
+	}
+}
+
+// allowsAutoCreate reports whether a dependency is auto-created at the depth, counted from 1.
+func (o *options) allowsAutoCreate(depth int) bool {
+	return o.maxAutoCreateDepth < 0 || depth <= o.maxAutoCreateDepth
+}
+
+// WithRegistry makes a container store its dependencies in the provided registry,
+// e.g. dino.New(dino.WithRegistry(dino.NewShardedRegistry(16))). It is ignored by NewInjector,
+// which takes its registry as an argument.
+func WithRegistry(registry Registry) Option {
+	return func(o *options) {
+		o.registry = registry
+	}
+}
@@ -0,0 +1,183 @@
+package dino
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// factoryDependencies returns the registry keys a factory function takes as parameters: a key per
+// parameter, or per field of a parameter object. Providers are resolved lazily and the container's
+// Lifecycle is not registered, so neither counts as a dependency.
+func factoryDependencies(rt reflect.Type) []RegistryKey {
+	var deps []RegistryKey
+
+	for param := range rt.Ins() {
+		switch {
+		case param == reflect.TypeFor[*Lifecycle]() || isProvider(param):
+			continue
+
+		case isInStruct(param):
+			deps = append(deps, fieldDependencies(param)...)
+
+		default:
+			deps = append(deps, RegistryKey{
+				Tag:       "",
+				Type:      param,
+				Scope:     "",
+				Qualifier: nil,
+			})
+		}
+	}
+
+	return deps
+}
+
+// setDependencies records the keys that the value registered under the keys depends on.
+// Factory results keep the dependencies of their factory after being cached.
+func (o *options) setDependencies(keys []RegistryKey, deps []RegistryKey) {
+	for _, key := range keys {
+		if len(deps) > 0 {
+			o.deps.Store(key, deps)
+		} else {
+			o.deps.Delete(key)
+		}
+	}
+}
+
+// dependencies returns the keys the value registered under the key depends on.
+func (o *options) dependencies(key RegistryKey) []RegistryKey {
+	value, ok := o.deps.Load(key)
+	if !ok {
+		if o.parent != nil {
+			return o.parent.dependencies(key)
+		}
+
+		return nil
+	}
+
+	deps, _ := value.([]RegistryKey)
+
+	return deps
+}
+
+// topoOrder returns the indexes of keys ordered so that every key comes after the keys it depends on,
+// keeping the given order wherever dependencies allow. Keys may repeat; dependencies on keys that are
+// not listed are ignored. It returns ErrCircularDependency if no such order exists.
+func topoOrder(keys []RegistryKey, dependencies func(RegistryKey) []RegistryKey) ([]int, error) {
+	positions := make(map[RegistryKey][]int, len(keys))
+	for idx, key := range keys {
+		positions[key] = append(positions[key], idx)
+	}
+
+	emitted := make([]bool, len(keys))
+	order := make([]int, 0, len(keys))
+
+	ready := func(idx int) bool {
+		for _, dep := range dependencies(keys[idx]) {
+			for _, pos := range positions[dep] {
+				if pos != idx && !emitted[pos] {
+					return false
+				}
+			}
+		}
+
+		return true
+	}
+
+	for len(order) < len(keys) {
+		next := -1
+
+		for idx := range keys {
+			if !emitted[idx] && ready(idx) {
+				next = idx
+
+				break
+			}
+		}
+
+		if next < 0 {
+			return nil, fmt.Errorf("%w between %s", ErrCircularDependency, describeKeys(keys, emitted))
+		}
+
+		emitted[next] = true
+		order = append(order, next)
+	}
+
+	return order, nil
+}
+
+// describeKeys lists the keys that were not emitted.
+func describeKeys(keys []RegistryKey, emitted []bool) string {
+	var names []string
+
+	for idx, key := range keys {
+		if !emitted[idx] {
+			names = append(names, keyName(key))
+		}
+	}
+
+	return strings.Join(slices.Compact(names), ", ")
+}
+
+// orderInstances orders lifecycle entries so that every instance comes after the instances it depends on,
+// keeping creation order wherever dependencies allow.
+func (o *options) orderInstances(instances []*instance) ([]*instance, error) {
+	keys := make([]RegistryKey, len(instances))
+	for idx, inst := range instances {
+		keys[idx] = inst.key
+	}
+
+	order, err := topoOrder(keys, o.dependencies)
+	if err != nil {
+		return instances, err
+	}
+
+	ordered := make([]*instance, len(order))
+	for pos, idx := range order {
+		ordered[pos] = instances[idx]
+	}
+
+	return ordered, nil
+}
+
+// Order returns the registry keys in the order Start brings their instances up, dependencies first:
+// instances already created in creation order, then the remaining registrations, each placed after
+// the keys its factory depends on. Shutdown uses the reverse order. It returns ErrCircularDependency
+// if the factories depend on each other in a cycle.
+func (d *Dino) Order() ([]RegistryKey, error) {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	keys := d.options.lifecycle.keys()
+	created := make(map[RegistryKey]struct{}, len(keys))
+
+	for _, key := range keys {
+		created[key] = struct{}{}
+	}
+
+	var pending []RegistryKey
+
+	for key := range d.registry.All() {
+		if _, ok := created[key]; !ok {
+			pending = append(pending, key)
+		}
+	}
+
+	sortKeys(pending)
+
+	keys = append(keys, pending...)
+
+	order, err := topoOrder(keys, d.options.dependencies)
+	if err != nil {
+		return nil, err
+	}
+
+	ordered := make([]RegistryKey, len(order))
+	for pos, idx := range order {
+		ordered[pos] = keys[idx]
+	}
+
+	return ordered, nil
+}
@@ -0,0 +1,161 @@
+package dino_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/yuppyweb/dino"
+)
+
+type OrderConfig struct{}
+
+type OrderPool struct{}
+
+type OrderDatabase struct{}
+
+type OrderCache struct{}
+
+type OrderRepository struct{}
+
+type OrderService struct{}
+
+type OrderHandler struct{}
+
+type OrderMetrics struct{}
+
+type OrderTracer struct{}
+
+func orderKey[T any]() dino.RegistryKey {
+	return dino.RegistryKey{
+		Tag:   "",
+		Type:  reflect.TypeFor[T](),
+		Scope: "",
+	}
+}
+
+// newOrderChain registers the examples/04 dependency chain, factories in no particular order.
+func newOrderChain(t *testing.T) *dino.Dino {
+	t.Helper()
+
+	di := dino.New()
+
+	factories := []any{
+		func(*OrderService) *OrderHandler { return &OrderHandler{} },
+		func(*OrderCache) *OrderRepository { return &OrderRepository{} },
+		func(*OrderPool) *OrderDatabase { return &OrderDatabase{} },
+		func(*OrderRepository) *OrderService { return &OrderService{} },
+		func(*OrderConfig) *OrderPool { return &OrderPool{} },
+		func(*OrderDatabase) *OrderCache { return &OrderCache{} },
+	}
+
+	for _, factory := range factories {
+		if err := di.Factory(factory); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if err := di.Singleton(&OrderConfig{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	return di
+}
+
+func TestDino_OrderChain(t *testing.T) {
+	t.Parallel()
+
+	di := newOrderChain(t)
+
+	expected := []dino.RegistryKey{
+		orderKey[*OrderConfig](),
+		orderKey[*OrderPool](),
+		orderKey[*OrderDatabase](),
+		orderKey[*OrderCache](),
+		orderKey[*OrderRepository](),
+		orderKey[*OrderService](),
+		orderKey[*OrderHandler](),
+	}
+
+	order, err := di.Order()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !slices.Equal(order, expected) {
+		t.Fatalf("expected order %v, got %v", expected, order)
+	}
+
+	// Building the container records the same order
+	if err := di.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	order, err = di.Order()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !slices.Equal(order, expected) {
+		t.Fatalf("expected order %v after start, got %v", expected, order)
+	}
+}
+
+func TestDino_OrderCrossEdge(t *testing.T) {
+	t.Parallel()
+
+	di := dino.New()
+
+	if err := di.Factory(func() *OrderMetrics { return &OrderMetrics{} }); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := di.Factory(func() *OrderTracer { return &OrderTracer{} }); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	order, err := di.Order()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []dino.RegistryKey{orderKey[*OrderMetrics](), orderKey[*OrderTracer]()}
+	if !slices.Equal(order, expected) {
+		t.Fatalf("expected order %v, got %v", expected, order)
+	}
+
+	// Metrics now report through the tracer, which must come first
+	if err := di.Factory(func(*OrderTracer) *OrderMetrics { return &OrderMetrics{} }); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	order, err = di.Order()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected = []dino.RegistryKey{orderKey[*OrderTracer](), orderKey[*OrderMetrics]()}
+	if !slices.Equal(order, expected) {
+		t.Fatalf("expected order %v, got %v", expected, order)
+	}
+}
+
+func TestDino_OrderCycle(t *testing.T) {
+	t.Parallel()
+
+	di := dino.New()
+
+	if err := di.Factory(func(*OrderTracer) *OrderMetrics { return &OrderMetrics{} }); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := di.Factory(func(*OrderMetrics) *OrderTracer { return &OrderTracer{} }); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := di.Order(); !errors.Is(err, dino.ErrCircularDependency) {
+		t.Fatalf("expected ErrCircularDependency, got %v", err)
+	}
+}
@@ -0,0 +1,89 @@
+// Package otel instruments the resolutions of a dino container with OpenTelemetry spans.
+// It is kept apart from the dino package so that only applications using it depend on OpenTelemetry:
+//
+//	hook := otel.NewHook(provider.Tracer("app"))
+//	di := dino.New(dino.WithTrace(hook))
+package otel
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/yuppyweb/dino"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TagAttribute is the span attribute holding the tag of the resolved key.
+const TagAttribute = "dino.tag"
+
+// Hook is a dino.TraceHook wrapping every resolution in a span named after the resolved type, with the
+// tag as the dino.tag attribute and a failure recorded as the span error. Resolutions of dependencies
+// are children of the resolution of their dependent; top-level resolutions are children of the span set
+// with SetParent, if any. Spans are nested by the order their resolutions begin and end, so resolutions
+// running concurrently may be recorded as children of each other.
+type Hook struct {
+	tracer trace.Tracer
+	mutex  sync.Mutex
+	parent trace.SpanContext
+	open   []trace.Span
+}
+
+// NewHook creates a hook starting spans with the tracer.
+func NewHook(tracer trace.Tracer) *Hook {
+	return &Hook{
+		tracer: tracer,
+		mutex:  sync.Mutex{},
+		parent: trace.SpanContext{},
+		open:   nil,
+	}
+}
+
+// SetParent makes the span of the context, e.g. the one passed to dino.Dino.Start, the parent of the
+// following top-level resolutions. A context without a span makes them root spans again.
+func (h *Hook) SetParent(ctx context.Context) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	h.parent = trace.SpanContextFromContext(ctx)
+}
+
+// ResolveStart starts the span of the resolution of the key and returns the function ending it.
+func (h *Hook) ResolveStart(key dino.RegistryKey) func(err error) {
+	h.mutex.Lock()
+
+	ctx := trace.ContextWithSpanContext(context.Background(), h.parent)
+	if len(h.open) > 0 {
+		ctx = trace.ContextWithSpan(context.Background(), h.open[len(h.open)-1])
+	}
+
+	_, span := h.tracer.Start(ctx, key.Type.String(), trace.WithAttributes(
+		attribute.String(TagAttribute, key.Tag),
+	))
+
+	h.open = append(h.open, span)
+
+	h.mutex.Unlock()
+
+	return func(err error) {
+		h.mutex.Lock()
+
+		if idx := slices.Index(h.open, span); idx >= 0 {
+			h.open = slices.Delete(h.open, idx, idx+1)
+		}
+
+		h.mutex.Unlock()
+
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+
+		span.End()
+	}
+}
+
+// Ensure Hook implements the dino.TraceHook interface.
+var _ dino.TraceHook = (*Hook)(nil)
@@ -0,0 +1,158 @@
+package otel_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/yuppyweb/dino"
+	"github.com/yuppyweb/dino/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+var errConnect = errors.New("connection refused")
+
+type Config struct{}
+
+type Pool struct{}
+
+type Database struct{}
+
+type Cache struct{}
+
+type Repository struct{}
+
+type Service struct{}
+
+type Handler struct{}
+
+// newChain registers the examples/04 dependency chain, with the database factory failing with dbErr.
+func newChain(t *testing.T, dbErr error) (*dino.Dino, *otel.Hook, *tracetest.SpanRecorder) {
+	t.Helper()
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	hook := otel.NewHook(provider.Tracer("dino"))
+	di := dino.New(dino.WithTrace(hook))
+
+	factories := []any{
+		func(*Config) *Pool { return &Pool{} },
+		func(*Pool) (*Database, error) { return &Database{}, dbErr },
+		func(*Database) *Cache { return &Cache{} },
+		func(*Cache) *Repository { return &Repository{} },
+		func(*Repository) *Service { return &Service{} },
+		func(*Service) *Handler { return &Handler{} },
+	}
+
+	if err := di.Singleton(&Config{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := di.Singleton("postgres://localhost", "dsn"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, factory := range factories {
+		if err := di.Factory(factory); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	return di, hook, recorder
+}
+
+func TestHook_Chain(t *testing.T) {
+	t.Parallel()
+
+	di, hook, recorder := newChain(t, nil)
+
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	ctx, root := provider.Tracer("app").Start(context.Background(), "startup")
+	hook.SetParent(ctx)
+
+	if _, err := di.Resolve(reflect.TypeFor[*Handler]()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	root.End()
+
+	spans := recorder.Ended()
+
+	// Spans end innermost first
+	expected := []string{"*otel_test.Config", "*otel_test.Pool", "*otel_test.Database", "*otel_test.Cache",
+		"*otel_test.Repository", "*otel_test.Service", "*otel_test.Handler", "startup"}
+
+	if len(spans) != len(expected) {
+		t.Fatalf("expected %d spans, got %d", len(expected), len(spans))
+	}
+
+	for idx, span := range spans {
+		if span.Name() != expected[idx] {
+			t.Errorf("expected span %d to be %s, got %s", idx, expected[idx], span.Name())
+		}
+
+		// Every span is a child of the one ending after it
+		if idx+1 < len(spans) && span.Parent().SpanID() != spans[idx+1].SpanContext().SpanID() {
+			t.Errorf("expected %s to be a child of %s", span.Name(), spans[idx+1].Name())
+		}
+
+		if span.SpanContext().TraceID() != root.SpanContext().TraceID() {
+			t.Errorf("expected %s to belong to the startup trace", span.Name())
+		}
+	}
+}
+
+func TestHook_Error(t *testing.T) {
+	t.Parallel()
+
+	di, _, recorder := newChain(t, errConnect)
+
+	if _, err := di.Resolve(reflect.TypeFor[*Cache]()); !errors.Is(err, errConnect) {
+		t.Fatalf("expected factory error, got %v", err)
+	}
+
+	for _, span := range recorder.Ended() {
+		if span.Name() == "*otel_test.Config" || span.Name() == "*otel_test.Pool" {
+			if span.Status().Code == codes.Error {
+				t.Errorf("expected %s to succeed", span.Name())
+			}
+
+			continue
+		}
+
+		if span.Status().Code != codes.Error || len(span.Events()) == 0 {
+			t.Errorf("expected %s to record the factory error", span.Name())
+		}
+	}
+
+	if len(recorder.Ended()) != 4 {
+		t.Errorf("expected 4 spans, got %d", len(recorder.Ended()))
+	}
+}
+
+func TestHook_Tag(t *testing.T) {
+	t.Parallel()
+
+	di, _, recorder := newChain(t, nil)
+
+	if _, err := di.Resolve(reflect.TypeFor[string](), "dsn"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("expected a single span, got %d", len(spans))
+	}
+
+	for _, attr := range spans[0].Attributes() {
+		if string(attr.Key) == otel.TagAttribute && attr.Value.AsString() == "dsn" {
+			return
+		}
+	}
+
+	t.Errorf("expected the %s attribute to hold the tag, got %v", otel.TagAttribute, spans[0].Attributes())
+}
@@ -0,0 +1,178 @@
+package dino
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+// OverrideT replaces the binding of T under the tags, or the untagged one, with the value for the
+// duration of the test, e.g. to swap a client for a fake. Any cached instance is replaced as well.
+// The previous binding, whether an instance, a factory or nothing, is put back when the test and its
+// subtests complete. An invalid value fails the test.
+func OverrideT[T any](tb testing.TB, d *Dino, value T, tags ...string) {
+	tb.Helper()
+
+	rt := reflect.TypeFor[T]()
+	rv := reflect.ValueOf(any(value))
+
+	if isNil(rv) {
+		tb.Fatalf("dino: override %s: %v", rt, fmt.Errorf("%w: value cannot be nil", ErrInvalidInputValue))
+
+		return
+	}
+
+	saved := d.saveKeys(bindingKeys([]binding{{typ: rt, tags: tags}}))
+
+	if err := d.overrideValue(rt, rv, tags...); err != nil {
+		tb.Fatalf("dino: override %s: %v", rt, errors.Join(err, d.restoreKeys(saved)))
+
+		return
+	}
+
+	tb.Cleanup(func() {
+		if err := d.restoreKeys(saved); err != nil {
+			tb.Errorf("dino: restore %s: %v", rt, err)
+		}
+	})
+}
+
+// OverrideFactoryT registers the factory function like Factory for the duration of the test, e.g. a
+// constructor returning fakes. The bindings of its outputs, and the instances cached for them, are put
+// back when the test and its subtests complete. An invalid factory fails the test.
+func OverrideFactoryT(tb testing.TB, d *Dino, fn any, tags ...string) {
+	tb.Helper()
+
+	var keys []RegistryKey
+
+	if rv := reflect.ValueOf(fn); !isNil(rv) && isFunction(rv.Type()) {
+		keys = bindingKeys(factoryBindings(rv.Type(), tags))
+	}
+
+	saved := d.saveKeys(keys)
+
+	if err := d.Factory(fn, tags...); err != nil {
+		tb.Fatalf("dino: override factory: %v", errors.Join(err, d.restoreKeys(saved)))
+
+		return
+	}
+
+	tb.Cleanup(func() {
+		if err := d.restoreKeys(saved); err != nil {
+			tb.Errorf("dino: restore factory %T: %v", fn, err)
+		}
+	})
+}
+
+// savedKeys holds the registrations and settings of keys, as they were before an override.
+type savedKeys struct {
+	registrations []registration
+	settings      []savedSetting
+}
+
+// savedSetting is the setting recorded for a key in one of the per-key stores of the options.
+type savedSetting struct {
+	store *sync.Map
+	key   RegistryKey
+	value any
+	found bool
+}
+
+// bindingKeys returns the registry keys of the bindings, the untagged one for bindings without tags.
+func bindingKeys(bindings []binding) []RegistryKey {
+	var keys []RegistryKey
+
+	for _, bnd := range bindings {
+		tags := bnd.tags
+		if len(tags) == 0 {
+			tags = []string{""}
+		}
+
+		for _, tag := range tags {
+			keys = append(keys, RegistryKey{
+				Tag:       tag,
+				Type:      bnd.typ,
+				Scope:     "",
+				Qualifier: nil,
+			})
+		}
+	}
+
+	return keys
+}
+
+// saveKeys records the registrations of the keys and the settings recorded for them.
+func (d *Dino) saveKeys(keys []RegistryKey) savedKeys {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	stores := []*sync.Map{&d.options.ttls, &d.options.deps, &d.options.factories, &d.options.typedFactories}
+
+	saved := savedKeys{
+		registrations: make([]registration, 0, len(keys)),
+		settings:      make([]savedSetting, 0, len(keys)*len(stores)),
+	}
+
+	for _, key := range keys {
+		prev, err := d.registry.Find(key)
+
+		saved.registrations = append(saved.registrations, registration{
+			key:   key,
+			prev:  prev,
+			found: err == nil,
+		})
+
+		for _, store := range stores {
+			value, found := store.Load(key)
+
+			saved.settings = append(saved.settings, savedSetting{
+				store: store,
+				key:   key,
+				value: value,
+				found: found,
+			})
+		}
+	}
+
+	return saved
+}
+
+// restoreKeys puts back the registrations and settings recorded by saveKeys.
+func (d *Dino) restoreKeys(saved savedKeys) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	for _, setting := range saved.settings {
+		if setting.found {
+			setting.store.Store(setting.key, setting.value)
+		} else {
+			setting.store.Delete(setting.key)
+		}
+	}
+
+	if err := newInjector(d.registry, d.options).rollback(saved.registrations); err != nil {
+		return fmt.Errorf("failed to restore overridden bindings: %w", err)
+	}
+
+	return nil
+}
+
+// overrideValue binds the value under the type and tags in place of any instance or factory.
+func (d *Dino) overrideValue(rt reflect.Type, rv reflect.Value, tags ...string) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	keys := taggedKeys(rt, nil, tags)
+
+	if err := newInjector(d.registry, d.options).bind(keys, rv); err != nil {
+		return fmt.Errorf("failed to bind override: %w", err)
+	}
+
+	d.options.setLifetime(keys, 0)
+	d.options.setDependencies(keys, nil)
+	d.options.setFactory(keys, reflect.Value{})
+
+	return nil
+}
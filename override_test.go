@@ -0,0 +1,213 @@
+package dino_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/yuppyweb/dino"
+)
+
+type OverrideMailer interface {
+	Send(to string) string
+}
+
+type smtpMailer struct{}
+
+func (smtpMailer) Send(to string) string {
+	return "smtp:" + to
+}
+
+type fakeMailer struct {
+	name string
+}
+
+func (m fakeMailer) Send(to string) string {
+	return m.name + ":" + to
+}
+
+type OverrideSignup struct {
+	Mailer OverrideMailer
+}
+
+// fatalTB records the failures of a test helper instead of ending the test.
+type fatalTB struct {
+	testing.TB
+
+	failures []string
+	cleanups []func()
+}
+
+func (tb *fatalTB) Helper() {}
+
+func (tb *fatalTB) Fatalf(format string, args ...any) {
+	tb.failures = append(tb.failures, fmt.Sprintf(format, args...))
+}
+
+func (tb *fatalTB) Cleanup(fn func()) {
+	tb.cleanups = append(tb.cleanups, fn)
+}
+
+func sendWith(t *testing.T, di *dino.Dino) string {
+	t.Helper()
+
+	mailer, err := dino.Resolve[OverrideMailer](di)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	return mailer.Send("bob")
+}
+
+func TestOverrideT(t *testing.T) {
+	t.Parallel()
+
+	di := dino.New()
+
+	if err := di.Factory(func() OverrideMailer { return smtpMailer{} }); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	t.Run("fake", func(t *testing.T) {
+		dino.OverrideT[OverrideMailer](t, di, fakeMailer{name: "fake"})
+
+		if got := sendWith(t, di); got != "fake:bob" {
+			t.Fatalf("expected the fake mailer, got %q", got)
+		}
+
+		// Dependents resolved during the test receive the fake as well
+		signup := new(OverrideSignup)
+		if err := di.Inject(signup); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if got := signup.Mailer.Send("eve"); got != "fake:eve" {
+			t.Fatalf("expected the fake mailer to be injected, got %q", got)
+		}
+	})
+
+	if got := sendWith(t, di); got != "smtp:bob" {
+		t.Fatalf("expected the original factory to be back, got %q", got)
+	}
+}
+
+func TestOverrideT_CachedInstance(t *testing.T) {
+	t.Parallel()
+
+	di := dino.New()
+
+	if err := di.Factory(func() *fakeMailer { return &fakeMailer{name: "original"} }, "primary"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	original, err := dino.Resolve[*fakeMailer](di, "primary")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	t.Run("fake", func(t *testing.T) {
+		dino.OverrideT(t, di, &fakeMailer{name: "fake"}, "primary")
+
+		if mailer, _ := dino.Resolve[*fakeMailer](di, "primary"); mailer.name != "fake" {
+			t.Fatalf("expected the cached instance to be replaced, got %q", mailer.name)
+		}
+	})
+
+	if mailer, _ := dino.Resolve[*fakeMailer](di, "primary"); mailer != original {
+		t.Fatalf("expected the original cached instance to be back, got %+v", mailer)
+	}
+}
+
+func TestOverrideT_ParallelScopes(t *testing.T) {
+	t.Parallel()
+
+	di := dino.New()
+
+	if err := di.Factory(func() OverrideMailer { return smtpMailer{} }); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	t.Run("group", func(t *testing.T) {
+		for _, name := range []string{"first", "second", "third"} {
+			t.Run(name, func(t *testing.T) {
+				t.Parallel()
+
+				scope := di.Scope()
+				dino.OverrideT[OverrideMailer](t, scope, fakeMailer{name: name})
+
+				if got := sendWith(t, scope); got != name+":bob" {
+					t.Fatalf("expected the mailer of the subtest, got %q", got)
+				}
+			})
+		}
+	})
+
+	if got := sendWith(t, di); got != "smtp:bob" {
+		t.Fatalf("expected the container to be left alone, got %q", got)
+	}
+}
+
+func TestOverrideFactoryT(t *testing.T) {
+	t.Parallel()
+
+	di := dino.New()
+
+	if err := di.Factory(func() OverrideMailer { return smtpMailer{} }); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	t.Run("fake", func(t *testing.T) {
+		dino.OverrideFactoryT(t, di, func() OverrideMailer { return fakeMailer{name: "constructed"} })
+
+		if got := sendWith(t, di); got != "constructed:bob" {
+			t.Fatalf("expected the fake constructor to be called, got %q", got)
+		}
+	})
+
+	if got := sendWith(t, di); got != "smtp:bob" {
+		t.Fatalf("expected the original factory to be back, got %q", got)
+	}
+}
+
+func TestOverrideT_Invalid(t *testing.T) {
+	t.Parallel()
+
+	di := dino.New()
+
+	if err := di.Factory(func() OverrideMailer { return smtpMailer{} }); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		override func(tb testing.TB)
+	}{
+		{
+			name:     "nil value",
+			override: func(tb testing.TB) { dino.OverrideT[OverrideMailer](tb, di, nil) },
+		},
+		{
+			name:     "nil factory",
+			override: func(tb testing.TB) { dino.OverrideFactoryT(tb, di, nil) },
+		},
+		{
+			name:     "not a function",
+			override: func(tb testing.TB) { dino.OverrideFactoryT(tb, di, smtpMailer{}) },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tb := &fatalTB{TB: t, failures: nil, cleanups: nil}
+
+			tt.override(tb)
+
+			if len(tb.failures) != 1 || len(tb.cleanups) != 0 {
+				t.Fatalf("expected the test to fail without a cleanup, got %v", tb.failures)
+			}
+
+			if got := sendWith(t, di); got != "smtp:bob" {
+				t.Fatalf("expected the binding to be kept, got %q", got)
+			}
+		})
+	}
+}
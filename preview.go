@@ -0,0 +1,37 @@
+package dino
+
+import "fmt"
+
+// Preview predicts the outcome of injecting the target without setting any of its fields and without
+// calling any factory function: it walks the injection like Inject does, as a dry run that only consults
+// the registry and the auto-creation rules. Fields that an injection would resolve by calling a factory
+// are reported with that factory, e.g. as "would invoke factory func(*app.Config) *app.Database", which
+// makes Preview safe to run against production wiring whose factories have side effects. Failures an
+// injection would run into, e.g. an ambiguous binding or a missing environment variable, are returned
+// with the fields predicted so far. Since no factory is called, failures of factories themselves and of
+// their own dependencies are not predicted.
+func (d *Dino) Preview(target any) (Report, error) {
+	report := &reportRecorder{
+		fields:    nil,
+		prefix:    nil,
+		predicted: true,
+	}
+
+	rv, err := targetValue(target)
+	if err != nil {
+		return Report{Fields: nil}, fmt.Errorf("failed to preview injection: %w", err)
+	}
+
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	injector := newInjector(d.registry, d.options)
+	injector.report = report
+	injector.dryRun = true
+
+	if err := injector.injectTarget(rv); err != nil {
+		return Report{Fields: report.fields}, fmt.Errorf("failed to preview injection: %w", err)
+	}
+
+	return Report{Fields: report.fields}, nil
+}
@@ -0,0 +1,133 @@
+package dino_test
+
+import (
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/yuppyweb/dino"
+)
+
+func TestDino_Preview(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	di := dino.New()
+
+	if err := di.Factory(func() *ReportDB {
+		calls.Add(1)
+
+		return &ReportDB{DSN: "postgres"}
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	target := ReportTarget{Clock: &ReportClock{Zone: "UTC"}, name: "preview"}
+
+	report, err := di.Preview(&target)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := `DB *dino_test.ReportDB: resolved *dino_test.ReportDB, would invoke factory func() *dino_test.ReportDB
+Repo *dino_test.ReportRepo: auto-created
+Repo.DB *dino_test.ReportDB: resolved *dino_test.ReportDB, would invoke factory func() *dino_test.ReportDB
+Clock *dino_test.ReportClock: preserved
+Clock.Zone string: preserved
+Cache *dino_test.ReportCache: missing
+Ignored *dino_test.ReportDB: skipped
+name string: skipped
+`
+
+	if got := report.String(); got != expected {
+		t.Errorf("unexpected report:\n%s\nexpected:\n%s", got, expected)
+	}
+
+	// Nothing was set and no factory was called
+	if calls.Load() != 0 {
+		t.Errorf("expected the factory not to be called, got %d calls", calls.Load())
+	}
+
+	if target.DB != nil || target.Repo != nil || target.Clock.Zone != "UTC" {
+		t.Errorf("expected the target to be untouched, got %+v", target)
+	}
+
+	field, ok := report.Field("DB")
+	if !ok || field.Factory == nil || !field.Predicted {
+		t.Errorf("expected DB to be predicted as factory-backed, got %+v", field)
+	}
+}
+
+func TestDino_PreviewError(t *testing.T) {
+	t.Parallel()
+
+	di := dino.New()
+
+	for range 2 {
+		if err := di.AppendValue(&ReportDB{DSN: "replica"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	var target ReportTarget
+
+	report, err := di.Preview(&target)
+	if !errors.Is(err, dino.ErrAmbiguousBinding) {
+		t.Fatalf("expected ErrAmbiguousBinding, got %v", err)
+	}
+
+	if len(report.Fields) != 0 {
+		t.Errorf("expected no field before the failure, got:\n%s", report)
+	}
+
+	if _, err := di.Preview(target); !errors.Is(err, dino.ErrExpectedPointerToStruct) {
+		t.Errorf("expected ErrExpectedPointerToStruct, got %v", err)
+	}
+}
+
+func TestDino_PreviewDryRun(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	di := dino.New(dino.WithStats())
+
+	if err := di.Append(func() *ReportDB {
+		calls.Add(1)
+
+		return &ReportDB{DSN: "replica"}
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	report, err := di.Preview(&ReportTarget{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The only appended binding is predicted like a registered factory
+	field, ok := report.Field("DB")
+	if !ok || field.Outcome != dino.FieldResolved || field.Factory == nil {
+		t.Errorf("expected DB to be predicted as factory-backed, got %+v", field)
+	}
+
+	if calls.Load() != 0 {
+		t.Errorf("expected the appended factory not to be called, got %d calls", calls.Load())
+	}
+
+	// A dry run is not a resolution: it leaves the counters alone
+	if stats := di.Stats(); len(stats.Keys) != 0 {
+		t.Errorf("expected no key stats, got %v", stats.Keys)
+	}
+
+	_, err = di.Preview(42)
+	if !errors.Is(err, dino.ErrExpectedStruct) {
+		t.Fatalf("expected ErrExpectedStruct, got %v", err)
+	}
+
+	if !strings.Contains(err.Error(), "failed to preview injection:") {
+		t.Errorf("expected error message to contain 'failed to preview injection:', got %s", err.Error())
+	}
+}
@@ -0,0 +1,139 @@
+package dino
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// typedFactory calls a factory function registered with Provide without reflection.
+type typedFactory interface {
+	call() (reflect.Value, error)
+}
+
+// providerFunc is the factory function of type T registered with Provide.
+type providerFunc[T any] func() (T, error)
+
+// call calls the provider and returns its result as a value of type T, even if T is an interface.
+func (p providerFunc[T]) call() (reflect.Value, error) {
+	val, err := p()
+	if err != nil {
+		return reflect.Value{}, err
+	}
+
+	return reflect.ValueOf(&val).Elem(), nil
+}
+
+// Provide registers a provider function for type T like Factory. The provider is also kept as a typed
+// function: resolutions of T, through Resolve, Inject or Invoke, call it directly instead of through
+// reflection, and cache its result like any factory result.
+func Provide[T any](d *Dino, provider func() (T, error), tags ...string) error {
+	return d.factory(provider, providerFunc[T](provider), Tags(tags...))
+}
+
+// Resolve returns the dependency registered for type T like Dino.Resolve, without the type assertion.
+func Resolve[T any](d *Dino, tags ...string) (T, error) {
+	var zero T
+
+	val, err := d.Resolve(reflect.TypeFor[T](), tags...)
+	if err != nil {
+		return zero, err
+	}
+
+	// A nil interface value converts to the zero T
+	typed, _ := val.(T)
+
+	return typed, nil
+}
+
+// ResolveTagged resolves every dependency of type T registered under a tag starting with the prefix and
+// returns them by the rest of their tag, e.g. ResolveTagged[Driver](d, "driver:") returns the drivers
+// tagged "driver:postgres" and "driver:mysql" as "postgres" and "mysql". An empty map is returned
+// if nothing matches; the first failing resolution is returned as an error.
+func ResolveTagged[T any](d *Dino, prefix string) (map[string]T, error) {
+	d.mutex.RLock()
+	keys := FindByTagPrefix(d.registry, reflect.TypeFor[T](), prefix)
+	d.mutex.RUnlock()
+
+	resolved := make(map[string]T, len(keys))
+
+	for _, key := range keys {
+		suffix := strings.TrimPrefix(key.Tag, prefix)
+
+		// Keys of several scopes share a tag
+		if _, ok := resolved[suffix]; ok {
+			continue
+		}
+
+		val, err := Resolve[T](d, key.Tag)
+		if err != nil {
+			return nil, fmt.Errorf("resolve tag %q: %w", key.Tag, err)
+		}
+
+		resolved[suffix] = val
+	}
+
+	return resolved, nil
+}
+
+// setTypedFactory records the typed factory registered under the keys. It is forgotten by
+// setFactory once another factory or a singleton is registered for them.
+func (o *options) setTypedFactory(keys []RegistryKey, factory typedFactory) {
+	for _, key := range keys {
+		o.typedFactories.Store(key, factory)
+	}
+}
+
+// typedFactory returns the typed factory registered for the key, if any.
+func (o *options) typedFactory(key RegistryKey) (typedFactory, bool) {
+	value, ok := o.typedFactories.Load(key)
+	if !ok {
+		if o.parent != nil {
+			return o.parent.typedFactory(key)
+		}
+
+		return nil, false
+	}
+
+	factory, ok := value.(typedFactory)
+
+	return factory, ok
+}
+
+// callTyped calls the typed factory registered for the key and binds its result to the registry
+// for future resolutions, like call does for factory functions.
+func (i *Injector) callTyped(key RegistryKey, factory typedFactory) (reflect.Value, error) {
+	resVal := reflect.Zero(key.Type)
+
+	val, err := factory.call()
+	if err != nil {
+		return resVal, fmt.Errorf(
+			"%w: factory for %s tag %q: %w",
+			ErrFactoryFailed,
+			key.Type,
+			key.Tag,
+			err,
+		)
+	}
+
+	// Nil results are not cached
+	if isNil(val) {
+		return resVal, nil
+	}
+
+	if err := i.cache(RegistryKey{
+		Tag:       key.Tag,
+		Type:      key.Type,
+		Scope:     "",
+		Qualifier: key.Qualifier,
+	}, val); err != nil {
+		return resVal, fmt.Errorf(
+			"bind factory function return value of type %s with tag '%s': %w",
+			key.Type,
+			key.Tag,
+			err,
+		)
+	}
+
+	return val, nil
+}
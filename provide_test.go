@@ -0,0 +1,332 @@
+package dino_test
+
+import (
+	"errors"
+	"reflect"
+	"sync/atomic"
+	"testing"
+
+	"github.com/yuppyweb/dino"
+)
+
+var errProvideFailed = errors.New("provide failed")
+
+type ProvidedClock struct {
+	Zone string
+}
+
+type ProvidedHandler struct {
+	Clock *ProvidedClock
+}
+
+type ProvidedNamer interface {
+	Name() string
+}
+
+type providedName string
+
+func (n providedName) Name() string {
+	return string(n)
+}
+
+func TestProvide(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	di := dino.New()
+
+	if err := dino.Provide(di, func() (*ProvidedClock, error) {
+		calls.Add(1)
+
+		return &ProvidedClock{Zone: "UTC"}, nil
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	clock, err := dino.Resolve[*ProvidedClock](di)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if clock == nil || clock.Zone != "UTC" {
+		t.Fatalf("expected the provided clock, got %+v", clock)
+	}
+
+	// The same instance is visible through the reflection based paths
+	resolved, err := di.Resolve(reflect.TypeFor[*ProvidedClock]())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if resolved != clock {
+		t.Fatalf("expected Resolve to return the provided instance, got %v", resolved)
+	}
+
+	handler := new(ProvidedHandler)
+	if err := di.Inject(handler); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if handler.Clock != clock {
+		t.Fatalf("expected Inject to set the provided instance, got %v", handler.Clock)
+	}
+
+	if got := calls.Load(); got != 1 {
+		t.Fatalf("expected the provider to be called once, got %d calls", got)
+	}
+}
+
+func TestProvide_ResolvedByInjectFirst(t *testing.T) {
+	t.Parallel()
+
+	di := dino.New()
+
+	if err := dino.Provide(di, func() (*ProvidedClock, error) {
+		return &ProvidedClock{Zone: "CET"}, nil
+	}, "local"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var injected *ProvidedClock
+
+	if _, err := di.Invoke(func(in struct {
+		dino.In
+
+		Clock *ProvidedClock `inject:"local"`
+	},
+	) {
+		injected = in.Clock
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	clock, err := dino.Resolve[*ProvidedClock](di, "local")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if injected == nil || clock != injected {
+		t.Fatalf("expected the injected instance to be cached, got %v and %v", injected, clock)
+	}
+}
+
+func TestProvide_Interface(t *testing.T) {
+	t.Parallel()
+
+	di := dino.New()
+
+	if err := dino.Provide(di, func() (ProvidedNamer, error) {
+		return providedName("dino"), nil
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var name string
+
+	if _, err := di.Invoke(func(namer ProvidedNamer) {
+		name = namer.Name()
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if name != "dino" {
+		t.Fatalf("expected the provided name, got %q", name)
+	}
+}
+
+func TestProvide_Error(t *testing.T) {
+	t.Parallel()
+
+	di := dino.New()
+
+	if err := dino.Provide(di, func() (*ProvidedClock, error) {
+		return nil, errProvideFailed
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	clock, err := dino.Resolve[*ProvidedClock](di)
+	if !errors.Is(err, errProvideFailed) {
+		t.Fatalf("expected the provider error, got %v", err)
+	}
+
+	if clock != nil {
+		t.Fatalf("expected no clock, got %+v", clock)
+	}
+}
+
+func TestProvide_Nil(t *testing.T) {
+	t.Parallel()
+
+	di := dino.New()
+
+	if err := dino.Provide[*ProvidedClock](di, nil); !errors.Is(err, dino.ErrInvalidInputValue) {
+		t.Fatalf("expected ErrInvalidInputValue, got %v", err)
+	}
+}
+
+func TestProvide_ReplacedByFactory(t *testing.T) {
+	t.Parallel()
+
+	di := dino.New()
+
+	if err := dino.Provide(di, func() (*ProvidedClock, error) {
+		return &ProvidedClock{Zone: "provided"}, nil
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := di.Factory(func() *ProvidedClock {
+		return &ProvidedClock{Zone: "factory"}
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	clock, err := dino.Resolve[*ProvidedClock](di)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if clock.Zone != "factory" {
+		t.Fatalf("expected the later factory to be called, got %q", clock.Zone)
+	}
+}
+
+func TestProvide_RestoredBySnapshot(t *testing.T) {
+	t.Parallel()
+
+	di := dino.New()
+
+	if err := di.Factory(func() *ProvidedClock {
+		return &ProvidedClock{Zone: "factory"}
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	snapshot := di.Snapshot()
+
+	if err := dino.Provide(di, func() (*ProvidedClock, error) {
+		return &ProvidedClock{Zone: "provided"}, nil
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := di.Restore(snapshot); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	clock, err := dino.Resolve[*ProvidedClock](di)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if clock.Zone != "factory" {
+		t.Fatalf("expected the restored factory to be called, got %q", clock.Zone)
+	}
+}
+
+func TestResolve_NotFound(t *testing.T) {
+	t.Parallel()
+
+	di := dino.New()
+
+	if _, err := dino.Resolve[*ProvidedClock](di); !errors.Is(err, dino.ErrValueNotFound) {
+		t.Fatalf("expected ErrValueNotFound, got %v", err)
+	}
+}
+
+func BenchmarkResolveProvided(b *testing.B) {
+	di := dino.New()
+
+	if err := dino.Provide(di, func() (*ProvidedClock, error) {
+		return &ProvidedClock{Zone: "UTC"}, nil
+	}); err != nil {
+		b.Fatalf("unexpected error: %v", err)
+	}
+
+	b.ReportAllocs()
+
+	// Every scope calls the provider again, without reflect.Call
+	for b.Loop() {
+		if _, err := dino.Resolve[*ProvidedClock](di.Scope()); err != nil {
+			b.Fatalf("unexpected error: %v", err)
+		}
+	}
+}
+
+func BenchmarkResolveFactory(b *testing.B) {
+	di := dino.New()
+
+	if err := di.Factory(func() (*ProvidedClock, error) {
+		return &ProvidedClock{Zone: "UTC"}, nil
+	}); err != nil {
+		b.Fatalf("unexpected error: %v", err)
+	}
+
+	b.ReportAllocs()
+
+	for b.Loop() {
+		if _, err := dino.Resolve[*ProvidedClock](di.Scope()); err != nil {
+			b.Fatalf("unexpected error: %v", err)
+		}
+	}
+}
+
+type TaggedDriver struct {
+	Name string
+}
+
+func TestResolveTagged(t *testing.T) {
+	t.Parallel()
+
+	di := dino.New()
+
+	for _, name := range []string{"postgres", "mysql"} {
+		if err := di.Singleton(&TaggedDriver{Name: name}, "driver:"+name); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if err := di.Factory(func() *TaggedDriver { return &TaggedDriver{Name: "sqlite"} }, "driver:sqlite"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := di.Singleton(&TaggedDriver{Name: "cache"}, "cache:redis"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	drivers, err := dino.ResolveTagged[*TaggedDriver](di, "driver:")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	names := make(map[string]string, len(drivers))
+	for suffix, driver := range drivers {
+		names[suffix] = driver.Name
+	}
+
+	expected := map[string]string{"postgres": "postgres", "mysql": "mysql", "sqlite": "sqlite"}
+	if !reflect.DeepEqual(names, expected) {
+		t.Fatalf("expected %v, got %v", expected, names)
+	}
+
+	// The prefix is never a fallback for exact tags
+	if _, err := dino.Resolve[*TaggedDriver](di, "driver:"); !errors.Is(err, dino.ErrValueNotFound) {
+		t.Fatalf("expected ErrValueNotFound, got %v", err)
+	}
+}
+
+func TestResolveTagged_FactoryError(t *testing.T) {
+	t.Parallel()
+
+	di := dino.New()
+
+	if err := di.Factory(func() (*TaggedDriver, error) { return nil, errProvideFailed }, "driver:broken"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := dino.ResolveTagged[*TaggedDriver](di, "driver:"); !errors.Is(err, errProvideFailed) {
+		t.Fatalf("expected the factory error, got %v", err)
+	}
+}
@@ -0,0 +1,87 @@
+package dino
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// ErrUnknownQualifier is returned when an "inject" struct tag names a qualifier that was not registered
+// with WithQualifierName.
+var ErrUnknownQualifier = errors.New("unknown qualifier")
+
+// Qualify registers the factory or singleton under the qualifier in addition to its tags. Unlike tags,
+// qualifiers are values of any comparable type, so a package can qualify its dependencies with an
+// unexported type that cannot collide with the qualifiers of other packages, e.g.
+//
+//	type primary struct{}
+//	di.FactoryWith(NewDB, dino.Qualify(primary{}))
+//
+// Non-comparable qualifiers, such as slices or maps, cannot be part of a registry key and are rejected
+// with ErrInvalidInputValue at registration.
+func Qualify(qualifier any) RegisterOption {
+	return func(o *registerOptions) {
+		o.qualifier = qualifier
+	}
+}
+
+// WithQualifierName makes the qualifier available to struct fields under the name, since struct tags
+// can only carry strings: a field tagged `inject:"q:mypkg.Primary"` is resolved with the qualifier
+// registered as "mypkg.Primary". Fields naming an unregistered qualifier fail with ErrUnknownQualifier.
+func WithQualifierName(name string, qualifier any) Option {
+	return func(o *options) {
+		if o.qualifiers == nil {
+			o.qualifiers = make(map[string]any)
+		}
+
+		o.qualifiers[name] = qualifier
+	}
+}
+
+// Qualified returns the untagged dependency of type T registered with the qualifier.
+// Like Resolve, a missing dependency is reported as ErrValueNotFound instead of being created.
+func Qualified[T any](d *Dino, qualifier any) (T, error) {
+	var zero T
+
+	if err := validateQualifier(qualifier); err != nil {
+		return zero, fmt.Errorf("failed to resolve dependency: %w", err)
+	}
+
+	val, err := d.resolveKey(RegistryKey{
+		Tag:       "",
+		Type:      reflect.TypeFor[T](),
+		Scope:     "",
+		Qualifier: qualifier,
+	})
+	if err != nil {
+		return zero, err
+	}
+
+	// A nil interface value converts to the zero T
+	typed, _ := val.(T)
+
+	return typed, nil
+}
+
+// validateQualifier returns ErrInvalidInputValue if the qualifier cannot be used in a registry key.
+func validateQualifier(qualifier any) error {
+	if qualifier == nil || reflect.ValueOf(qualifier).Comparable() {
+		return nil
+	}
+
+	return fmt.Errorf("%w: qualifier of type %T is not comparable", ErrInvalidInputValue, qualifier)
+}
+
+// qualifierNamed returns the qualifier registered under the name.
+func (o *options) qualifierNamed(name string) (any, error) {
+	qualifier, ok := o.qualifiers[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownQualifier, name)
+	}
+
+	if err := validateQualifier(qualifier); err != nil {
+		return nil, err
+	}
+
+	return qualifier, nil
+}
@@ -0,0 +1,206 @@
+package dino_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/yuppyweb/dino"
+)
+
+// Both qualifiers are "primary" as strings, but their types tell them apart.
+type (
+	dbQualifier    string
+	cacheQualifier string
+)
+
+const (
+	primaryDB    dbQualifier    = "primary"
+	primaryCache cacheQualifier = "primary"
+)
+
+type QualifiedConn struct {
+	Addr string
+}
+
+type QualifiedService struct {
+	DB    *QualifiedConn `inject:"q:db.Primary"`
+	Cache *QualifiedConn `inject:"q:cache.Primary"`
+}
+
+func TestQualified(t *testing.T) {
+	t.Parallel()
+
+	di := dino.New()
+
+	if err := di.FactoryWith(func() *QualifiedConn {
+		return &QualifiedConn{Addr: "postgres:5432"}
+	}, dino.Qualify(primaryDB)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := di.SingletonWith(&QualifiedConn{Addr: "redis:6379"}, dino.Qualify(primaryCache)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	db, err := dino.Qualified[*QualifiedConn](di, primaryDB)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if db.Addr != "postgres:5432" {
+		t.Errorf("expected the database connection, got %q", db.Addr)
+	}
+
+	cache, err := dino.Qualified[*QualifiedConn](di, primaryCache)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cache.Addr != "redis:6379" {
+		t.Errorf("expected the cache connection, got %q", cache.Addr)
+	}
+
+	// Neither the string qualifier nor the empty one match
+	if _, err := dino.Qualified[*QualifiedConn](di, "primary"); !errors.Is(err, dino.ErrValueNotFound) {
+		t.Errorf("expected ErrValueNotFound for a string qualifier, got %v", err)
+	}
+
+	if _, err := dino.Resolve[*QualifiedConn](di); !errors.Is(err, dino.ErrValueNotFound) {
+		t.Errorf("expected ErrValueNotFound without qualifier, got %v", err)
+	}
+}
+
+func TestQualified_StructTag(t *testing.T) {
+	t.Parallel()
+
+	di := dino.New(
+		dino.WithQualifierName("db.Primary", primaryDB),
+		dino.WithQualifierName("cache.Primary", primaryCache),
+	)
+
+	if err := di.SingletonWith(&QualifiedConn{Addr: "postgres:5432"}, dino.Qualify(primaryDB)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := di.SingletonWith(&QualifiedConn{Addr: "redis:6379"}, dino.Qualify(primaryCache)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var svc QualifiedService
+
+	if err := di.Inject(&svc); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if svc.DB == nil || svc.DB.Addr != "postgres:5432" {
+		t.Errorf("expected the database connection, got %+v", svc.DB)
+	}
+
+	if svc.Cache == nil || svc.Cache.Addr != "redis:6379" {
+		t.Errorf("expected the cache connection, got %+v", svc.Cache)
+	}
+}
+
+type QualifiedProviderService struct {
+	DB func() *QualifiedConn `inject:"q:db.Primary"`
+}
+
+func TestQualified_Provider(t *testing.T) {
+	t.Parallel()
+
+	di := dino.New(dino.WithQualifierName("db.Primary", primaryDB))
+
+	if err := di.Singleton(&QualifiedConn{Addr: "default:5432"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := di.FactoryWith(func() *QualifiedConn {
+		return &QualifiedConn{Addr: "postgres:5432"}
+	}, dino.Qualify(primaryDB)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var svc QualifiedProviderService
+
+	if err := di.Inject(&svc); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if svc.DB == nil {
+		t.Fatal("expected a lazy provider")
+	}
+
+	if db := svc.DB(); db == nil || db.Addr != "postgres:5432" {
+		t.Errorf("expected the provider to resolve the qualified connection, got %+v", db)
+	}
+}
+
+func TestQualified_UnknownName(t *testing.T) {
+	t.Parallel()
+
+	di := dino.New(dino.WithQualifierName("db.Primary", primaryDB))
+
+	var svc QualifiedService
+
+	err := di.Inject(&svc)
+	if !errors.Is(err, dino.ErrUnknownQualifier) {
+		t.Fatalf("expected ErrUnknownQualifier, got %v", err)
+	}
+}
+
+func TestQualify_Invalid(t *testing.T) {
+	t.Parallel()
+
+	di := dino.New()
+
+	testCases := []struct {
+		name string
+		fn   func() error
+	}{
+		{
+			name: "Factory with non-comparable qualifier",
+			fn: func() error {
+				return di.FactoryWith(func() *QualifiedConn {
+					return &QualifiedConn{}
+				}, dino.Qualify([]string{"primary"}))
+			},
+		},
+		{
+			name: "Singleton with non-comparable qualifier",
+			fn: func() error {
+				return di.SingletonWith(&QualifiedConn{}, dino.Qualify(map[string]int{}))
+			},
+		},
+		{
+			name: "Singleton with TTL",
+			fn: func() error {
+				return di.SingletonWith(&QualifiedConn{}, dino.TTL(time.Minute))
+			},
+		},
+		{
+			name: "Singleton per scope",
+			fn: func() error {
+				return di.SingletonWith(&QualifiedConn{}, dino.Scoped())
+			},
+		},
+		{
+			name: "Resolution with non-comparable qualifier",
+			fn: func() error {
+				_, err := dino.Qualified[*QualifiedConn](di, []int{1})
+
+				return err
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if err := tc.fn(); !errors.Is(err, dino.ErrInvalidInputValue) {
+				t.Errorf("expected ErrInvalidInputValue, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package dino
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// ErrNoFactory is returned by Refresh for a key that is not backed by a factory, e.g. a singleton.
+var ErrNoFactory = errors.New("no factory registered")
+
+// setFactory records the factory function registered under the keys, or forgets it for
+// an invalid function, e.g. when a singleton replaces the factory.
+func (o *options) setFactory(keys []RegistryKey, fn reflect.Value) {
+	for _, key := range keys {
+		if fn.IsValid() {
+			o.factories.Store(key, fn)
+		} else {
+			o.factories.Delete(key)
+		}
+
+		// A typed factory belongs to the registration it was provided with
+		o.typedFactories.Delete(key)
+	}
+}
+
+// factory returns the factory function registered for the key, if any.
+func (o *options) factory(key RegistryKey) (reflect.Value, bool) {
+	value, ok := o.factories.Load(key)
+	if !ok {
+		if o.parent != nil {
+			return o.parent.factory(key)
+		}
+
+		return reflect.Value{}, false
+	}
+
+	fn, ok := value.(reflect.Value)
+
+	return fn, ok
+}
+
+// Refresh calls the factory registered for the type again, with freshly resolved dependencies, and
+// replaces the cached instance with its result, e.g. to rebuild a connection pool after a configuration
+// reload. Only the first tag is used. The other results of a factory returning several values, or a result
+// object, are replaced as well. Once the new instances are cached, the OnReplace subscriptions of their
+// types are notified, then the old instances are no longer tracked by the container and are closed like
+// by Shutdown if they implement io.Closer, Shutdowner or ContextShutdowner. Consumers that already hold
+// an old instance keep it; only later resolutions receive the new one, and Inject keeps struct fields
+// that already hold the old instance. Keys not backed by a factory, e.g. singletons, are reported
+// as ErrNoFactory.
+func (d *Dino) Refresh(rt reflect.Type, tags ...string) error {
+	if rt == nil {
+		return fmt.Errorf("%w: type to refresh cannot be nil", ErrInvalidInputValue)
+	}
+
+	key := RegistryKey{
+		Tag:       "",
+		Type:      rt,
+		Scope:     "",
+		Qualifier: nil,
+	}
+
+	if len(tags) > 0 {
+		key.Tag = tags[0]
+	}
+
+	replacements, err := d.refresh(key)
+	if err != nil {
+		return fmt.Errorf("failed to refresh dependency %s: %w", keyName(key), err)
+	}
+
+	d.notifyReplaced(replacements)
+
+	var errs []error
+
+	for _, repl := range replacements {
+		replaced := &instance{
+			key:     repl.key,
+			value:   repl.old,
+			hook:    nil,
+			started: false,
+		}
+
+		if err := closeInstance(context.Background(), replaced); err != nil {
+			errs = append(errs, fmt.Errorf("failed to close replaced dependency %s: %w", keyName(repl.key), err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// refresh replaces the instances cached for the results of the factory registered for the key with new
+// results of the factory, and returns the cached instances it replaced. Results that were not cached yet
+// replace nothing.
+func (d *Dino) refresh(key RegistryKey) ([]replacement, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	fn, ok := d.options.factory(key)
+	if !ok {
+		return nil, ErrNoFactory
+	}
+
+	keys := bindingKeys(factoryBindings(fn.Type(), []string{key.Tag}))
+	olds := make([]reflect.Value, len(keys))
+
+	for idx := range keys {
+		keys[idx].Qualifier = key.Qualifier
+
+		if rv, err := d.registry.Find(keys[idx]); err == nil && !isFactory(keys[idx], rv) {
+			olds[idx] = rv
+		}
+	}
+
+	injector := newInjector(d.registry, d.options)
+
+	injector.enter(key, StepFactory)
+	defer injector.leave()
+
+	d.options.stats.factoryCall(key)
+
+	start := d.options.stats.start()
+
+	_, err := injector.call(key, fn)
+
+	d.options.stats.factoryDone(key, start, err)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var replacements []replacement
+
+	for idx, old := range olds {
+		current, err := d.registry.Find(keys[idx])
+
+		// A factory returning the instance it returned before, or nil, replaces nothing
+		if !old.IsValid() || err != nil || sameValue(old, current) {
+			continue
+		}
+
+		d.options.lifecycle.untrack(old)
+
+		replacements = append(replacements, replacement{
+			key:   keys[idx],
+			old:   old,
+			value: current,
+		})
+	}
+
+	return replacements, nil
+}
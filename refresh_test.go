@@ -0,0 +1,221 @@
+package dino_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/yuppyweb/dino"
+)
+
+type ConnectionPool struct {
+	dsn    string
+	closed bool
+}
+
+func (p *ConnectionPool) Close() error {
+	p.closed = true
+
+	return nil
+}
+
+type PoolConsumer struct {
+	Pool *ConnectionPool
+}
+
+func newPoolContainer(t *testing.T) (*dino.Dino, *int) {
+	t.Helper()
+
+	di := dino.New()
+	version := 0
+
+	if err := di.Singleton("postgres://primary"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := di.Factory(func(dsn string) *ConnectionPool {
+		version++
+
+		return &ConnectionPool{dsn: dsn}
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	return di, &version
+}
+
+func TestDino_Refresh(t *testing.T) {
+	di, version := newPoolContainer(t)
+
+	var before PoolConsumer
+	if err := di.Inject(&before); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// A configuration reload replaces the dependency of the factory
+	if err := di.Singleton("postgres://replica"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := di.Refresh(reflect.TypeFor[*ConnectionPool]()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var after PoolConsumer
+	if err := di.Inject(&after); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if *version != 2 {
+		t.Errorf("expected the factory to be called twice, got %d", *version)
+	}
+
+	if after.Pool == before.Pool {
+		t.Fatal("expected Inject to receive the new instance after Refresh")
+	}
+
+	if after.Pool.dsn != "postgres://replica" {
+		t.Errorf("expected the new instance to use the fresh dependency, got %q", after.Pool.dsn)
+	}
+
+	if !before.Pool.closed {
+		t.Error("expected the replaced instance to be closed")
+	}
+
+	if err := di.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !after.Pool.closed {
+		t.Error("expected Close to close the new instance")
+	}
+}
+
+func TestDino_RefreshNotResolved(t *testing.T) {
+	di, version := newPoolContainer(t)
+
+	if err := di.Refresh(reflect.TypeFor[*ConnectionPool]()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if *version != 1 {
+		t.Errorf("expected the factory to be called once, got %d", *version)
+	}
+
+	if _, err := di.Resolve(reflect.TypeFor[*ConnectionPool]()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if *version != 1 {
+		t.Errorf("expected the refreshed instance to be cached, got %d factory calls", *version)
+	}
+}
+
+func TestDino_RefreshSingleton(t *testing.T) {
+	di := dino.New()
+
+	if err := di.Singleton(&ConnectionPool{dsn: "postgres://primary", closed: false}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err := di.Refresh(reflect.TypeFor[*ConnectionPool]())
+	if !errors.Is(err, dino.ErrNoFactory) {
+		t.Fatalf("expected ErrNoFactory, got %v", err)
+	}
+}
+
+func TestDino_RefreshFactoryError(t *testing.T) {
+	di, _ := newPoolContainer(t)
+
+	pool, err := di.Resolve(reflect.TypeFor[*ConnectionPool]())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := di.Factory(func() (*ConnectionPool, error) {
+		return nil, errHookFailed
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := di.Refresh(reflect.TypeFor[*ConnectionPool]()); !errors.Is(err, errHookFailed) {
+		t.Fatalf("expected factory error, got %v", err)
+	}
+
+	if old, ok := pool.(*ConnectionPool); !ok || old.closed {
+		t.Error("expected the cached instance to stay open when the factory fails")
+	}
+}
+
+func TestDino_RefreshRestored(t *testing.T) {
+	di, version := newPoolContainer(t)
+
+	snapshot := di.Snapshot()
+
+	if err := di.Factory(func() *ConnectionPool {
+		return &ConnectionPool{dsn: "postgres://replaced"}
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := di.Restore(snapshot); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := di.Refresh(reflect.TypeFor[*ConnectionPool]()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	pool, err := dino.Resolve[*ConnectionPool](di)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if *version != 1 || pool.dsn != "postgres://primary" {
+		t.Errorf("expected Refresh to call the restored factory, got %q after %d calls", pool.dsn, *version)
+	}
+}
+
+func TestDino_RefreshMultipleResults(t *testing.T) {
+	di := dino.New()
+
+	if err := di.Factory(func() (*ConnectionPool, *PoolConsumer) {
+		pool := &ConnectionPool{dsn: "postgres://primary"}
+
+		return pool, &PoolConsumer{Pool: pool}
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var replaced []any
+
+	di.OnReplace(reflect.TypeFor[*PoolConsumer](), func(oldValue, _ any) {
+		replaced = append(replaced, oldValue)
+	})
+
+	before, err := dino.Resolve[*PoolConsumer](di)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := di.Refresh(reflect.TypeFor[*ConnectionPool]()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	after, err := dino.Resolve[*PoolConsumer](di)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if after == before || after.Pool == before.Pool {
+		t.Fatal("expected the other result of the factory to be replaced as well")
+	}
+
+	if len(replaced) != 1 || replaced[0] != before {
+		t.Errorf("expected the replaced result to be reported to its subscriptions, got %v", replaced)
+	}
+
+	if !before.Pool.closed {
+		t.Error("expected the replaced instance to be closed")
+	}
+}
@@ -1,8 +1,13 @@
 package dino
 
 import (
+	"cmp"
 	"errors"
+	"fmt"
+	"iter"
 	"reflect"
+	"slices"
+	"strings"
 	"sync"
 )
 
@@ -10,38 +15,101 @@ var (
 	ErrKeyTypeNil    = errors.New("registry key type cannot be nil")
 	ErrValueNotFound = errors.New("value not found in registry")
 	ErrInvalidValue  = errors.New("registry invalid value")
+	ErrTypeMismatch  = errors.New("registry value type mismatch")
+	ErrObserverPanic = errors.New("registry observer panicked")
+	ErrStoredFactory = errors.New("registry holds a factory instead of a value")
 )
 
 // Registry defines the interface for a dependency registry.
 type Registry interface {
 	Register(key RegistryKey, rv reflect.Value) error
+	RegisterIfAbsent(key RegistryKey, rv reflect.Value) (reflect.Value, bool, error)
 	Find(key RegistryKey) (reflect.Value, error)
+	FindByType(rt reflect.Type) []RegistryKey
+	FindAssignableTo(rt reflect.Type) []RegistryKey
+	Delete(key RegistryKey) error
+	DeleteAll(rt reflect.Type) error
+	All() iter.Seq2[RegistryKey, reflect.Value]
 }
 
-// RegistryKey represents a unique key for a dependency in the registry, consisting of a tag and a type.
+// RegistryKey represents a unique key for a dependency in the registry, consisting of a type with a tag
+// and an optional qualifier.
 type RegistryKey struct {
 	Tag  string
 	Type reflect.Type
+	// Scope keeps the entries of scoped containers sharing one registry apart.
+	// The empty scope is the unscoped namespace.
+	Scope string
+	// Qualifier tells registrations of one type apart like Tag, with a comparable value of any type
+	// instead of a string, so that qualifiers of different packages never collide. Nil is unqualified.
+	Qualifier any
 }
 
 // SyncMapRegistry is a thread-safe implementation of the Registry interface using sync.Map.
+// Lookups by key are lock-free; writes are serialized to keep a secondary index of keys by type
+// consistent with the stored values.
 type SyncMapRegistry struct {
-	sm sync.Map
+	sm        sync.Map
+	mutex     sync.RWMutex
+	index     typeIndex
+	observers observers
+	clock     Clock
 }
 
 // Register stores a value in the registry with the specified key.
 func (r *SyncMapRegistry) Register(key RegistryKey, rv reflect.Value) error {
-	if key.Type == nil {
-		return ErrKeyTypeNil
+	if err := validateEntry(key, rv); err != nil {
+		return err
 	}
 
-	if !rv.IsValid() {
-		return ErrInvalidValue
-	}
+	r.store(key, rv)
+	r.observers.notifyRegister(key, rv)
+
+	return nil
+}
+
+// store writes the value under the key and indexes the key.
+func (r *SyncMapRegistry) store(key RegistryKey, rv reflect.Value) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
 
 	r.sm.Store(key, rv)
+	r.index.add(key)
+}
 
-	return nil
+// RegisterIfAbsent stores a value in the registry with the specified key unless a value is already stored.
+// It returns the stored value and true if the key was already present, or rv and false if rv was stored.
+func (r *SyncMapRegistry) RegisterIfAbsent(key RegistryKey, rv reflect.Value) (reflect.Value, bool, error) {
+	if err := validateEntry(key, rv); err != nil {
+		return reflect.Value{}, false, err
+	}
+
+	value, loaded := r.loadOrStore(key, rv)
+	if !loaded {
+		r.observers.notifyRegister(key, rv)
+
+		return rv, false, nil
+	}
+
+	existing, err := r.value(key, value)
+	if err != nil {
+		return reflect.Value{}, true, err
+	}
+
+	return existing, true, nil
+}
+
+// loadOrStore writes the value under the key unless the key is present, indexing it if written.
+func (r *SyncMapRegistry) loadOrStore(key RegistryKey, rv reflect.Value) (any, bool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	value, loaded := r.sm.LoadOrStore(key, rv)
+	if !loaded {
+		r.index.add(key)
+	}
+
+	return value, loaded
 }
 
 // Find looks up a value in the registry based on the specified key.
@@ -55,13 +123,264 @@ func (r *SyncMapRegistry) Find(key RegistryKey) (reflect.Value, error) {
 		return reflect.Zero(key.Type), ErrValueNotFound
 	}
 
-	rv, ok := value.(reflect.Value)
-	if !ok {
-		return reflect.Zero(key.Type), ErrInvalidValue
+	rv, err := r.value(key, value)
+	if err != nil {
+		return reflect.Zero(key.Type), err
 	}
 
 	return rv, nil
 }
 
+// FindByType returns the keys of all values registered for the specified type, sorted by tag.
+func (r *SyncMapRegistry) FindByType(rt reflect.Type) []RegistryKey {
+	if rt == nil {
+		return nil
+	}
+
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	return r.index.byType(rt)
+}
+
+// FindAssignableTo returns the keys of all values registered for a type assignable to rt,
+// e.g. every implementation of an interface, sorted by type name and tag.
+func (r *SyncMapRegistry) FindAssignableTo(rt reflect.Type) []RegistryKey {
+	if rt == nil {
+		return nil
+	}
+
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	return r.index.assignableTo(rt)
+}
+
+// Delete removes the value registered with the specified key.
+func (r *SyncMapRegistry) Delete(key RegistryKey) error {
+	if key.Type == nil {
+		return ErrKeyTypeNil
+	}
+
+	value, ok := r.loadAndDelete(key)
+	if !ok {
+		return ErrValueNotFound
+	}
+
+	r.observers.notifyDelete(key, storedValue(value))
+
+	return nil
+}
+
+// loadAndDelete removes the key and its value, returning the value if the key was present.
+func (r *SyncMapRegistry) loadAndDelete(key RegistryKey) (any, bool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	value, ok := r.sm.LoadAndDelete(key)
+	if ok {
+		r.index.remove(key)
+	}
+
+	return value, ok
+}
+
+// DeleteAll removes the values registered for the specified type under every tag.
+// It returns ErrValueNotFound if nothing was registered for the type.
+func (r *SyncMapRegistry) DeleteAll(rt reflect.Type) error {
+	if rt == nil {
+		return ErrKeyTypeNil
+	}
+
+	deleted := r.deleteType(rt)
+	if len(deleted) == 0 {
+		return ErrValueNotFound
+	}
+
+	for key, value := range deleted {
+		r.observers.notifyDelete(key, storedValue(value))
+	}
+
+	return nil
+}
+
+// deleteType removes every key of the type and returns the removed values.
+func (r *SyncMapRegistry) deleteType(rt reflect.Type) map[RegistryKey]any {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	deleted := make(map[RegistryKey]any)
+
+	for _, key := range r.index.removeType(rt) {
+		if value, ok := r.sm.LoadAndDelete(key); ok {
+			deleted[key] = value
+		}
+	}
+
+	return deleted
+}
+
+// All returns an iterator over the keys and values stored in the registry, in no particular order.
+// Malformed and expired entries are skipped. Concurrent modifications follow the semantics of sync.Map.Range.
+func (r *SyncMapRegistry) All() iter.Seq2[RegistryKey, reflect.Value] {
+	return func(yield func(RegistryKey, reflect.Value) bool) {
+		r.sm.Range(func(k, v any) bool {
+			key, ok := k.(RegistryKey)
+			if !ok {
+				return true
+			}
+
+			rv, err := r.value(key, v)
+			if err != nil {
+				return true
+			}
+
+			return yield(key, rv)
+		})
+	}
+}
+
+// Keys returns the keys of all values stored in the registry, sorted by type name and tag.
+func (r *SyncMapRegistry) Keys() []RegistryKey {
+	var keys []RegistryKey
+
+	for key := range r.All() {
+		keys = append(keys, key)
+	}
+
+	sortKeys(keys)
+
+	return keys
+}
+
+// Clone returns a new registry holding every key and value stored in this one.
+// The copy is shallow: the stored reflect.Values, and the pointers they may hold, are shared,
+// but registering or deleting in one registry afterwards does not affect the other. Observers are not copied.
+// Values registered concurrently with Clone may or may not be copied.
+func (r *SyncMapRegistry) Clone() *SyncMapRegistry {
+	clone := new(SyncMapRegistry)
+
+	for key, rv := range r.All() {
+		clone.sm.Store(key, rv)
+		clone.index.add(key)
+	}
+
+	return clone
+}
+
+// Len returns the number of values stored in the registry.
+// It is computed on demand by ranging over every entry, so it costs O(n) for n entries.
+func (r *SyncMapRegistry) Len() int {
+	count := 0
+
+	for range r.All() {
+		count++
+	}
+
+	return count
+}
+
+// CountType returns the number of values stored for the specified type under any tag.
+func (r *SyncMapRegistry) CountType(rt reflect.Type) int {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	return r.index.count(rt)
+}
+
+// sortKeys sorts registry keys by type name, tag and scope.
+func sortKeys(keys []RegistryKey) {
+	slices.SortFunc(keys, func(a, b RegistryKey) int {
+		return cmp.Or(cmp.Compare(a.Type.String(), b.Type.String()), compareTags(a, b))
+	})
+}
+
+// sortKeysByTag sorts registry keys of a single type by tag and scope.
+func sortKeysByTag(keys []RegistryKey) {
+	slices.SortFunc(keys, compareTags)
+}
+
+// compareTags orders registry keys by tag, then by scope and qualifier.
+func compareTags(a, b RegistryKey) int {
+	return cmp.Or(cmp.Compare(a.Tag, b.Tag), cmp.Compare(a.Scope, b.Scope), compareQualifiers(a.Qualifier, b.Qualifier))
+}
+
+// compareQualifiers orders unqualified keys first, then qualifiers by their Go syntax representation.
+func compareQualifiers(a, b any) int {
+	switch {
+	case a == b:
+		return 0
+
+	case a == nil:
+		return -1
+
+	case b == nil:
+		return 1
+
+	default:
+		return cmp.Compare(fmt.Sprintf("%#v", a), fmt.Sprintf("%#v", b))
+	}
+}
+
+// FindByTagPrefix returns the keys of the values registered for the type under a tag starting with
+// the prefix, sorted by tag, e.g. every "driver:" tag. It is built on FindByType, so it works with
+// any Registry. Unlike Find, it never matches a tag exactly and is never used to resolve a key.
+func FindByTagPrefix(registry Registry, rt reflect.Type, prefix string) []RegistryKey {
+	keys := registry.FindByType(rt)
+
+	return slices.DeleteFunc(keys, func(key RegistryKey) bool {
+		return !strings.HasPrefix(key.Tag, prefix)
+	})
+}
+
+// RegistryGet looks up the value registered for type T with the specified tag and returns it as a T.
+// It returns ErrStoredFactory if a factory is registered instead of a resolved value, and ErrTypeMismatch
+// if the stored value cannot be converted to T.
+func RegistryGet[T any](registry Registry, tag string) (T, error) {
+	var zero T
+
+	key := RegistryKey{
+		Tag:       tag,
+		Type:      reflect.TypeFor[T](),
+		Scope:     "",
+		Qualifier: nil,
+	}
+
+	rv, err := registry.Find(key)
+	if err != nil {
+		return zero, fmt.Errorf("get %s with tag '%s': %w", key.Type, tag, err)
+	}
+
+	if isFactory(key, rv) {
+		return zero, fmt.Errorf("get %s with tag '%s': %w: %s", key.Type, tag, ErrStoredFactory, rv.Type())
+	}
+
+	if !rv.Type().AssignableTo(key.Type) {
+		return zero, fmt.Errorf("get %s with tag '%s': %w: got %s", key.Type, tag, ErrTypeMismatch, rv.Type())
+	}
+
+	// A nil interface value converts to the zero T
+	val, _ := rv.Interface().(T)
+
+	return val, nil
+}
+
+// validateEntry checks that the value can be stored in a registry with the specified key.
+func validateEntry(key RegistryKey, rv reflect.Value) error {
+	if key.Type == nil {
+		return ErrKeyTypeNil
+	}
+
+	if !rv.IsValid() {
+		return ErrInvalidValue
+	}
+
+	if !isAssignable(rv.Type(), key.Type) {
+		return fmt.Errorf("%w: %s is not assignable to %s", ErrTypeMismatch, rv.Type(), key.Type)
+	}
+
+	return nil
+}
+
 // Ensure SyncMapRegistry implements the Registry interface.
 var _ Registry = (*SyncMapRegistry)(nil)
@@ -0,0 +1,143 @@
+package dino
+
+import (
+	"errors"
+	"iter"
+	"reflect"
+	"slices"
+	"time"
+)
+
+// ChildRegistry layers a registry of its own over a parent registry. Lookups check the child first
+// and fall back to the parent, while registrations and deletions only ever affect the child, so the
+// parent stays unchanged from the child's perspective. Children can be chained to any depth.
+type ChildRegistry struct {
+	parent Registry
+	local  *SyncMapRegistry
+}
+
+// NewChildRegistry creates an empty registry that falls back to the parent on lookups.
+func NewChildRegistry(parent Registry) *ChildRegistry {
+	return &ChildRegistry{
+		parent: parent,
+		local:  new(SyncMapRegistry),
+	}
+}
+
+// Parent returns the registry the child falls back to.
+func (r *ChildRegistry) Parent() Registry {
+	return r.parent
+}
+
+// Register stores a value in the child with the specified key.
+func (r *ChildRegistry) Register(key RegistryKey, rv reflect.Value) error {
+	return r.local.Register(key, rv)
+}
+
+// RegisterTTL stores a value in the child that expires after the specified duration.
+func (r *ChildRegistry) RegisterTTL(key RegistryKey, rv reflect.Value, ttl time.Duration) error {
+	return r.local.RegisterTTL(key, rv, ttl)
+}
+
+// RegisterIfAbsent stores a value in the child unless the child or one of its parents already holds
+// a value for the key. It returns the stored value and true if the key was already present,
+// or rv and false if rv was stored.
+func (r *ChildRegistry) RegisterIfAbsent(key RegistryKey, rv reflect.Value) (reflect.Value, bool, error) {
+	if key.Type != nil {
+		if inherited, err := r.parent.Find(key); err == nil {
+			return inherited, true, nil
+		}
+	}
+
+	return r.local.RegisterIfAbsent(key, rv)
+}
+
+// Find looks up a value in the child, then in the parent, based on the specified key.
+func (r *ChildRegistry) Find(key RegistryKey) (reflect.Value, error) {
+	rv, err := r.local.Find(key)
+	if !errors.Is(err, ErrValueNotFound) {
+		return rv, err
+	}
+
+	return r.parent.Find(key)
+}
+
+// Clear removes every value stored in the child, leaving the parent untouched.
+func (r *ChildRegistry) Clear() error {
+	var errs []error
+
+	for key := range r.local.All() {
+		if err := r.local.Delete(key); err != nil && !errors.Is(err, ErrValueNotFound) {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// FindByType returns the keys of all values registered for the specified type in the child and its
+// parents, sorted by tag.
+func (r *ChildRegistry) FindByType(rt reflect.Type) []RegistryKey {
+	keys := slices.Concat(r.local.FindByType(rt), r.parent.FindByType(rt))
+
+	sortKeysByTag(keys)
+
+	return slices.Compact(keys)
+}
+
+// FindAssignableTo returns the keys of all values registered for a type assignable to rt in the child
+// and its parents, sorted by type name and tag.
+func (r *ChildRegistry) FindAssignableTo(rt reflect.Type) []RegistryKey {
+	keys := slices.Concat(r.local.FindAssignableTo(rt), r.parent.FindAssignableTo(rt))
+
+	sortKeys(keys)
+
+	return slices.Compact(keys)
+}
+
+// Delete removes the value stored in the child for the specified key. Values inherited from the parent
+// are not affected and become visible again.
+func (r *ChildRegistry) Delete(key RegistryKey) error {
+	return r.local.Delete(key)
+}
+
+// DeleteAll removes every value of the specified type stored in the child.
+func (r *ChildRegistry) DeleteAll(rt reflect.Type) error {
+	return r.local.DeleteAll(rt)
+}
+
+// All returns an iterator over the keys and values visible from the child, in no particular order.
+// Entries of the child shadow parent entries stored under the same key.
+func (r *ChildRegistry) All() iter.Seq2[RegistryKey, reflect.Value] {
+	return func(yield func(RegistryKey, reflect.Value) bool) {
+		for key, rv := range r.local.All() {
+			if !yield(key, rv) {
+				return
+			}
+		}
+
+		for key, rv := range r.parent.All() {
+			if _, err := r.local.Find(key); err == nil {
+				continue
+			}
+
+			if !yield(key, rv) {
+				return
+			}
+		}
+	}
+}
+
+// Len returns the number of values visible from the child.
+func (r *ChildRegistry) Len() int {
+	count := 0
+
+	for range r.All() {
+		count++
+	}
+
+	return count
+}
+
+// Ensure ChildRegistry implements the ExpiringRegistry interface.
+var _ ExpiringRegistry = (*ChildRegistry)(nil)
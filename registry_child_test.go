@@ -0,0 +1,209 @@
+package dino_test
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/yuppyweb/dino"
+)
+
+func TestChildRegistry_ShadowsParent(t *testing.T) {
+	t.Parallel()
+
+	forEachRegistry(t, func(t *testing.T, parent testRegistry) {
+		t.Helper()
+
+		key := dino.RegistryKey{
+			Tag:   "",
+			Type:  reflect.TypeFor[int](),
+			Scope: "",
+		}
+
+		if err := parent.Register(key, reflect.ValueOf(1)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		child := dino.NewChildRegistry(parent)
+
+		if err := child.Register(key, reflect.ValueOf(2)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if rv, err := child.Find(key); err != nil || rv.Int() != 2 {
+			t.Fatalf("expected the child value 2, got %v (%v)", rv, err)
+		}
+
+		if rv, err := parent.Find(key); err != nil || rv.Int() != 1 {
+			t.Fatalf("expected the parent value to be untouched, got %v (%v)", rv, err)
+		}
+
+		entries := 0
+
+		for _, rv := range child.All() {
+			if rv.Int() != 2 {
+				t.Fatalf("expected the shadowed parent entry to be hidden, got %v", rv)
+			}
+
+			entries++
+		}
+
+		if entries != 1 {
+			t.Fatalf("expected 1 entry, got %d", entries)
+		}
+
+		if keys := child.FindByType(key.Type); len(keys) != 1 {
+			t.Fatalf("expected one key for the shadowed entry, got %v", keys)
+		}
+
+		// Deleting from the child uncovers the parent value
+		if err := child.Delete(key); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if rv, err := child.Find(key); err != nil || rv.Int() != 1 {
+			t.Fatalf("expected the parent value 1 after delete, got %v (%v)", rv, err)
+		}
+
+		if err := child.Delete(key); !errors.Is(err, dino.ErrValueNotFound) {
+			t.Fatalf("expected delete to leave the parent alone, got %v", err)
+		}
+
+		if _, err := parent.Find(key); err != nil {
+			t.Fatalf("expected the parent value to survive, got %v", err)
+		}
+	})
+}
+
+func TestChildRegistry_FallsBackToParent(t *testing.T) {
+	t.Parallel()
+
+	parent := new(dino.SyncMapRegistry)
+	child := dino.NewChildRegistry(parent)
+
+	key := dino.RegistryKey{
+		Tag:   "inherited",
+		Type:  reflect.TypeFor[string](),
+		Scope: "",
+	}
+
+	if _, err := child.Find(key); !errors.Is(err, dino.ErrValueNotFound) {
+		t.Fatalf("expected ErrValueNotFound, got %v", err)
+	}
+
+	if err := parent.Register(key, reflect.ValueOf("parent")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if rv, err := child.Find(key); err != nil || rv.String() != "parent" {
+		t.Fatalf("expected the parent value, got %v (%v)", rv, err)
+	}
+
+	rv, loaded, err := child.RegisterIfAbsent(key, reflect.ValueOf("child"))
+	if err != nil || !loaded || rv.String() != "parent" {
+		t.Fatalf("expected the inherited value to count as present, got %v, %t (%v)", rv, loaded, err)
+	}
+}
+
+func TestChildRegistry_ThreeLevelChain(t *testing.T) {
+	t.Parallel()
+
+	root := new(dino.SyncMapRegistry)
+	middle := dino.NewChildRegistry(root)
+	leaf := dino.NewChildRegistry(middle)
+
+	keys := make([]dino.RegistryKey, 3)
+	for idx := range keys {
+		keys[idx] = dino.RegistryKey{
+			Tag:   strconv.Itoa(idx),
+			Type:  reflect.TypeFor[int](),
+			Scope: "",
+		}
+	}
+
+	registries := []dino.Registry{root, middle, leaf}
+	for idx, registry := range registries {
+		// Every level registers its own key and overrides key 0
+		if err := registry.Register(keys[idx], reflect.ValueOf(idx)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if err := registry.Register(keys[0], reflect.ValueOf(idx*10)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if rv, err := leaf.Find(keys[0]); err != nil || rv.Int() != 20 {
+		t.Fatalf("expected the leaf override 20, got %v (%v)", rv, err)
+	}
+
+	if rv, err := leaf.Find(keys[1]); err != nil || rv.Int() != 1 {
+		t.Fatalf("expected the middle value 1, got %v (%v)", rv, err)
+	}
+
+	if rv, err := middle.Find(keys[0]); err != nil || rv.Int() != 10 {
+		t.Fatalf("expected the middle override 10, got %v (%v)", rv, err)
+	}
+
+	if _, err := middle.Find(keys[2]); !errors.Is(err, dino.ErrValueNotFound) {
+		t.Fatalf("expected the leaf value to stay invisible to its parent, got %v", err)
+	}
+
+	if got := leaf.FindByType(reflect.TypeFor[int]()); len(got) != 3 {
+		t.Fatalf("expected 3 keys, got %v", got)
+	}
+
+	if leaf.Len() != 3 {
+		t.Fatalf("expected 3 visible entries, got %d", leaf.Len())
+	}
+}
+
+func TestChildRegistry_ConcurrentParentReadsAndChildWrites(t *testing.T) {
+	t.Parallel()
+
+	parent := new(dino.SyncMapRegistry)
+	child := dino.NewChildRegistry(parent)
+
+	keys := make([]dino.RegistryKey, 16)
+	for idx := range keys {
+		keys[idx] = dino.RegistryKey{
+			Tag:   strconv.Itoa(idx),
+			Type:  reflect.TypeFor[int](),
+			Scope: "",
+		}
+
+		if err := parent.Register(keys[idx], reflect.ValueOf(idx)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	var wg sync.WaitGroup
+
+	for worker := range 8 {
+		wg.Go(func() {
+			for idx := range 100 {
+				key := keys[(worker+idx)%len(keys)]
+
+				if err := child.Register(key, reflect.ValueOf(-idx)); err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+
+				if _, err := child.Find(key); err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+
+				if rv, err := parent.Find(key); err != nil || rv.Int() < 0 {
+					t.Errorf("expected the parent value to be untouched, got %v (%v)", rv, err)
+				}
+
+				if child.Len() != len(keys) {
+					t.Errorf("expected %d visible entries, got %d", len(keys), child.Len())
+				}
+			}
+		})
+	}
+
+	wg.Wait()
+}
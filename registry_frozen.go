@@ -0,0 +1,226 @@
+package dino
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"maps"
+	"reflect"
+	"slices"
+	"time"
+)
+
+// ErrContainerFrozen is returned when registering in or deleting from a frozen registry.
+var ErrContainerFrozen = errors.New("container is frozen")
+
+// resultStore caches the results of factory functions.
+type resultStore interface {
+	store(key RegistryKey, rv reflect.Value, ttl time.Duration) error
+	storeIfAbsent(key RegistryKey, rv reflect.Value) error
+}
+
+// registryResults caches factory results in a registry like any registered value.
+type registryResults struct {
+	registry Registry
+}
+
+// store registers the result, expiring after ttl if it is positive.
+func (r registryResults) store(key RegistryKey, rv reflect.Value, ttl time.Duration) error {
+	if ttl <= 0 {
+		if err := r.registry.Register(key, rv); err != nil {
+			return fmt.Errorf("bind value to registry: %w", err)
+		}
+
+		return nil
+	}
+
+	registry, ok := r.registry.(ExpiringRegistry)
+	if !ok {
+		return fmt.Errorf("%w: %T", ErrTTLUnsupported, r.registry)
+	}
+
+	if err := registry.RegisterTTL(key, rv, ttl); err != nil {
+		return fmt.Errorf("bind expiring value to registry: %w", err)
+	}
+
+	return nil
+}
+
+// storeIfAbsent registers the result unless something is registered under the key.
+func (r registryResults) storeIfAbsent(key RegistryKey, rv reflect.Value) error {
+	_, _, err := r.registry.RegisterIfAbsent(key, rv)
+
+	return err
+}
+
+// FrozenRegistry is a read-optimized registry holding a fixed set of entries, created by freezing
+// another registry once the container is set up. Its entries are read from a plain map without
+// synchronization; registering or deleting returns ErrContainerFrozen. Factory results resolved
+// afterwards are cached in a separate SyncMapRegistry and take precedence over the frozen entries.
+type FrozenRegistry struct {
+	entries map[RegistryKey]reflect.Value
+	index   typeIndex
+	cache   *SyncMapRegistry
+}
+
+// Freeze copies every entry of the registry into a new FrozenRegistry.
+func Freeze(registry Registry) *FrozenRegistry {
+	if frozen, ok := registry.(*FrozenRegistry); ok {
+		return frozen
+	}
+
+	entries := maps.Collect(registry.All())
+
+	return &FrozenRegistry{
+		entries: entries,
+		index:   newTypeIndex(maps.Keys(entries)),
+		cache:   new(SyncMapRegistry),
+	}
+}
+
+// Freeze copies every entry of the registry into a new FrozenRegistry.
+func (r *SyncMapRegistry) Freeze() *FrozenRegistry {
+	return Freeze(r)
+}
+
+// Freeze copies every entry of the registry into a new FrozenRegistry.
+func (r *MapRegistry) Freeze() *FrozenRegistry {
+	return Freeze(r)
+}
+
+// Freeze copies every entry of the registry into a new FrozenRegistry.
+func (r *ShardedRegistry) Freeze() *FrozenRegistry {
+	return Freeze(r)
+}
+
+// Register returns ErrContainerFrozen.
+func (r *FrozenRegistry) Register(RegistryKey, reflect.Value) error {
+	return ErrContainerFrozen
+}
+
+// RegisterIfAbsent returns ErrContainerFrozen.
+func (r *FrozenRegistry) RegisterIfAbsent(RegistryKey, reflect.Value) (reflect.Value, bool, error) {
+	return reflect.Value{}, false, ErrContainerFrozen
+}
+
+// Find looks up a cached factory result, then a frozen entry, based on the specified key.
+func (r *FrozenRegistry) Find(key RegistryKey) (reflect.Value, error) {
+	if key.Type == nil {
+		return reflect.Value{}, ErrKeyTypeNil
+	}
+
+	// Plain values are never replaced, so only factories need a look into the result cache
+	rv, ok := r.entries[key]
+	if ok && rv.Type() == key.Type {
+		return rv, nil
+	}
+
+	if cached, err := r.cache.Find(key); err == nil {
+		return cached, nil
+	}
+
+	if !ok {
+		return reflect.Zero(key.Type), ErrValueNotFound
+	}
+
+	return rv, nil
+}
+
+// FindByType returns the keys of all values registered for the specified type, sorted by tag.
+func (r *FrozenRegistry) FindByType(rt reflect.Type) []RegistryKey {
+	if rt == nil {
+		return nil
+	}
+
+	keys := slices.Concat(r.index.byType(rt), r.cache.FindByType(rt))
+
+	sortKeysByTag(keys)
+
+	return slices.Compact(keys)
+}
+
+// FindAssignableTo returns the keys of all values registered for a type assignable to rt,
+// e.g. every implementation of an interface, sorted by type name and tag.
+func (r *FrozenRegistry) FindAssignableTo(rt reflect.Type) []RegistryKey {
+	if rt == nil {
+		return nil
+	}
+
+	keys := slices.Concat(r.index.assignableTo(rt), r.cache.FindAssignableTo(rt))
+
+	sortKeys(keys)
+
+	return slices.Compact(keys)
+}
+
+// Delete returns ErrContainerFrozen.
+func (r *FrozenRegistry) Delete(RegistryKey) error {
+	return ErrContainerFrozen
+}
+
+// DeleteAll returns ErrContainerFrozen.
+func (r *FrozenRegistry) DeleteAll(reflect.Type) error {
+	return ErrContainerFrozen
+}
+
+// All returns an iterator over the keys and values stored in the registry, in no particular order.
+// Cached factory results replace the frozen entries registered under the same key.
+func (r *FrozenRegistry) All() iter.Seq2[RegistryKey, reflect.Value] {
+	return func(yield func(RegistryKey, reflect.Value) bool) {
+		cached := maps.Collect(r.cache.All())
+
+		for key, rv := range cached {
+			if !yield(key, rv) {
+				return
+			}
+		}
+
+		for key, rv := range r.entries {
+			if _, ok := cached[key]; ok {
+				continue
+			}
+
+			if !yield(key, rv) {
+				return
+			}
+		}
+	}
+}
+
+// Len returns the number of values stored in the registry.
+func (r *FrozenRegistry) Len() int {
+	count := 0
+
+	for range r.All() {
+		count++
+	}
+
+	return count
+}
+
+// store caches a factory result, expiring after ttl if it is positive.
+func (r *FrozenRegistry) store(key RegistryKey, rv reflect.Value, ttl time.Duration) error {
+	return registryResults{registry: r.cache}.store(key, rv, ttl)
+}
+
+// storeIfAbsent caches a factory result unless something is registered or cached under the key.
+func (r *FrozenRegistry) storeIfAbsent(key RegistryKey, rv reflect.Value) error {
+	if _, ok := r.entries[key]; ok {
+		return nil
+	}
+
+	return registryResults{registry: r.cache}.storeIfAbsent(key, rv)
+}
+
+// Ensure FrozenRegistry implements the Registry interface.
+var _ Registry = (*FrozenRegistry)(nil)
+
+// Freeze replaces the container's registry with a read-optimized FrozenRegistry holding its current
+// entries. Afterwards registering or removing dependencies fails with ErrContainerFrozen, while
+// factories keep working and their results are still cached.
+func (d *Dino) Freeze() {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.registry = Freeze(d.registry)
+}
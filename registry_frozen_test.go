@@ -0,0 +1,232 @@
+package dino_test
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/yuppyweb/dino"
+)
+
+type FrozenService struct {
+	ID int64
+}
+
+func TestFrozenRegistry_CopiesEntries(t *testing.T) {
+	t.Parallel()
+
+	forEachRegistry(t, func(t *testing.T, registry testRegistry) {
+		t.Helper()
+
+		key := dino.RegistryKey{
+			Tag:   "answer",
+			Type:  reflect.TypeFor[int](),
+			Scope: "",
+		}
+
+		if err := registry.Register(key, reflect.ValueOf(42)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		frozen := dino.Freeze(registry)
+
+		rv, err := frozen.Find(key)
+		if err != nil || rv.Int() != 42 {
+			t.Fatalf("expected 42, got %v (%v)", rv, err)
+		}
+
+		if keys := frozen.FindByType(key.Type); len(keys) != 1 || keys[0] != key {
+			t.Fatalf("expected [%v], got %v", key, keys)
+		}
+
+		if frozen.Len() != 1 {
+			t.Fatalf("expected 1 entry, got %d", frozen.Len())
+		}
+
+		// Later changes to the source registry do not leak into the frozen copy
+		if err := registry.Delete(key); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if _, err := frozen.Find(key); err != nil {
+			t.Fatalf("expected frozen entry to survive, got %v", err)
+		}
+	})
+}
+
+func TestFrozenRegistry_RejectsWrites(t *testing.T) {
+	t.Parallel()
+
+	frozen := new(dino.SyncMapRegistry).Freeze()
+
+	key := dino.RegistryKey{
+		Tag:   "",
+		Type:  reflect.TypeFor[int](),
+		Scope: "",
+	}
+
+	if err := frozen.Register(key, reflect.ValueOf(1)); !errors.Is(err, dino.ErrContainerFrozen) {
+		t.Fatalf("expected ErrContainerFrozen from Register, got %v", err)
+	}
+
+	if _, _, err := frozen.RegisterIfAbsent(key, reflect.ValueOf(1)); !errors.Is(err, dino.ErrContainerFrozen) {
+		t.Fatalf("expected ErrContainerFrozen from RegisterIfAbsent, got %v", err)
+	}
+
+	if err := frozen.Delete(key); !errors.Is(err, dino.ErrContainerFrozen) {
+		t.Fatalf("expected ErrContainerFrozen from Delete, got %v", err)
+	}
+
+	if err := frozen.DeleteAll(key.Type); !errors.Is(err, dino.ErrContainerFrozen) {
+		t.Fatalf("expected ErrContainerFrozen from DeleteAll, got %v", err)
+	}
+
+	if _, err := frozen.Find(key); !errors.Is(err, dino.ErrValueNotFound) {
+		t.Fatalf("expected ErrValueNotFound, got %v", err)
+	}
+}
+
+func TestDino_FreezeRejectsRegistrations(t *testing.T) {
+	t.Parallel()
+
+	di := dino.New()
+
+	if err := di.Singleton(1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	di.Freeze()
+
+	if err := di.Singleton("late"); !errors.Is(err, dino.ErrContainerFrozen) {
+		t.Fatalf("expected ErrContainerFrozen, got %v", err)
+	}
+
+	if err := di.Factory(func() string { return "late" }); !errors.Is(err, dino.ErrContainerFrozen) {
+		t.Fatalf("expected ErrContainerFrozen, got %v", err)
+	}
+
+	value, err := di.Resolve(reflect.TypeFor[int]())
+	if err != nil || value != 1 {
+		t.Fatalf("expected 1, got %v (%v)", value, err)
+	}
+}
+
+func TestDino_FreezeCachesFactoryResults(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int64
+
+	di := dino.New(dino.WithCacheSharing())
+
+	err := di.Factory(func() *FrozenService {
+		return &FrozenService{ID: calls.Add(1)}
+	}, "primary")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	di.Freeze()
+
+	first, err := di.Resolve(reflect.TypeFor[*FrozenService](), "primary")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	second, err := di.Resolve(reflect.TypeFor[*FrozenService](), "primary")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if first != second || calls.Load() != 1 {
+		t.Fatalf("expected one cached instance, got %v and %v after %d calls", first, second, calls.Load())
+	}
+
+	shared, err := di.Resolve(reflect.TypeFor[*FrozenService]())
+	if err != nil || shared != first {
+		t.Fatalf("expected the shared untagged instance, got %v (%v)", shared, err)
+	}
+}
+
+func TestDino_FreezeConcurrentResolve(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int64
+
+	di := dino.New()
+
+	if err := di.Singleton(7); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for idx := range 8 {
+		err := di.Factory(func(base int) *FrozenService {
+			return &FrozenService{ID: calls.Add(1) + int64(base)}
+		}, strconv.Itoa(idx))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	di.Freeze()
+
+	var wg sync.WaitGroup
+
+	for worker := range 32 {
+		wg.Go(func() {
+			if _, err := di.Resolve(reflect.TypeFor[*FrozenService](), strconv.Itoa(worker%8)); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			base, err := di.Resolve(reflect.TypeFor[int]())
+			if err != nil || base != 7 {
+				t.Errorf("expected 7, got %v (%v)", base, err)
+			}
+		})
+	}
+
+	wg.Wait()
+
+	if calls.Load() < 8 {
+		t.Fatalf("expected every factory to be called, got %d calls", calls.Load())
+	}
+}
+
+func BenchmarkFrozenRegistry_Find(b *testing.B) {
+	keys := make([]dino.RegistryKey, 256)
+	for idx := range keys {
+		keys[idx] = dino.RegistryKey{
+			Tag:   strconv.Itoa(idx),
+			Type:  reflect.TypeFor[int](),
+			Scope: "",
+		}
+	}
+
+	registry := new(dino.SyncMapRegistry)
+
+	for idx, key := range keys {
+		if err := registry.Register(key, reflect.ValueOf(idx)); err != nil {
+			b.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	registries := []struct {
+		name     string
+		registry dino.Registry
+	}{
+		{name: "SyncMapRegistry", registry: registry},
+		{name: "FrozenRegistry", registry: registry.Freeze()},
+	}
+
+	for _, impl := range registries {
+		for _, goroutines := range []int{1, 8, 64} {
+			b.Run(impl.name+"/"+strconv.Itoa(goroutines), func(b *testing.B) {
+				runConcurrently(b, goroutines, func(idx int) {
+					_, _ = impl.registry.Find(keys[idx%len(keys)])
+				})
+			})
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package dino
+
+import (
+	"iter"
+	"maps"
+	"reflect"
+	"slices"
+)
+
+// typeIndex maps every registered type to the keys stored for it, so that lookups by type
+// do not have to range over the whole registry. It is not safe for concurrent use.
+type typeIndex struct {
+	types map[reflect.Type]map[RegistryKey]struct{}
+}
+
+// newTypeIndex builds an index of the provided keys.
+func newTypeIndex(keys iter.Seq[RegistryKey]) typeIndex {
+	var idx typeIndex
+
+	for key := range keys {
+		idx.add(key)
+	}
+
+	return idx
+}
+
+// add records the key in the index.
+func (idx *typeIndex) add(key RegistryKey) {
+	if idx.types == nil {
+		idx.types = make(map[reflect.Type]map[RegistryKey]struct{})
+	}
+
+	keys, ok := idx.types[key.Type]
+	if !ok {
+		keys = make(map[RegistryKey]struct{})
+		idx.types[key.Type] = keys
+	}
+
+	keys[key] = struct{}{}
+}
+
+// remove deletes the key from the index.
+func (idx *typeIndex) remove(key RegistryKey) {
+	keys, ok := idx.types[key.Type]
+	if !ok {
+		return
+	}
+
+	delete(keys, key)
+
+	if len(keys) == 0 {
+		delete(idx.types, key.Type)
+	}
+}
+
+// removeType deletes every key of the type from the index and returns them.
+func (idx *typeIndex) removeType(rt reflect.Type) []RegistryKey {
+	keys := slices.Collect(maps.Keys(idx.types[rt]))
+
+	delete(idx.types, rt)
+
+	return keys
+}
+
+// byType returns the keys stored for the type, sorted by tag.
+func (idx *typeIndex) byType(rt reflect.Type) []RegistryKey {
+	keys := slices.Collect(maps.Keys(idx.types[rt]))
+
+	sortKeysByTag(keys)
+
+	return keys
+}
+
+// count returns the number of keys stored for the type.
+func (idx *typeIndex) count(rt reflect.Type) int {
+	return len(idx.types[rt])
+}
+
+// assignableTo returns the keys whose type is assignable to rt, sorted by type name and tag.
+// Its cost depends on the number of distinct registered types, not on the number of entries.
+func (idx *typeIndex) assignableTo(rt reflect.Type) []RegistryKey {
+	var keys []RegistryKey
+
+	for keyType, typeKeys := range idx.types {
+		if keyType.AssignableTo(rt) {
+			keys = slices.AppendSeq(keys, maps.Keys(typeKeys))
+		}
+	}
+
+	sortKeys(keys)
+
+	return keys
+}
@@ -0,0 +1,294 @@
+package dino
+
+import (
+	"iter"
+	"maps"
+	"reflect"
+	"slices"
+	"sync"
+)
+
+// MapRegistry is a thread-safe implementation of the Registry interface using a map guarded by a sync.RWMutex.
+// Unlike SyncMapRegistry, its Clone and Restore operations are atomic with respect to concurrent registrations.
+// The zero value is ready to use.
+type MapRegistry struct {
+	mutex     sync.RWMutex
+	entries   map[RegistryKey]reflect.Value
+	index     typeIndex
+	observers observers
+}
+
+// NewMapRegistry creates an empty MapRegistry.
+func NewMapRegistry() *MapRegistry {
+	registry := new(MapRegistry)
+	registry.entries = make(map[RegistryKey]reflect.Value)
+
+	return registry
+}
+
+// Register stores a value in the registry with the specified key.
+func (r *MapRegistry) Register(key RegistryKey, rv reflect.Value) error {
+	if err := validateEntry(key, rv); err != nil {
+		return err
+	}
+
+	r.store(key, rv)
+	r.observers.notifyRegister(key, rv)
+
+	return nil
+}
+
+// store writes the value under the key and indexes the key.
+func (r *MapRegistry) store(key RegistryKey, rv reflect.Value) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.storeLocked(key, rv)
+}
+
+// storeLocked writes the value under the key and indexes the key. The caller must hold the write lock.
+func (r *MapRegistry) storeLocked(key RegistryKey, rv reflect.Value) {
+	if r.entries == nil {
+		r.entries = make(map[RegistryKey]reflect.Value)
+	}
+
+	r.entries[key] = rv
+	r.index.add(key)
+}
+
+// RegisterIfAbsent stores a value in the registry with the specified key unless a value is already stored.
+// It returns the stored value and true if the key was already present, or rv and false if rv was stored.
+func (r *MapRegistry) RegisterIfAbsent(key RegistryKey, rv reflect.Value) (reflect.Value, bool, error) {
+	if err := validateEntry(key, rv); err != nil {
+		return reflect.Value{}, false, err
+	}
+
+	existing, loaded := r.loadOrStore(key, rv)
+	if !loaded {
+		r.observers.notifyRegister(key, rv)
+	}
+
+	return existing, loaded, nil
+}
+
+// loadOrStore writes the value under the key unless the key is present and returns the stored value.
+func (r *MapRegistry) loadOrStore(key RegistryKey, rv reflect.Value) (reflect.Value, bool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if existing, ok := r.entries[key]; ok {
+		return existing, true
+	}
+
+	r.storeLocked(key, rv)
+
+	return rv, false
+}
+
+// Find looks up a value in the registry based on the specified key.
+func (r *MapRegistry) Find(key RegistryKey) (reflect.Value, error) {
+	if key.Type == nil {
+		return reflect.Value{}, ErrKeyTypeNil
+	}
+
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	rv, ok := r.entries[key]
+	if !ok {
+		return reflect.Zero(key.Type), ErrValueNotFound
+	}
+
+	return rv, nil
+}
+
+// FindByType returns the keys of all values registered for the specified type, sorted by tag.
+func (r *MapRegistry) FindByType(rt reflect.Type) []RegistryKey {
+	if rt == nil {
+		return nil
+	}
+
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	return r.index.byType(rt)
+}
+
+// FindAssignableTo returns the keys of all values registered for a type assignable to rt,
+// e.g. every implementation of an interface, sorted by type name and tag.
+func (r *MapRegistry) FindAssignableTo(rt reflect.Type) []RegistryKey {
+	if rt == nil {
+		return nil
+	}
+
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	return r.index.assignableTo(rt)
+}
+
+// Delete removes the value registered with the specified key.
+func (r *MapRegistry) Delete(key RegistryKey) error {
+	if key.Type == nil {
+		return ErrKeyTypeNil
+	}
+
+	rv, ok := r.loadAndDelete(key)
+	if !ok {
+		return ErrValueNotFound
+	}
+
+	r.observers.notifyDelete(key, rv)
+
+	return nil
+}
+
+// loadAndDelete removes the key and its value, returning the value if the key was present.
+func (r *MapRegistry) loadAndDelete(key RegistryKey) (reflect.Value, bool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	rv, ok := r.entries[key]
+	if ok {
+		delete(r.entries, key)
+		r.index.remove(key)
+	}
+
+	return rv, ok
+}
+
+// DeleteAll removes the values registered for the specified type under every tag.
+// It returns ErrValueNotFound if nothing was registered for the type.
+func (r *MapRegistry) DeleteAll(rt reflect.Type) error {
+	if rt == nil {
+		return ErrKeyTypeNil
+	}
+
+	deleted := r.deleteType(rt)
+	if len(deleted) == 0 {
+		return ErrValueNotFound
+	}
+
+	for key, rv := range deleted {
+		r.observers.notifyDelete(key, rv)
+	}
+
+	return nil
+}
+
+// deleteType removes every key of the type and returns the removed values.
+func (r *MapRegistry) deleteType(rt reflect.Type) map[RegistryKey]reflect.Value {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	deleted := make(map[RegistryKey]reflect.Value)
+
+	for _, key := range r.index.removeType(rt) {
+		deleted[key] = r.entries[key]
+		delete(r.entries, key)
+	}
+
+	return deleted
+}
+
+// All returns an iterator over the keys and values stored in the registry, in no particular order.
+// It iterates over a copy of the entries, so the registry may be modified while ranging over it.
+func (r *MapRegistry) All() iter.Seq2[RegistryKey, reflect.Value] {
+	r.mutex.RLock()
+	entries := maps.Clone(r.entries)
+	r.mutex.RUnlock()
+
+	return maps.All(entries)
+}
+
+// Keys returns the keys of all values stored in the registry, sorted by type name and tag.
+func (r *MapRegistry) Keys() []RegistryKey {
+	r.mutex.RLock()
+	keys := slices.Collect(maps.Keys(r.entries))
+	r.mutex.RUnlock()
+
+	sortKeys(keys)
+
+	return keys
+}
+
+// Clone returns a new registry holding every key and value stored in this one.
+// The copy is shallow: the stored reflect.Values, and the pointers they may hold, are shared,
+// but registering or deleting in one registry afterwards does not affect the other. Observers are not copied.
+func (r *MapRegistry) Clone() *MapRegistry {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	clone := new(MapRegistry)
+	clone.entries = maps.Clone(r.entries)
+	clone.index = newTypeIndex(maps.Keys(r.entries))
+
+	return clone
+}
+
+// Len returns the number of values stored in the registry.
+func (r *MapRegistry) Len() int {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	return len(r.entries)
+}
+
+// CountType returns the number of values stored for the specified type under any tag.
+func (r *MapRegistry) CountType(rt reflect.Type) int {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	return r.index.count(rt)
+}
+
+// Snapshot returns a copy of the entries currently stored in the registry.
+func (r *MapRegistry) Snapshot() RegistrySnapshot {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	return RegistrySnapshot{
+		entries:   maps.Clone(r.entries),
+		settings:  nil,
+		lifecycle: nil,
+	}
+}
+
+// Restore atomically replaces the entries of the registry with the ones of the snapshot.
+// Afterwards, observers are notified about every removed entry and every entry of the snapshot.
+func (r *MapRegistry) Restore(snapshot RegistrySnapshot) error {
+	removed := r.replace(snapshot.entries)
+
+	for key, rv := range removed {
+		r.observers.notifyDelete(key, rv)
+	}
+
+	for key, rv := range snapshot.entries {
+		r.observers.notifyRegister(key, rv)
+	}
+
+	return nil
+}
+
+// replace swaps the entries of the registry for a copy of the provided ones and returns the entries
+// whose keys are not present anymore.
+func (r *MapRegistry) replace(entries map[RegistryKey]reflect.Value) map[RegistryKey]reflect.Value {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	removed := make(map[RegistryKey]reflect.Value)
+
+	for key, rv := range r.entries {
+		if _, ok := entries[key]; !ok {
+			removed[key] = rv
+		}
+	}
+
+	r.entries = maps.Clone(entries)
+	r.index = newTypeIndex(maps.Keys(entries))
+
+	return removed
+}
+
+// Ensure MapRegistry implements the Registry interface.
+var _ Registry = (*MapRegistry)(nil)
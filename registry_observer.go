@@ -0,0 +1,134 @@
+package dino
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"sync"
+)
+
+// Observer is notified about a change of a registry entry.
+type Observer func(key RegistryKey, rv reflect.Value)
+
+// observers holds the callbacks notified about changes of a registry. It is safe for concurrent use
+// and its zero value has no observers.
+type observers struct {
+	mutex    sync.RWMutex
+	register []Observer
+	remove   []Observer
+	handler  func(error)
+}
+
+// onRegister adds an observer notified after a value is stored.
+func (o *observers) onRegister(fn Observer) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	o.register = append(o.register, fn)
+}
+
+// onDelete adds an observer notified after a value is removed.
+func (o *observers) onDelete(fn Observer) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	o.remove = append(o.remove, fn)
+}
+
+// onError sets the handler receiving panics recovered from observers.
+func (o *observers) onError(fn func(error)) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	o.handler = fn
+}
+
+// notifyRegister calls the register observers in the order they were added.
+func (o *observers) notifyRegister(key RegistryKey, rv reflect.Value) {
+	o.mutex.RLock()
+	fns, handler := slices.Clone(o.register), o.handler
+	o.mutex.RUnlock()
+
+	for _, fn := range fns {
+		observe(fn, handler, key, rv)
+	}
+}
+
+// notifyDelete calls the delete observers in the order they were added.
+func (o *observers) notifyDelete(key RegistryKey, rv reflect.Value) {
+	o.mutex.RLock()
+	fns, handler := slices.Clone(o.remove), o.handler
+	o.mutex.RUnlock()
+
+	for _, fn := range fns {
+		observe(fn, handler, key, rv)
+	}
+}
+
+// observe calls the observer, recovering a panic and passing it to the handler, if any.
+func observe(fn Observer, handler func(error), key RegistryKey, rv reflect.Value) {
+	defer func() {
+		if rec := recover(); rec != nil && handler != nil {
+			handler(fmt.Errorf("%w for type %s with tag '%s': %v", ErrObserverPanic, key.Type, key.Tag, rec))
+		}
+	}()
+
+	fn(key, rv)
+}
+
+// OnRegister adds an observer called synchronously after every successful registration,
+// including factory results cached by the injector.
+func (r *SyncMapRegistry) OnRegister(fn Observer) {
+	r.observers.onRegister(fn)
+}
+
+// OnDelete adds an observer called synchronously after every removed entry with the removed value.
+func (r *SyncMapRegistry) OnDelete(fn Observer) {
+	r.observers.onDelete(fn)
+}
+
+// OnObserverError sets the handler receiving ErrObserverPanic errors for panics recovered from observers.
+// Without a handler such panics are recovered and dropped.
+func (r *SyncMapRegistry) OnObserverError(fn func(error)) {
+	r.observers.onError(fn)
+}
+
+// OnRegister adds an observer called synchronously after every successful registration,
+// including factory results cached by the injector.
+func (r *MapRegistry) OnRegister(fn Observer) {
+	r.observers.onRegister(fn)
+}
+
+// OnDelete adds an observer called synchronously after every removed entry with the removed value.
+func (r *MapRegistry) OnDelete(fn Observer) {
+	r.observers.onDelete(fn)
+}
+
+// OnObserverError sets the handler receiving ErrObserverPanic errors for panics recovered from observers.
+// Without a handler such panics are recovered and dropped.
+func (r *MapRegistry) OnObserverError(fn func(error)) {
+	r.observers.onError(fn)
+}
+
+// OnRegister adds an observer called synchronously after every successful registration,
+// including factory results cached by the injector.
+func (r *ShardedRegistry) OnRegister(fn Observer) {
+	for _, shard := range r.shards {
+		shard.OnRegister(fn)
+	}
+}
+
+// OnDelete adds an observer called synchronously after every removed entry with the removed value.
+func (r *ShardedRegistry) OnDelete(fn Observer) {
+	for _, shard := range r.shards {
+		shard.OnDelete(fn)
+	}
+}
+
+// OnObserverError sets the handler receiving ErrObserverPanic errors for panics recovered from observers.
+// Without a handler such panics are recovered and dropped.
+func (r *ShardedRegistry) OnObserverError(fn func(error)) {
+	for _, shard := range r.shards {
+		shard.OnObserverError(fn)
+	}
+}
@@ -0,0 +1,186 @@
+package dino
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"reflect"
+	"slices"
+)
+
+// ScopedRegistry is a view of a registry restricted to a single scope. Keys passed to the view are
+// stamped with its scope, so scoped containers can share one registry without seeing each other's
+// entries. Lookups that miss in the scope fall back to the unscoped namespace.
+type ScopedRegistry struct {
+	registry Registry
+	scope    string
+}
+
+// WithinScope returns a view of the registry restricted to the named scope.
+// The view of the empty scope behaves exactly like the registry itself.
+func WithinScope(registry Registry, name string) *ScopedRegistry {
+	return &ScopedRegistry{
+		registry: registry,
+		scope:    name,
+	}
+}
+
+// WithinScope returns a view of the registry restricted to the named scope.
+func (r *SyncMapRegistry) WithinScope(name string) *ScopedRegistry {
+	return WithinScope(r, name)
+}
+
+// WithinScope returns a view of the registry restricted to the named scope.
+func (r *MapRegistry) WithinScope(name string) *ScopedRegistry {
+	return WithinScope(r, name)
+}
+
+// WithinScope returns a view of the registry restricted to the named scope.
+func (r *ShardedRegistry) WithinScope(name string) *ScopedRegistry {
+	return WithinScope(r, name)
+}
+
+// Scope returns the name of the scope the view is restricted to.
+func (r *ScopedRegistry) Scope() string {
+	return r.scope
+}
+
+// Register stores the value in the scope of the view.
+func (r *ScopedRegistry) Register(key RegistryKey, rv reflect.Value) error {
+	return r.registry.Register(r.stamp(key), rv)
+}
+
+// RegisterIfAbsent stores the value in the scope of the view unless the scope already holds one
+// for the key.
+func (r *ScopedRegistry) RegisterIfAbsent(key RegistryKey, rv reflect.Value) (reflect.Value, bool, error) {
+	return r.registry.RegisterIfAbsent(r.stamp(key), rv)
+}
+
+// Find looks up the key in the scope of the view, then in the unscoped namespace.
+func (r *ScopedRegistry) Find(key RegistryKey) (reflect.Value, error) {
+	rv, err := r.registry.Find(r.stamp(key))
+	if r.scope == "" || !errors.Is(err, ErrValueNotFound) {
+		return rv, err
+	}
+
+	key.Scope = ""
+
+	return r.registry.Find(key)
+}
+
+// FindByType returns the keys of all values visible in the scope for the specified type,
+// sorted by tag. Unscoped keys shadowed by a scoped entry are left out.
+func (r *ScopedRegistry) FindByType(rt reflect.Type) []RegistryKey {
+	return r.visible(r.registry.FindByType(rt))
+}
+
+// FindAssignableTo returns the keys of all values visible in the scope for a type assignable to rt,
+// sorted by type name and tag. Unscoped keys shadowed by a scoped entry are left out.
+func (r *ScopedRegistry) FindAssignableTo(rt reflect.Type) []RegistryKey {
+	return r.visible(r.registry.FindAssignableTo(rt))
+}
+
+// Delete removes the value stored for the key in the scope of the view.
+// The unscoped namespace is never modified.
+func (r *ScopedRegistry) Delete(key RegistryKey) error {
+	return r.registry.Delete(r.stamp(key))
+}
+
+// DeleteAll removes every value of the specified type stored in the scope of the view.
+func (r *ScopedRegistry) DeleteAll(rt reflect.Type) error {
+	if rt == nil {
+		return ErrKeyTypeNil
+	}
+
+	if r.scope == "" {
+		return r.registry.DeleteAll(rt)
+	}
+
+	return r.delete(r.scoped(slices.Values(r.registry.FindByType(rt))))
+}
+
+// DeleteScope removes every value stored in the scope of the view in one call.
+func (r *ScopedRegistry) DeleteScope() error {
+	keys := func(yield func(RegistryKey) bool) {
+		for key := range r.registry.All() {
+			if !yield(key) {
+				return
+			}
+		}
+	}
+
+	return r.delete(r.scoped(keys))
+}
+
+// All returns an iterator over the keys and values visible in the scope, in no particular order.
+// Unscoped entries shadowed by a scoped entry are left out.
+func (r *ScopedRegistry) All() iter.Seq2[RegistryKey, reflect.Value] {
+	return func(yield func(RegistryKey, reflect.Value) bool) {
+		for key, rv := range r.registry.All() {
+			if !r.isVisible(key) {
+				continue
+			}
+
+			if !yield(key, rv) {
+				return
+			}
+		}
+	}
+}
+
+// stamp returns the key moved to the scope of the view.
+func (r *ScopedRegistry) stamp(key RegistryKey) RegistryKey {
+	key.Scope = r.scope
+
+	return key
+}
+
+// isVisible reports whether the key belongs to the scope, or is unscoped and not shadowed by it.
+func (r *ScopedRegistry) isVisible(key RegistryKey) bool {
+	switch key.Scope {
+	case r.scope:
+		return true
+	case "":
+		_, err := r.registry.Find(r.stamp(key))
+
+		return err != nil
+	default:
+		return false
+	}
+}
+
+// visible keeps the keys that are visible in the scope, preserving their order.
+func (r *ScopedRegistry) visible(keys []RegistryKey) []RegistryKey {
+	return slices.DeleteFunc(keys, func(key RegistryKey) bool {
+		return !r.isVisible(key)
+	})
+}
+
+// scoped collects the keys that belong to the scope of the view.
+func (r *ScopedRegistry) scoped(keys iter.Seq[RegistryKey]) []RegistryKey {
+	var owned []RegistryKey
+
+	for key := range keys {
+		if key.Scope == r.scope {
+			owned = append(owned, key)
+		}
+	}
+
+	return owned
+}
+
+// delete removes the keys from the underlying registry.
+func (r *ScopedRegistry) delete(keys []RegistryKey) error {
+	var errs []error
+
+	for _, key := range keys {
+		if err := r.registry.Delete(key); err != nil && !errors.Is(err, ErrValueNotFound) {
+			errs = append(errs, fmt.Errorf("delete %s (tag %q) from scope %q: %w", key.Type, key.Tag, r.scope, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Ensure ScopedRegistry implements the Registry interface.
+var _ Registry = (*ScopedRegistry)(nil)
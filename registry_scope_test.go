@@ -0,0 +1,161 @@
+package dino_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/yuppyweb/dino"
+)
+
+func TestScopedRegistry_SeparatesScopes(t *testing.T) {
+	t.Parallel()
+
+	forEachRegistry(t, func(t *testing.T, registry testRegistry) {
+		t.Helper()
+
+		key := dino.RegistryKey{
+			Tag:   "request",
+			Type:  reflect.TypeFor[int](),
+			Scope: "",
+		}
+
+		first := dino.WithinScope(registry, "first")
+		second := dino.WithinScope(registry, "second")
+
+		if err := first.Register(key, reflect.ValueOf(1)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if err := second.Register(key, reflect.ValueOf(2)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if rv, err := first.Find(key); err != nil || rv.Int() != 1 {
+			t.Fatalf("expected 1 in first scope, got %v (%v)", rv, err)
+		}
+
+		if rv, err := second.Find(key); err != nil || rv.Int() != 2 {
+			t.Fatalf("expected 2 in second scope, got %v (%v)", rv, err)
+		}
+
+		if _, err := registry.Find(key); !errors.Is(err, dino.ErrValueNotFound) {
+			t.Fatalf("expected scoped entries to stay out of the unscoped namespace, got %v", err)
+		}
+
+		if keys := first.FindByType(key.Type); len(keys) != 1 || keys[0].Scope != "first" {
+			t.Fatalf("expected only the first scope key, got %v", keys)
+		}
+	})
+}
+
+func TestScopedRegistry_FallsBackToUnscoped(t *testing.T) {
+	t.Parallel()
+
+	forEachRegistry(t, func(t *testing.T, registry testRegistry) {
+		t.Helper()
+
+		shared := dino.RegistryKey{
+			Tag:   "",
+			Type:  reflect.TypeFor[string](),
+			Scope: "",
+		}
+
+		shadowed := dino.RegistryKey{
+			Tag:   "",
+			Type:  reflect.TypeFor[int](),
+			Scope: "",
+		}
+
+		if err := registry.Register(shared, reflect.ValueOf("shared")); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if err := registry.Register(shadowed, reflect.ValueOf(1)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		scope := dino.WithinScope(registry, "request")
+
+		if err := scope.Register(shadowed, reflect.ValueOf(2)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if rv, err := scope.Find(shared); err != nil || rv.String() != "shared" {
+			t.Fatalf("expected fallback to the unscoped value, got %v (%v)", rv, err)
+		}
+
+		if rv, err := scope.Find(shadowed); err != nil || rv.Int() != 2 {
+			t.Fatalf("expected the scoped value to shadow the unscoped one, got %v (%v)", rv, err)
+		}
+
+		if rv, err := registry.Find(shadowed); err != nil || rv.Int() != 1 {
+			t.Fatalf("expected the unscoped value to be untouched, got %v (%v)", rv, err)
+		}
+
+		count := 0
+
+		for key := range scope.All() {
+			if key.Type == shadowed.Type && key.Scope == "" {
+				t.Fatalf("expected the shadowed unscoped entry to be hidden")
+			}
+
+			count++
+		}
+
+		if count != 2 {
+			t.Fatalf("expected 2 visible entries, got %d", count)
+		}
+	})
+}
+
+func TestScopedRegistry_DeleteScope(t *testing.T) {
+	t.Parallel()
+
+	forEachRegistry(t, func(t *testing.T, registry testRegistry) {
+		t.Helper()
+
+		unscoped := dino.RegistryKey{
+			Tag:   "",
+			Type:  reflect.TypeFor[int](),
+			Scope: "",
+		}
+
+		if err := registry.Register(unscoped, reflect.ValueOf(0)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		torndown := dino.WithinScope(registry, "torndown")
+		kept := dino.WithinScope(registry, "kept")
+
+		for _, scope := range []*dino.ScopedRegistry{torndown, kept} {
+			for _, tag := range []string{"", "a", "b"} {
+				key := dino.RegistryKey{
+					Tag:   tag,
+					Type:  reflect.TypeFor[int](),
+					Scope: "",
+				}
+
+				if err := scope.Register(key, reflect.ValueOf(len(tag))); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			}
+		}
+
+		if err := torndown.DeleteScope(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if registry.Len() != 4 {
+			t.Fatalf("expected the unscoped and kept entries to remain, got %d entries", registry.Len())
+		}
+
+		if rv, err := torndown.Find(unscoped); err != nil || rv.Int() != 0 {
+			t.Fatalf("expected fallback to the unscoped value after teardown, got %v (%v)", rv, err)
+		}
+
+		if keys := kept.FindByType(unscoped.Type); len(keys) != 3 {
+			t.Fatalf("expected the kept scope to be untouched, got %v", keys)
+		}
+	})
+}
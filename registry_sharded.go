@@ -0,0 +1,222 @@
+package dino
+
+import (
+	"errors"
+	"hash/maphash"
+	"iter"
+	"reflect"
+)
+
+// ShardedRegistry is a thread-safe implementation of the Registry interface that spreads its entries
+// across several MapRegistry shards, each guarded by its own lock, to reduce contention under heavy
+// parallel use. Keys are assigned to shards by hashing their type and tag.
+type ShardedRegistry struct {
+	seed   maphash.Seed
+	shards []*MapRegistry
+}
+
+// NewShardedRegistry creates an empty ShardedRegistry with the specified number of shards, at least one.
+func NewShardedRegistry(shards int) *ShardedRegistry {
+	registry := &ShardedRegistry{
+		seed:   maphash.MakeSeed(),
+		shards: make([]*MapRegistry, max(shards, 1)),
+	}
+
+	for idx := range registry.shards {
+		registry.shards[idx] = NewMapRegistry()
+	}
+
+	return registry
+}
+
+// shardIndex returns the index of the shard holding the specified key.
+func (r *ShardedRegistry) shardIndex(key RegistryKey) uint64 {
+	return maphash.Comparable(r.seed, key) % uint64(len(r.shards))
+}
+
+// shard returns the shard holding the specified key.
+func (r *ShardedRegistry) shard(key RegistryKey) *MapRegistry {
+	return r.shards[r.shardIndex(key)]
+}
+
+// Register stores a value in the registry with the specified key.
+func (r *ShardedRegistry) Register(key RegistryKey, rv reflect.Value) error {
+	return r.shard(key).Register(key, rv)
+}
+
+// RegisterIfAbsent stores a value in the registry with the specified key unless a value is already stored.
+// It returns the stored value and true if the key was already present, or rv and false if rv was stored.
+func (r *ShardedRegistry) RegisterIfAbsent(key RegistryKey, rv reflect.Value) (reflect.Value, bool, error) {
+	return r.shard(key).RegisterIfAbsent(key, rv)
+}
+
+// Find looks up a value in the registry based on the specified key.
+func (r *ShardedRegistry) Find(key RegistryKey) (reflect.Value, error) {
+	if key.Type == nil {
+		return reflect.Value{}, ErrKeyTypeNil
+	}
+
+	return r.shard(key).Find(key)
+}
+
+// FindByType returns the keys of all values registered for the specified type, sorted by tag.
+func (r *ShardedRegistry) FindByType(rt reflect.Type) []RegistryKey {
+	var keys []RegistryKey
+
+	for _, shard := range r.shards {
+		keys = append(keys, shard.FindByType(rt)...)
+	}
+
+	sortKeysByTag(keys)
+
+	return keys
+}
+
+// FindAssignableTo returns the keys of all values registered for a type assignable to rt,
+// e.g. every implementation of an interface, sorted by type name and tag.
+func (r *ShardedRegistry) FindAssignableTo(rt reflect.Type) []RegistryKey {
+	var keys []RegistryKey
+
+	for _, shard := range r.shards {
+		keys = append(keys, shard.FindAssignableTo(rt)...)
+	}
+
+	sortKeys(keys)
+
+	return keys
+}
+
+// Delete removes the value registered with the specified key.
+func (r *ShardedRegistry) Delete(key RegistryKey) error {
+	if key.Type == nil {
+		return ErrKeyTypeNil
+	}
+
+	return r.shard(key).Delete(key)
+}
+
+// DeleteAll removes the values registered for the specified type under every tag.
+// It returns ErrValueNotFound if nothing was registered for the type.
+func (r *ShardedRegistry) DeleteAll(rt reflect.Type) error {
+	if rt == nil {
+		return ErrKeyTypeNil
+	}
+
+	deleted := false
+
+	for _, shard := range r.shards {
+		err := shard.DeleteAll(rt)
+		if err == nil {
+			deleted = true
+		} else if !errors.Is(err, ErrValueNotFound) {
+			return err
+		}
+	}
+
+	if !deleted {
+		return ErrValueNotFound
+	}
+
+	return nil
+}
+
+// All returns an iterator over the keys and values stored in the registry, shard by shard.
+func (r *ShardedRegistry) All() iter.Seq2[RegistryKey, reflect.Value] {
+	return func(yield func(RegistryKey, reflect.Value) bool) {
+		for _, shard := range r.shards {
+			for key, rv := range shard.All() {
+				if !yield(key, rv) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Keys returns the keys of all values stored in the registry, sorted by type name and tag.
+func (r *ShardedRegistry) Keys() []RegistryKey {
+	var keys []RegistryKey
+
+	for _, shard := range r.shards {
+		keys = append(keys, shard.Keys()...)
+	}
+
+	sortKeys(keys)
+
+	return keys
+}
+
+// Clone returns a new registry holding every key and value stored in this one.
+// The copy is shallow: the stored reflect.Values, and the pointers they may hold, are shared,
+// but registering or deleting in one registry afterwards does not affect the other. Observers are not copied.
+// Each shard is copied atomically, but not the registry as a whole.
+func (r *ShardedRegistry) Clone() *ShardedRegistry {
+	clone := &ShardedRegistry{
+		seed:   r.seed,
+		shards: make([]*MapRegistry, len(r.shards)),
+	}
+
+	for idx, shard := range r.shards {
+		clone.shards[idx] = shard.Clone()
+	}
+
+	return clone
+}
+
+// Len returns the number of values stored in the registry.
+func (r *ShardedRegistry) Len() int {
+	count := 0
+
+	for _, shard := range r.shards {
+		count += shard.Len()
+	}
+
+	return count
+}
+
+// CountType returns the number of values stored for the specified type under any tag.
+func (r *ShardedRegistry) CountType(rt reflect.Type) int {
+	count := 0
+
+	for _, shard := range r.shards {
+		count += shard.CountType(rt)
+	}
+
+	return count
+}
+
+// Snapshot returns a copy of the entries currently stored in the registry.
+func (r *ShardedRegistry) Snapshot() RegistrySnapshot {
+	return takeSnapshot(r)
+}
+
+// Restore replaces the entries of the registry with the ones of the snapshot.
+// Each shard is replaced atomically, but not the registry as a whole.
+func (r *ShardedRegistry) Restore(snapshot RegistrySnapshot) error {
+	entries := make([]map[RegistryKey]reflect.Value, len(r.shards))
+
+	for idx := range entries {
+		entries[idx] = make(map[RegistryKey]reflect.Value)
+	}
+
+	for key, rv := range snapshot.entries {
+		entries[r.shardIndex(key)][key] = rv
+	}
+
+	for idx, shard := range r.shards {
+		shardSnapshot := RegistrySnapshot{
+			entries:   entries[idx],
+			settings:  nil,
+			lifecycle: nil,
+		}
+
+		if err := shard.Restore(shardSnapshot); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Ensure ShardedRegistry implements the Registry interface.
+var _ Registry = (*ShardedRegistry)(nil)
@@ -2,254 +2,297 @@ package dino_test
 
 import (
 	"errors"
+	"fmt"
 	"reflect"
+	"slices"
 	"strconv"
+	"strings"
 	"sync"
 	"testing"
 
 	"github.com/yuppyweb/dino"
+	"github.com/yuppyweb/dino/dinotest"
 )
 
-type MockRegistry struct {
-	RegisterOn []struct {
-		Key   dino.RegistryKey
-		Value reflect.Value
-	}
-	RegisterOut []error
-	FindOn      []dino.RegistryKey
-	FindOut     []struct {
-		Value reflect.Value
-		Err   error
-	}
-	numRegOut  int
-	numFindOut int
+// testRegistry is the method set shared by the registry implementations shipped with the package.
+type testRegistry interface {
+	dino.Registry
+	Keys() []dino.RegistryKey
+	Len() int
+	CountType(rt reflect.Type) int
+	Snapshot() dino.RegistrySnapshot
+	Restore(snapshot dino.RegistrySnapshot) error
+	OnRegister(fn dino.Observer)
+	OnDelete(fn dino.Observer)
+	OnObserverError(fn func(error))
 }
 
-func NewMockRegistry() *MockRegistry {
-	return &MockRegistry{
-		RegisterOn: []struct {
-			Key   dino.RegistryKey
-			Value reflect.Value
-		}{},
-		RegisterOut: []error{},
-		FindOn:      []dino.RegistryKey{},
-		FindOut: []struct {
-			Value reflect.Value
-			Err   error
-		}{},
-		numRegOut:  0,
-		numFindOut: 0,
-	}
-}
+// forEachRegistry runs the test in parallel against a fresh instance of every registry implementation.
+func forEachRegistry(t *testing.T, test func(t *testing.T, registry testRegistry)) {
+	t.Helper()
 
-func (m *MockRegistry) Register(key dino.RegistryKey, value reflect.Value) error {
-	m.RegisterOn = append(m.RegisterOn, struct {
-		Key   dino.RegistryKey
-		Value reflect.Value
+	implementations := []struct {
+		name     string
+		registry func() testRegistry
 	}{
-		Key:   key,
-		Value: value,
-	})
+		{
+			name:     "SyncMapRegistry",
+			registry: func() testRegistry { return new(dino.SyncMapRegistry) },
+		},
+		{
+			name:     "MapRegistry",
+			registry: func() testRegistry { return dino.NewMapRegistry() },
+		},
+		{
+			name:     "ShardedRegistry",
+			registry: func() testRegistry { return dino.NewShardedRegistry(4) },
+		},
+	}
 
-	defer func() {
-		m.numRegOut++
-	}()
+	for _, impl := range implementations {
+		t.Run(impl.name, func(t *testing.T) {
+			t.Parallel()
 
-	return m.RegisterOut[m.numRegOut]
+			test(t, impl.registry())
+		})
+	}
 }
 
-func (m *MockRegistry) Find(key dino.RegistryKey) (reflect.Value, error) {
-	m.FindOn = append(m.FindOn, key)
+// cloneRegistry clones a registry through the Clone method of its concrete type.
+func cloneRegistry(registry testRegistry) testRegistry {
+	switch reg := registry.(type) {
+	case *dino.SyncMapRegistry:
+		return reg.Clone()
 
-	defer func() {
-		m.numFindOut++
-	}()
+	case *dino.MapRegistry:
+		return reg.Clone()
 
-	return m.FindOut[m.numFindOut].Value, m.FindOut[m.numFindOut].Err
-}
+	case *dino.ShardedRegistry:
+		return reg.Clone()
 
-var _ dino.Registry = (*MockRegistry)(nil)
+	default:
+		panic("unknown registry implementation")
+	}
+}
 
 func TestRegistry_EmptyTag(t *testing.T) {
 	t.Parallel()
 
 	key := dino.RegistryKey{
-		Tag:  "",
-		Type: reflect.TypeFor[int](),
+		Tag:   "",
+		Type:  reflect.TypeFor[int](),
+		Scope: "",
 	}
 
-	registry := new(dino.SyncMapRegistry)
+	forEachRegistry(t, func(t *testing.T, registry testRegistry) {
+		if err := registry.Register(key, reflect.ValueOf(42)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
 
-	if err := registry.Register(key, reflect.ValueOf(42)); err != nil {
-		t.Fatalf("unexpected error: %v", err)
-	}
+		val, err := registry.Find(key)
+		if err != nil {
+			t.Fatalf("expected key to be found")
+		}
 
-	val, err := registry.Find(key)
-	if err != nil {
-		t.Fatalf("expected key to be found")
-	}
+		if val.Int() != 42 {
+			t.Fatalf("expected value to be 42, got %d", val.Int())
+		}
+	})
+}
 
-	if val.Int() != 42 {
-		t.Fatalf("expected value to be 42, got %d", val.Int())
-	}
+func TestRegistry_FindByTagPrefix(t *testing.T) {
+	t.Parallel()
+
+	forEachRegistry(t, func(t *testing.T, registry testRegistry) {
+		for idx, tag := range []string{"driver:postgres", "cache:redis", "driver:mysql", "driver:sqlite", "driver"} {
+			key := dino.RegistryKey{Tag: tag, Type: reflect.TypeFor[int](), Scope: ""}
+
+			if err := registry.Register(key, reflect.ValueOf(idx)); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+
+		keys := dino.FindByTagPrefix(registry, reflect.TypeFor[int](), "driver:")
+
+		tags := make([]string, len(keys))
+		for idx, key := range keys {
+			tags[idx] = key.Tag
+		}
+
+		expected := []string{"driver:mysql", "driver:postgres", "driver:sqlite"}
+		if !slices.Equal(tags, expected) {
+			t.Fatalf("expected %v, got %v", expected, tags)
+		}
+
+		if keys := dino.FindByTagPrefix(registry, reflect.TypeFor[string](), "driver:"); len(keys) != 0 {
+			t.Fatalf("expected no keys for another type, got %v", keys)
+		}
+	})
 }
 
 func TestRegistry_FilledTag(t *testing.T) {
 	t.Parallel()
 
 	key := dino.RegistryKey{
-		Tag:  "test",
-		Type: reflect.TypeFor[string](),
+		Tag:   "test",
+		Type:  reflect.TypeFor[string](),
+		Scope: "",
 	}
 
-	registry := new(dino.SyncMapRegistry)
-
-	if err := registry.Register(key, reflect.ValueOf("hello")); err != nil {
-		t.Fatalf("unexpected error: %v", err)
-	}
+	forEachRegistry(t, func(t *testing.T, registry testRegistry) {
+		if err := registry.Register(key, reflect.ValueOf("hello")); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
 
-	val, err := registry.Find(key)
-	if err != nil {
-		t.Fatalf("expected key to be found")
-	}
+		val, err := registry.Find(key)
+		if err != nil {
+			t.Fatalf("expected key to be found")
+		}
 
-	if val.String() != "hello" {
-		t.Fatalf("expected value to be 'hello', got %s", val.String())
-	}
+		if val.String() != "hello" {
+			t.Fatalf("expected value to be 'hello', got %s", val.String())
+		}
+	})
 }
 
 func TestRegistry_DifferentTagsSomeTypes(t *testing.T) {
 	t.Parallel()
 
 	key1 := dino.RegistryKey{
-		Tag:  "",
-		Type: reflect.TypeFor[int](),
+		Tag:   "",
+		Type:  reflect.TypeFor[int](),
+		Scope: "",
 	}
 
 	key2 := dino.RegistryKey{
-		Tag:  "special",
-		Type: reflect.TypeFor[int](),
+		Tag:   "special",
+		Type:  reflect.TypeFor[int](),
+		Scope: "",
 	}
 
 	key3 := dino.RegistryKey{
-		Tag:  "another",
-		Type: reflect.TypeFor[int](),
-	}
-
-	registry := new(dino.SyncMapRegistry)
-
-	if err := registry.Register(key1, reflect.ValueOf(1)); err != nil {
-		t.Fatalf("unexpected error: %v", err)
-	}
-
-	if err := registry.Register(key2, reflect.ValueOf(2)); err != nil {
-		t.Fatalf("unexpected error: %v", err)
+		Tag:   "another",
+		Type:  reflect.TypeFor[int](),
+		Scope: "",
 	}
 
-	if err := registry.Register(key3, reflect.ValueOf(3)); err != nil {
-		t.Fatalf("unexpected error: %v", err)
-	}
-
-	val1, err1 := registry.Find(key1)
-	if err1 != nil {
-		t.Fatalf("expected key1 to be found")
-	}
-
-	if val1.Int() != 1 {
-		t.Fatalf("expected value for key1 to be 1, got %d", val1.Int())
-	}
-
-	val2, err2 := registry.Find(key2)
-	if err2 != nil {
-		t.Fatalf("expected key2 to be found")
-	}
-
-	if val2.Int() != 2 {
-		t.Fatalf("expected value for key2 to be 2, got %d", val2.Int())
-	}
-
-	val3, err3 := registry.Find(key3)
-	if err3 != nil {
-		t.Fatalf("expected key3 to be found")
-	}
-
-	if val3.Int() != 3 {
-		t.Fatalf("expected value for key3 to be 3, got %d", val3.Int())
-	}
+	forEachRegistry(t, func(t *testing.T, registry testRegistry) {
+		if err := registry.Register(key1, reflect.ValueOf(1)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if err := registry.Register(key2, reflect.ValueOf(2)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if err := registry.Register(key3, reflect.ValueOf(3)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		val1, err1 := registry.Find(key1)
+		if err1 != nil {
+			t.Fatalf("expected key1 to be found")
+		}
+
+		if val1.Int() != 1 {
+			t.Fatalf("expected value for key1 to be 1, got %d", val1.Int())
+		}
+
+		val2, err2 := registry.Find(key2)
+		if err2 != nil {
+			t.Fatalf("expected key2 to be found")
+		}
+
+		if val2.Int() != 2 {
+			t.Fatalf("expected value for key2 to be 2, got %d", val2.Int())
+		}
+
+		val3, err3 := registry.Find(key3)
+		if err3 != nil {
+			t.Fatalf("expected key3 to be found")
+		}
+
+		if val3.Int() != 3 {
+			t.Fatalf("expected value for key3 to be 3, got %d", val3.Int())
+		}
+	})
 }
 
 func TestRegistry_OverwriteWithSomeKeys(t *testing.T) {
 	t.Parallel()
 
 	key := dino.RegistryKey{
-		Tag:  "duplicate",
-		Type: reflect.TypeFor[int](),
+		Tag:   "duplicate",
+		Type:  reflect.TypeFor[int](),
+		Scope: "",
 	}
 
-	registry := new(dino.SyncMapRegistry)
+	forEachRegistry(t, func(t *testing.T, registry testRegistry) {
+		if err := registry.Register(key, reflect.ValueOf(100)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
 
-	if err := registry.Register(key, reflect.ValueOf(100)); err != nil {
-		t.Fatalf("unexpected error: %v", err)
-	}
-
-	val, err := registry.Find(key)
-	if err != nil {
-		t.Fatalf("expected key to be found")
-	}
+		val, err := registry.Find(key)
+		if err != nil {
+			t.Fatalf("expected key to be found")
+		}
 
-	if val.Int() != 100 {
-		t.Fatalf("expected value to be 100, got %d", val.Int())
-	}
+		if val.Int() != 100 {
+			t.Fatalf("expected value to be 100, got %d", val.Int())
+		}
 
-	if err := registry.Register(key, reflect.ValueOf(200)); err != nil {
-		t.Fatalf("unexpected error: %v", err)
-	}
+		if err := registry.Register(key, reflect.ValueOf(200)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
 
-	val, err = registry.Find(key)
-	if err != nil {
-		t.Fatalf("expected key to be found")
-	}
+		val, err = registry.Find(key)
+		if err != nil {
+			t.Fatalf("expected key to be found")
+		}
 
-	if val.Int() != 200 {
-		t.Fatalf("expected value to be 200, got %d", val.Int())
-	}
+		if val.Int() != 200 {
+			t.Fatalf("expected value to be 200, got %d", val.Int())
+		}
+	})
 }
 
 func TestRegistry_RegisterKeyTypeNil(t *testing.T) {
 	t.Parallel()
 
 	key := dino.RegistryKey{
-		Tag:  "niltype",
-		Type: nil,
+		Tag:   "niltype",
+		Type:  nil,
+		Scope: "",
 	}
 
-	registry := new(dino.SyncMapRegistry)
-
-	err := registry.Register(key, reflect.ValueOf(0))
-	if !errors.Is(err, dino.ErrKeyTypeNil) {
-		t.Fatalf("expected ErrKeyTypeNil, got %v", err)
-	}
+	forEachRegistry(t, func(t *testing.T, registry testRegistry) {
+		err := registry.Register(key, reflect.ValueOf(0))
+		if !errors.Is(err, dino.ErrKeyTypeNil) {
+			t.Fatalf("expected ErrKeyTypeNil, got %v", err)
+		}
+	})
 }
 
 func TestRegistry_FindKeyTypeNil(t *testing.T) {
 	t.Parallel()
 
 	key := dino.RegistryKey{
-		Tag:  "niltype",
-		Type: nil,
+		Tag:   "niltype",
+		Type:  nil,
+		Scope: "",
 	}
 
-	registry := new(dino.SyncMapRegistry)
+	forEachRegistry(t, func(t *testing.T, registry testRegistry) {
+		val, err := registry.Find(key)
 
-	val, err := registry.Find(key)
-
-	if !errors.Is(err, dino.ErrKeyTypeNil) {
-		t.Fatalf("expected ErrKeyTypeNil, got %v", err)
-	}
+		if !errors.Is(err, dino.ErrKeyTypeNil) {
+			t.Fatalf("expected ErrKeyTypeNil, got %v", err)
+		}
 
-	if val != (reflect.Value{}) {
-		t.Fatalf("expected zero reflect.Value, got %v", val)
-	}
+		if val != (reflect.Value{}) {
+			t.Fatalf("expected zero reflect.Value, got %v", val)
+		}
+	})
 }
 
 func TestRegistry_InvalidValue(t *testing.T) {
@@ -274,50 +317,53 @@ func TestRegistry_InvalidValue(t *testing.T) {
 	}
 
 	key := dino.RegistryKey{
-		Tag:  "invalid",
-		Type: reflect.TypeFor[int](),
+		Tag:   "invalid",
+		Type:  reflect.TypeFor[int](),
+		Scope: "",
 	}
 
-	registry := new(dino.SyncMapRegistry)
-
-	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			t.Parallel()
-
-			err := registry.Register(key, tc.val)
-			if !errors.Is(err, dino.ErrInvalidValue) {
-				t.Fatalf("expected ErrInvalidValue, got %v", err)
-			}
-		})
-	}
+	forEachRegistry(t, func(t *testing.T, registry testRegistry) {
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+
+				err := registry.Register(key, tc.val)
+				if !errors.Is(err, dino.ErrInvalidValue) {
+					t.Fatalf("expected ErrInvalidValue, got %v", err)
+				}
+			})
+		}
+	})
 }
 
 func TestRegistry_ValueNotFound(t *testing.T) {
 	t.Parallel()
 
 	key := dino.RegistryKey{
-		Tag:  "missing",
-		Type: reflect.TypeFor[string](),
+		Tag:   "missing",
+		Type:  reflect.TypeFor[string](),
+		Scope: "",
 	}
 
-	registry := new(dino.SyncMapRegistry)
-
-	val, err := registry.Find(key)
-	if !errors.Is(err, dino.ErrValueNotFound) {
-		t.Fatalf("expected ErrValueNotFound, got %v", err)
-	}
+	forEachRegistry(t, func(t *testing.T, registry testRegistry) {
+		val, err := registry.Find(key)
+		if !errors.Is(err, dino.ErrValueNotFound) {
+			t.Fatalf("expected ErrValueNotFound, got %v", err)
+		}
 
-	if val != reflect.Zero(key.Type) {
-		t.Fatalf("expected value to be zero value, got %v", val)
-	}
+		if val != reflect.Zero(key.Type) {
+			t.Fatalf("expected value to be zero value, got %v", val)
+		}
+	})
 }
 
 func TestRegistry_InvalidValueStored(t *testing.T) {
 	t.Parallel()
 
 	key := dino.RegistryKey{
-		Tag:  "invalid",
-		Type: reflect.TypeFor[int](),
+		Tag:   "invalid",
+		Type:  reflect.TypeFor[int](),
+		Scope: "",
 	}
 
 	registry := new(dino.SyncMapRegistry)
@@ -339,82 +385,1092 @@ func TestRegistry_DifferentTypesSameTag(t *testing.T) {
 	tag := "shared"
 
 	key1 := dino.RegistryKey{
-		Tag:  tag,
-		Type: reflect.TypeFor[int](),
+		Tag:   tag,
+		Type:  reflect.TypeFor[int](),
+		Scope: "",
 	}
 
 	key2 := dino.RegistryKey{
-		Tag:  tag,
-		Type: reflect.TypeFor[string](),
+		Tag:   tag,
+		Type:  reflect.TypeFor[string](),
+		Scope: "",
+	}
+
+	forEachRegistry(t, func(t *testing.T, registry testRegistry) {
+		if err := registry.Register(key1, reflect.ValueOf(123)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if err := registry.Register(key2, reflect.ValueOf("abc")); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		val1, err1 := registry.Find(key1)
+		if err1 != nil {
+			t.Fatalf("expected key1 to be found")
+		}
+
+		if val1.Int() != 123 {
+			t.Fatalf("expected value for key1 to be 123, got %d", val1.Int())
+		}
+
+		val2, err2 := registry.Find(key2)
+		if err2 != nil {
+			t.Fatalf("expected key2 to be found")
+		}
+
+		if val2.String() != "abc" {
+			t.Fatalf("expected value for key2 to be 'abc', got %s", val2.String())
+		}
+	})
+}
+
+func TestRegistry_ConcurrentAccess(t *testing.T) {
+	t.Parallel()
+
+	var wg sync.WaitGroup
+
+	forEachRegistry(t, func(t *testing.T, registry testRegistry) {
+		keyChan := make(chan dino.RegistryKey, 100)
+
+		for idx := range 100 {
+			wg.Go(func() {
+				key := dino.RegistryKey{
+					Tag:   strconv.Itoa(idx),
+					Type:  reflect.TypeFor[int](),
+					Scope: "",
+				}
+
+				if err := registry.Register(key, reflect.ValueOf(idx)); err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+
+				keyChan <- key
+			})
+
+			wg.Go(func() {
+				key := <-keyChan
+
+				val, err := registry.Find(key)
+				if err != nil {
+					t.Errorf("expected key to be found for goroutine %d", idx)
+				}
+
+				num, _ := strconv.Atoi(key.Tag)
+
+				if val.Int() != int64(num) {
+					t.Errorf("expected value to be %d for goroutine %d, got %d", num, num, val.Int())
+				}
+			})
+		}
+
+		wg.Wait()
+		close(keyChan)
+	})
+}
+
+func TestRegistry_RegisterTypeMismatch(t *testing.T) {
+	t.Parallel()
+
+	key := dino.RegistryKey{
+		Tag:   "",
+		Type:  reflect.TypeFor[string](),
+		Scope: "",
+	}
+
+	forEachRegistry(t, func(t *testing.T, registry testRegistry) {
+		err := registry.Register(key, reflect.ValueOf(42))
+		if !errors.Is(err, dino.ErrTypeMismatch) {
+			t.Fatalf("expected ErrTypeMismatch, got %v", err)
+		}
+
+		if err.Error() != "registry value type mismatch: int is not assignable to string" {
+			t.Fatalf("unexpected error message: %s", err.Error())
+		}
+
+		if _, err := registry.Find(key); !errors.Is(err, dino.ErrValueNotFound) {
+			t.Fatalf("expected mismatched value not to be stored, got %v", err)
+		}
+	})
+}
+
+func TestRegistry_RegisterFactoryTypeMismatch(t *testing.T) {
+	t.Parallel()
+
+	key := dino.RegistryKey{
+		Tag:   "",
+		Type:  reflect.TypeFor[string](),
+		Scope: "",
+	}
+
+	forEachRegistry(t, func(t *testing.T, registry testRegistry) {
+		err := registry.Register(key, reflect.ValueOf(func() (int, error) { return 42, nil }))
+		if !errors.Is(err, dino.ErrTypeMismatch) {
+			t.Fatalf("expected ErrTypeMismatch, got %v", err)
+		}
+
+		errMsg := "registry value type mismatch: func() (int, error) is not assignable to string"
+
+		if err.Error() != errMsg {
+			t.Fatalf("expected error message '%s', got '%s'", errMsg, err.Error())
+		}
+
+		if err := registry.Register(key, reflect.ValueOf(func() (string, error) { return "", nil })); err != nil {
+			t.Fatalf("unexpected error for factory with matching output: %v", err)
+		}
+	})
+}
+
+func TestRegistry_FindByType(t *testing.T) {
+	t.Parallel()
+
+	forEachRegistry(t, func(t *testing.T, registry testRegistry) {
+		for _, tag := range []string{"replica", "", "primary"} {
+			key := dino.RegistryKey{
+				Tag:   tag,
+				Type:  reflect.TypeFor[int](),
+				Scope: "",
+			}
+
+			if err := registry.Register(key, reflect.ValueOf(1)); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+
+		otherKey := dino.RegistryKey{
+			Tag:   "primary",
+			Type:  reflect.TypeFor[string](),
+			Scope: "",
+		}
+
+		if err := registry.Register(otherKey, reflect.ValueOf("x")); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		keys := registry.FindByType(reflect.TypeFor[int]())
+		if len(keys) != 3 {
+			t.Fatalf("expected 3 keys, got %d", len(keys))
+		}
+
+		for idx, tag := range []string{"", "primary", "replica"} {
+			if keys[idx].Tag != tag || keys[idx].Type != reflect.TypeFor[int]() {
+				t.Fatalf("expected key %d to have tag '%s', got %v", idx, tag, keys[idx])
+			}
+		}
+
+		if keys := registry.FindByType(reflect.TypeFor[bool]()); len(keys) != 0 {
+			t.Fatalf("expected no keys for unregistered type, got %v", keys)
+		}
+
+		if keys := registry.FindByType(nil); keys != nil {
+			t.Fatalf("expected nil keys for nil type, got %v", keys)
+		}
+	})
+}
+
+func TestRegistry_Delete(t *testing.T) {
+	t.Parallel()
+
+	key := dino.RegistryKey{
+		Tag:   "primary",
+		Type:  reflect.TypeFor[int](),
+		Scope: "",
+	}
+
+	forEachRegistry(t, func(t *testing.T, registry testRegistry) {
+		if err := registry.Register(key, reflect.ValueOf(42)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if err := registry.Delete(key); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if _, err := registry.Find(key); !errors.Is(err, dino.ErrValueNotFound) {
+			t.Fatalf("expected ErrValueNotFound after delete, got %v", err)
+		}
+
+		if err := registry.Delete(key); !errors.Is(err, dino.ErrValueNotFound) {
+			t.Fatalf("expected ErrValueNotFound deleting a missing key, got %v", err)
+		}
+
+		err := registry.Delete(dino.RegistryKey{Tag: "", Type: nil, Scope: ""})
+		if !errors.Is(err, dino.ErrKeyTypeNil) {
+			t.Fatalf("expected ErrKeyTypeNil, got %v", err)
+		}
+	})
+}
+
+func TestRegistry_RegisterIfAbsent(t *testing.T) {
+	t.Parallel()
+
+	key := dino.RegistryKey{
+		Tag:   "",
+		Type:  reflect.TypeFor[int](),
+		Scope: "",
+	}
+
+	forEachRegistry(t, func(t *testing.T, registry testRegistry) {
+		rv, loaded, err := registry.RegisterIfAbsent(key, reflect.ValueOf(1))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if loaded || rv.Int() != 1 {
+			t.Fatalf("expected value to be stored, got %v (loaded %v)", rv, loaded)
+		}
+
+		rv, loaded, err = registry.RegisterIfAbsent(key, reflect.ValueOf(2))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !loaded || rv.Int() != 1 {
+			t.Fatalf("expected existing value to be returned, got %v (loaded %v)", rv, loaded)
+		}
+
+		if _, _, err := registry.RegisterIfAbsent(key, reflect.ValueOf("x")); !errors.Is(err, dino.ErrTypeMismatch) {
+			t.Fatalf("expected ErrTypeMismatch, got %v", err)
+		}
+
+		nilKey := dino.RegistryKey{
+			Tag:   "",
+			Type:  nil,
+			Scope: "",
+		}
+
+		if _, _, err := registry.RegisterIfAbsent(nilKey, reflect.ValueOf(1)); !errors.Is(err, dino.ErrKeyTypeNil) {
+			t.Fatalf("expected ErrKeyTypeNil, got %v", err)
+		}
+
+		if _, _, err := registry.RegisterIfAbsent(key, reflect.Value{}); !errors.Is(err, dino.ErrInvalidValue) {
+			t.Fatalf("expected ErrInvalidValue, got %v", err)
+		}
+	})
+}
+
+func TestRegistry_RegisterIfAbsentConcurrentAccess(t *testing.T) {
+	t.Parallel()
+
+	const goroutines = 100
+
+	key := dino.RegistryKey{
+		Tag:   "",
+		Type:  reflect.TypeFor[int](),
+		Scope: "",
+	}
+
+	forEachRegistry(t, func(t *testing.T, registry testRegistry) {
+		results := make([]reflect.Value, goroutines)
+		stored := make([]bool, goroutines)
+
+		var wg sync.WaitGroup
+
+		for idx := range goroutines {
+			wg.Go(func() {
+				rv, loaded, err := registry.RegisterIfAbsent(key, reflect.ValueOf(idx))
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+
+				results[idx] = rv
+				stored[idx] = !loaded
+			})
+		}
+
+		wg.Wait()
+
+		winners := 0
+
+		for _, ok := range stored {
+			if ok {
+				winners++
+			}
+		}
+
+		if winners != 1 {
+			t.Fatalf("expected exactly one winner, got %d", winners)
+		}
+
+		final, err := registry.Find(key)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		for idx, rv := range results {
+			if rv.Int() != final.Int() {
+				t.Fatalf("goroutine %d read %v, expected stored value %v", idx, rv.Int(), final.Int())
+			}
+		}
+	})
+}
+
+func TestRegistry_DeleteAll(t *testing.T) {
+	t.Parallel()
+
+	forEachRegistry(t, func(t *testing.T, registry testRegistry) {
+		for _, tag := range []string{"", "primary", "replica"} {
+			key := dino.RegistryKey{
+				Tag:   tag,
+				Type:  reflect.TypeFor[int](),
+				Scope: "",
+			}
+
+			if err := registry.Register(key, reflect.ValueOf(1)); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+
+		otherKey := dino.RegistryKey{
+			Tag:   "primary",
+			Type:  reflect.TypeFor[string](),
+			Scope: "",
+		}
+
+		if err := registry.Register(otherKey, reflect.ValueOf("x")); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if err := registry.DeleteAll(reflect.TypeFor[int]()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if keys := registry.FindByType(reflect.TypeFor[int]()); len(keys) != 0 {
+			t.Fatalf("expected every tag variant to be removed, got %v", keys)
+		}
+
+		if _, err := registry.Find(otherKey); err != nil {
+			t.Fatalf("expected other types to be kept, got %v", err)
+		}
+
+		if err := registry.DeleteAll(reflect.TypeFor[int]()); !errors.Is(err, dino.ErrValueNotFound) {
+			t.Fatalf("expected ErrValueNotFound for a type without values, got %v", err)
+		}
+
+		if err := registry.DeleteAll(nil); !errors.Is(err, dino.ErrKeyTypeNil) {
+			t.Fatalf("expected ErrKeyTypeNil, got %v", err)
+		}
+	})
+}
+
+func TestRegistry_DeleteConcurrentAccess(t *testing.T) {
+	t.Parallel()
+
+	const goroutines = 100
+
+	forEachRegistry(t, func(t *testing.T, registry testRegistry) {
+		var wg sync.WaitGroup
+
+		for idx := range goroutines {
+			key := dino.RegistryKey{
+				Tag:   strconv.Itoa(idx % 5),
+				Type:  reflect.TypeFor[int](),
+				Scope: "",
+			}
+
+			wg.Go(func() {
+				if err := registry.Register(key, reflect.ValueOf(idx)); err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+			})
+
+			wg.Go(func() {
+				if err := registry.Delete(key); err != nil && !errors.Is(err, dino.ErrValueNotFound) {
+					t.Errorf("unexpected error: %v", err)
+				}
+			})
+
+			wg.Go(func() {
+				if _, err := registry.Find(key); err != nil && !errors.Is(err, dino.ErrValueNotFound) {
+					t.Errorf("unexpected error: %v", err)
+				}
+			})
+
+			if idx%10 == 0 {
+				wg.Go(func() {
+					if err := registry.DeleteAll(key.Type); err != nil && !errors.Is(err, dino.ErrValueNotFound) {
+						t.Errorf("unexpected error: %v", err)
+					}
+				})
+			}
+		}
+
+		wg.Wait()
+	})
+}
+
+func TestRegistry_All(t *testing.T) {
+	t.Parallel()
+
+	type Service struct{}
+
+	entries := map[dino.RegistryKey]reflect.Value{
+		{Tag: "", Type: reflect.TypeFor[int](), Scope: ""}:             reflect.ValueOf(1),
+		{Tag: "primary", Type: reflect.TypeFor[int](), Scope: ""}:      reflect.ValueOf(2),
+		{Tag: "", Type: reflect.TypeFor[string](), Scope: ""}:          reflect.ValueOf("x"),
+		{Tag: "", Type: reflect.TypeFor[*Service](), Scope: ""}:        reflect.ValueOf(&Service{}),
+		{Tag: "factory", Type: reflect.TypeFor[*Service](), Scope: ""}: reflect.ValueOf(func() *Service { return nil }),
+	}
+
+	forEachRegistry(t, func(t *testing.T, registry testRegistry) {
+		for key, rv := range entries {
+			if err := registry.Register(key, rv); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+
+		seen := make(map[dino.RegistryKey]bool)
+
+		for key, rv := range registry.All() {
+			expected, ok := entries[key]
+			if !ok {
+				t.Fatalf("unexpected key %v", key)
+			}
+
+			if rv.Type() != expected.Type() {
+				t.Fatalf("expected value of type %s for key %v, got %s", expected.Type(), key, rv.Type())
+			}
+
+			seen[key] = true
+		}
+
+		if len(seen) != len(entries) {
+			t.Fatalf("expected %d entries, got %d", len(entries), len(seen))
+		}
+
+		keys := registry.Keys()
+		if len(keys) != len(entries) {
+			t.Fatalf("expected %d keys, got %d", len(entries), len(keys))
+		}
+
+		if keys[0].Type != reflect.TypeFor[*Service]() || keys[0].Tag != "" {
+			t.Fatalf("expected keys sorted by type and tag, got %v", keys)
+		}
+
+		count := 0
+
+		for range registry.All() {
+			count++
+
+			break
+		}
+
+		if count != 1 {
+			t.Fatalf("expected iteration to stop early, got %d entries", count)
+		}
+	})
+}
+
+func TestRegistry_AllSkipsInvalidValues(t *testing.T) {
+	t.Parallel()
+
+	valid := dino.RegistryKey{
+		Tag:   "valid",
+		Type:  reflect.TypeFor[int](),
+		Scope: "",
+	}
+
+	invalid := dino.RegistryKey{
+		Tag:   "invalid",
+		Type:  reflect.TypeFor[int](),
+		Scope: "",
 	}
 
 	registry := new(dino.SyncMapRegistry)
+	registry.MockRegister(invalid, "this is not a reflect.Value")
 
-	if err := registry.Register(key1, reflect.ValueOf(123)); err != nil {
+	if err := registry.Register(valid, reflect.ValueOf(1)); err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
-	if err := registry.Register(key2, reflect.ValueOf("abc")); err != nil {
-		t.Fatalf("unexpected error: %v", err)
+	keys := registry.Keys()
+	if len(keys) != 1 || keys[0] != valid {
+		t.Fatalf("expected only the valid key, got %v", keys)
 	}
+}
+
+func TestRegistry_LenAndCountType(t *testing.T) {
+	t.Parallel()
+
+	forEachRegistry(t, func(t *testing.T, registry testRegistry) {
+		if registry.Len() != 0 {
+			t.Fatalf("expected empty registry, got %d entries", registry.Len())
+		}
+
+		for _, tag := range []string{"", "primary", "primary"} {
+			key := dino.RegistryKey{
+				Tag:   tag,
+				Type:  reflect.TypeFor[int](),
+				Scope: "",
+			}
+
+			if err := registry.Register(key, reflect.ValueOf(1)); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+
+		stringKey := dino.RegistryKey{
+			Tag:   "",
+			Type:  reflect.TypeFor[string](),
+			Scope: "",
+		}
+
+		if err := registry.Register(stringKey, reflect.ValueOf("x")); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if registry.Len() != 3 {
+			t.Fatalf("expected overwritten key to be counted once, got %d entries", registry.Len())
+		}
+
+		if count := registry.CountType(reflect.TypeFor[int]()); count != 2 {
+			t.Fatalf("expected 2 int entries, got %d", count)
+		}
+
+		if count := registry.CountType(reflect.TypeFor[bool]()); count != 0 {
+			t.Fatalf("expected no bool entries, got %d", count)
+		}
+	})
+}
 
-	val1, err1 := registry.Find(key1)
-	if err1 != nil {
-		t.Fatalf("expected key1 to be found")
+func TestRegistry_LenConcurrentAccess(t *testing.T) {
+	t.Parallel()
+
+	const goroutines = 100
+
+	forEachRegistry(t, func(t *testing.T, registry testRegistry) {
+		var wg sync.WaitGroup
+
+		for idx := range goroutines {
+			key := dino.RegistryKey{
+				Tag:   strconv.Itoa(idx),
+				Type:  reflect.TypeFor[int](),
+				Scope: "",
+			}
+
+			wg.Go(func() {
+				if err := registry.Register(key, reflect.ValueOf(idx)); err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+
+				// Every other key is removed again, overwriting it first must not count it twice
+				if idx%2 == 0 {
+					if err := registry.Register(key, reflect.ValueOf(idx)); err != nil {
+						t.Errorf("unexpected error: %v", err)
+					}
+
+					if err := registry.Delete(key); err != nil {
+						t.Errorf("unexpected error: %v", err)
+					}
+				}
+			})
+
+			wg.Go(func() {
+				_ = registry.Len()
+			})
+		}
+
+		wg.Wait()
+
+		if registry.Len() != goroutines/2 {
+			t.Fatalf("expected %d entries, got %d", goroutines/2, registry.Len())
+		}
+
+		if count := registry.CountType(reflect.TypeFor[int]()); count != goroutines/2 {
+			t.Fatalf("expected %d int entries, got %d", goroutines/2, count)
+		}
+	})
+}
+
+func TestRegistry_Clone(t *testing.T) {
+	t.Parallel()
+
+	type Service struct {
+		Name string
 	}
 
-	if val1.Int() != 123 {
-		t.Fatalf("expected value for key1 to be 123, got %d", val1.Int())
+	key := dino.RegistryKey{
+		Tag:   "",
+		Type:  reflect.TypeFor[*Service](),
+		Scope: "",
 	}
 
-	val2, err2 := registry.Find(key2)
-	if err2 != nil {
-		t.Fatalf("expected key2 to be found")
+	service := &Service{Name: "original"}
+	forEachRegistry(t, func(t *testing.T, registry testRegistry) {
+		if err := registry.Register(key, reflect.ValueOf(service)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		clone := cloneRegistry(registry)
+
+		rv, err := clone.Find(key)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if rv.Interface() != service {
+			t.Fatalf("expected clone to share the stored value")
+		}
+
+		otherKey := dino.RegistryKey{
+			Tag:   "other",
+			Type:  reflect.TypeFor[*Service](),
+			Scope: "",
+		}
+
+		if err := clone.Register(otherKey, reflect.ValueOf(&Service{Name: "other"})); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if err := registry.Delete(key); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if _, err := registry.Find(otherKey); !errors.Is(err, dino.ErrValueNotFound) {
+			t.Fatalf("expected registration in the clone not to affect the original, got %v", err)
+		}
+
+		if _, err := clone.Find(key); err != nil {
+			t.Fatalf("expected deletion in the original not to affect the clone, got %v", err)
+		}
+	})
+}
+
+func TestRegistry_CloneConcurrentAccess(t *testing.T) {
+	t.Parallel()
+
+	const writes = 1000
+
+	forEachRegistry(t, func(t *testing.T, registry testRegistry) {
+		done := make(chan struct{})
+
+		go func() {
+			defer close(done)
+
+			for idx := range writes {
+				key := dino.RegistryKey{
+					Tag:   strconv.Itoa(idx),
+					Type:  reflect.TypeFor[int](),
+					Scope: "",
+				}
+
+				if err := registry.Register(key, reflect.ValueOf(idx)); err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+			}
+		}()
+
+		for {
+			select {
+			case <-done:
+				if clone := cloneRegistry(registry); clone.Len() != writes {
+					t.Fatalf("expected final clone to hold %d entries, got %d", writes, clone.Len())
+				}
+
+				return
+
+			default:
+			}
+
+			for key, rv := range cloneRegistry(registry).All() {
+				if strconv.Itoa(int(rv.Int())) != key.Tag {
+					t.Fatalf("expected cloned value %v to match its key %v", rv, key)
+				}
+			}
+		}
+	})
+}
+
+func TestRegistry_ShardedRegistryMinimumShards(t *testing.T) {
+	t.Parallel()
+
+	key := dino.RegistryKey{
+		Tag:   "",
+		Type:  reflect.TypeFor[int](),
+		Scope: "",
 	}
 
-	if val2.String() != "abc" {
-		t.Fatalf("expected value for key2 to be 'abc', got %s", val2.String())
+	registry := dino.NewShardedRegistry(0)
+
+	if err := registry.Register(key, reflect.ValueOf(1)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := registry.Find(key); err != nil {
+		t.Fatalf("unexpected error: %v", err)
 	}
 }
 
-func TestRegistry_ConcurrentAccess(t *testing.T) {
+type indexedStringer struct{}
+
+func (indexedStringer) String() string { return "indexed" }
+
+func TestRegistry_FindAssignableTo(t *testing.T) {
 	t.Parallel()
 
-	var wg sync.WaitGroup
+	forEachRegistry(t, func(t *testing.T, registry testRegistry) {
+		entries := map[dino.RegistryKey]reflect.Value{
+			{Tag: "", Type: reflect.TypeFor[indexedStringer](), Scope: ""}:      reflect.ValueOf(indexedStringer{}),
+			{Tag: "b", Type: reflect.TypeFor[*indexedStringer](), Scope: ""}:    reflect.ValueOf(&indexedStringer{}),
+			{Tag: "a", Type: reflect.TypeFor[*indexedStringer](), Scope: ""}:    reflect.ValueOf(&indexedStringer{}),
+			{Tag: "", Type: reflect.TypeFor[int](), Scope: ""}:                  reflect.ValueOf(1),
+			{Tag: "stringer", Type: reflect.TypeFor[fmt.Stringer](), Scope: ""}: reflect.ValueOf(indexedStringer{}),
+		}
+
+		for key, rv := range entries {
+			if err := registry.Register(key, rv); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
 
-	registry := new(dino.SyncMapRegistry)
-	keyChan := make(chan dino.RegistryKey, 100)
+		keys := registry.FindAssignableTo(reflect.TypeFor[fmt.Stringer]())
 
-	for idx := range 100 {
-		wg.Go(func() {
+		expected := []dino.RegistryKey{
+			{Tag: "a", Type: reflect.TypeFor[*indexedStringer](), Scope: ""},
+			{Tag: "b", Type: reflect.TypeFor[*indexedStringer](), Scope: ""},
+			{Tag: "", Type: reflect.TypeFor[indexedStringer](), Scope: ""},
+			{Tag: "stringer", Type: reflect.TypeFor[fmt.Stringer](), Scope: ""},
+		}
+
+		if !slices.Equal(keys, expected) {
+			t.Fatalf("expected %v, got %v", expected, keys)
+		}
+
+		if err := registry.DeleteAll(reflect.TypeFor[*indexedStringer]()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if keys := registry.FindAssignableTo(reflect.TypeFor[fmt.Stringer]()); len(keys) != 2 {
+			t.Fatalf("expected deleted keys to leave the index, got %v", keys)
+		}
+
+		if keys := registry.FindAssignableTo(nil); keys != nil {
+			t.Fatalf("expected nil keys for nil type, got %v", keys)
+		}
+	})
+}
+
+func TestRegistry_TypeIndexConcurrentAccess(t *testing.T) {
+	t.Parallel()
+
+	const goroutines = 100
+
+	forEachRegistry(t, func(t *testing.T, registry testRegistry) {
+		var wg sync.WaitGroup
+
+		for idx := range goroutines {
+			key := dino.RegistryKey{
+				Tag:   strconv.Itoa(idx % 10),
+				Type:  reflect.TypeFor[int](),
+				Scope: "",
+			}
+
+			wg.Go(func() {
+				if err := registry.Register(key, reflect.ValueOf(idx)); err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+			})
+
+			wg.Go(func() {
+				if err := registry.Delete(key); err != nil && !errors.Is(err, dino.ErrValueNotFound) {
+					t.Errorf("unexpected error: %v", err)
+				}
+			})
+
+			wg.Go(func() {
+				_, _, err := registry.RegisterIfAbsent(key, reflect.ValueOf(idx))
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+			})
+		}
+
+		wg.Wait()
+
+		var stored []dino.RegistryKey
+
+		for key := range registry.All() {
+			stored = append(stored, key)
+		}
+
+		slices.SortFunc(stored, func(a, b dino.RegistryKey) int {
+			return strings.Compare(a.Tag, b.Tag)
+		})
+
+		if indexed := registry.FindByType(reflect.TypeFor[int]()); !slices.Equal(indexed, stored) {
+			t.Fatalf("expected index %v to match stored keys %v", indexed, stored)
+		}
+
+		if registry.CountType(reflect.TypeFor[int]()) != len(stored) {
+			t.Fatalf("expected count %d, got %d", len(stored), registry.CountType(reflect.TypeFor[int]()))
+		}
+	})
+}
+
+func BenchmarkRegistry_FindAssignableTo(b *testing.B) {
+	for _, size := range []int{100, 10000} {
+		registry := new(dino.SyncMapRegistry)
+
+		for idx := range size {
 			key := dino.RegistryKey{
-				Tag:  strconv.Itoa(idx),
-				Type: reflect.TypeFor[int](),
+				Tag:   strconv.Itoa(idx),
+				Type:  reflect.TypeFor[int](),
+				Scope: "",
 			}
 
 			if err := registry.Register(key, reflect.ValueOf(idx)); err != nil {
-				t.Errorf("unexpected error: %v", err)
+				b.Fatalf("unexpected error: %v", err)
 			}
+		}
+
+		key := dino.RegistryKey{
+			Tag:   "",
+			Type:  reflect.TypeFor[indexedStringer](),
+			Scope: "",
+		}
 
-			keyChan <- key
+		if err := registry.Register(key, reflect.ValueOf(indexedStringer{})); err != nil {
+			b.Fatalf("unexpected error: %v", err)
+		}
+
+		b.Run(strconv.Itoa(size), func(b *testing.B) {
+			for b.Loop() {
+				registry.FindAssignableTo(reflect.TypeFor[fmt.Stringer]())
+			}
 		})
+	}
+}
 
-		wg.Go(func() {
-			key := <-keyChan
+func TestRegistry_Observers(t *testing.T) {
+	t.Parallel()
+
+	forEachRegistry(t, func(t *testing.T, registry testRegistry) {
+		var registered, deleted []dino.RegistryKey
 
-			val, err := registry.Find(key)
-			if err != nil {
-				t.Errorf("expected key to be found for goroutine %d", idx)
+		registry.OnRegister(func(key dino.RegistryKey, _ reflect.Value) {
+			registered = append(registered, key)
+		})
+
+		registry.OnDelete(func(key dino.RegistryKey, rv reflect.Value) {
+			if rv.Int() != 1 {
+				t.Errorf("expected deleted value to be passed, got %v", rv)
 			}
 
-			num, _ := strconv.Atoi(key.Tag)
+			deleted = append(deleted, key)
+		})
+
+		key := dino.RegistryKey{
+			Tag:   "observed",
+			Type:  reflect.TypeFor[int](),
+			Scope: "",
+		}
+
+		if err := registry.Register(key, reflect.ValueOf(1)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if _, _, err := registry.RegisterIfAbsent(key, reflect.ValueOf(2)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if err := registry.Register(dino.RegistryKey{Tag: "", Type: nil, Scope: ""}, reflect.ValueOf(1)); err == nil {
+			t.Fatal("expected error registering a nil type")
+		}
 
-			if val.Int() != int64(num) {
-				t.Errorf("expected value to be %d for goroutine %d, got %d", num, num, val.Int())
+		if err := registry.Delete(key); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(registered) != 1 || registered[0] != key {
+			t.Fatalf("expected one successful registration to be observed, got %v", registered)
+		}
+
+		if len(deleted) != 1 || deleted[0] != key {
+			t.Fatalf("expected one deletion to be observed, got %v", deleted)
+		}
+	})
+}
+
+func TestRegistry_ObserverPanic(t *testing.T) {
+	t.Parallel()
+
+	forEachRegistry(t, func(t *testing.T, registry testRegistry) {
+		var reported []error
+
+		registry.OnObserverError(func(err error) {
+			reported = append(reported, err)
+		})
+
+		registry.OnRegister(func(dino.RegistryKey, reflect.Value) {
+			panic("observer failed")
+		})
+
+		called := false
+
+		registry.OnRegister(func(dino.RegistryKey, reflect.Value) {
+			called = true
+		})
+
+		key := dino.RegistryKey{
+			Tag:   "",
+			Type:  reflect.TypeFor[int](),
+			Scope: "",
+		}
+
+		if err := registry.Register(key, reflect.ValueOf(1)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !called {
+			t.Fatal("expected later observers to be called after a panic")
+		}
+
+		if len(reported) != 1 || !errors.Is(reported[0], dino.ErrObserverPanic) {
+			t.Fatalf("expected ErrObserverPanic to be reported, got %v", reported)
+		}
+
+		if !strings.Contains(reported[0].Error(), "observer failed") {
+			t.Fatalf("expected reported error to contain the panic value, got '%s'", reported[0].Error())
+		}
+
+		if _, err := registry.Find(key); err != nil {
+			t.Fatalf("expected value to be registered despite the panic, got %v", err)
+		}
+	})
+}
+
+func BenchmarkRegistry(b *testing.B) {
+	implementations := []struct {
+		name     string
+		registry func() dino.Registry
+	}{
+		{
+			name:     "SyncMapRegistry",
+			registry: func() dino.Registry { return new(dino.SyncMapRegistry) },
+		},
+		{
+			name:     "ShardedRegistry",
+			registry: func() dino.Registry { return dino.NewShardedRegistry(16) },
+		},
+	}
+
+	keys := make([]dino.RegistryKey, 256)
+	for idx := range keys {
+		keys[idx] = dino.RegistryKey{
+			Tag:   strconv.Itoa(idx),
+			Type:  reflect.TypeFor[int](),
+			Scope: "",
+		}
+	}
+
+	for _, impl := range implementations {
+		for _, goroutines := range []int{1, 8, 64} {
+			registry := impl.registry()
+
+			for idx, key := range keys {
+				if err := registry.Register(key, reflect.ValueOf(idx)); err != nil {
+					b.Fatalf("unexpected error: %v", err)
+				}
+			}
+
+			b.Run(impl.name+"/Find/"+strconv.Itoa(goroutines), func(b *testing.B) {
+				runConcurrently(b, goroutines, func(idx int) {
+					_, _ = registry.Find(keys[idx%len(keys)])
+				})
+			})
+
+			b.Run(impl.name+"/Register/"+strconv.Itoa(goroutines), func(b *testing.B) {
+				runConcurrently(b, goroutines, func(idx int) {
+					_ = registry.Register(keys[idx%len(keys)], reflect.ValueOf(idx))
+				})
+			})
+		}
+	}
+}
+
+// runConcurrently splits b.N calls of op across the specified number of goroutines.
+func runConcurrently(b *testing.B, goroutines int, op func(idx int)) {
+	b.Helper()
+
+	var wg sync.WaitGroup
+
+	b.ResetTimer()
+
+	for worker := range goroutines {
+		wg.Go(func() {
+			for idx := worker; idx < b.N; idx += goroutines {
+				op(idx)
 			}
 		})
 	}
 
 	wg.Wait()
-	close(keyChan)
+}
+
+type registryGetService struct {
+	Name string
+}
+
+func TestRegistryGet(t *testing.T) {
+	t.Parallel()
+
+	forEachRegistry(t, func(t *testing.T, registry testRegistry) {
+		t.Helper()
+
+		service := &registryGetService{Name: "svc"}
+
+		entries := map[dino.RegistryKey]reflect.Value{
+			{Tag: "", Type: reflect.TypeFor[int](), Scope: ""}:                    reflect.ValueOf(42),
+			{Tag: "ptr", Type: reflect.TypeFor[*registryGetService](), Scope: ""}: reflect.ValueOf(service),
+			{Tag: "", Type: reflect.TypeFor[fmt.Stringer](), Scope: ""}:           reflect.ValueOf(indexedStringer{}),
+			{Tag: "factory", Type: reflect.TypeFor[*registryGetService](), Scope: ""}: reflect.ValueOf(
+				func() *registryGetService { return service },
+			),
+		}
+
+		for key, rv := range entries {
+			if err := registry.Register(key, rv); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+
+		if val, err := dino.RegistryGet[int](registry, ""); err != nil || val != 42 {
+			t.Fatalf("expected 42, got %d (%v)", val, err)
+		}
+
+		if val, err := dino.RegistryGet[*registryGetService](registry, "ptr"); err != nil || val != service {
+			t.Fatalf("expected the registered pointer, got %v (%v)", val, err)
+		}
+
+		if val, err := dino.RegistryGet[fmt.Stringer](registry, ""); err != nil || val.String() != "indexed" {
+			t.Fatalf("expected the registered stringer, got %v (%v)", val, err)
+		}
+
+		_, err := dino.RegistryGet[*registryGetService](registry, "factory")
+		if !errors.Is(err, dino.ErrStoredFactory) {
+			t.Fatalf("expected ErrStoredFactory, got %v", err)
+		}
+
+		if _, err := dino.RegistryGet[string](registry, ""); !errors.Is(err, dino.ErrValueNotFound) {
+			t.Fatalf("expected ErrValueNotFound, got %v", err)
+		}
+	})
+}
+
+func TestRegistryGet_TypeMismatch(t *testing.T) {
+	t.Parallel()
+
+	// Registries shipped with the package reject such values, a custom registry may not
+	registry := dinotest.NewMockRegistry()
+	registry.FindOut = append(registry.FindOut, struct {
+		Value reflect.Value
+		Err   error
+	}{
+		Value: reflect.ValueOf("not an int"),
+		Err:   nil,
+	})
+
+	if _, err := dino.RegistryGet[int](registry, ""); !errors.Is(err, dino.ErrTypeMismatch) {
+		t.Fatalf("expected ErrTypeMismatch, got %v", err)
+	}
 }
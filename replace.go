@@ -0,0 +1,91 @@
+package dino
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+// ErrReplaceCallbackPanic is passed to the error handler when an OnReplace callback panics.
+var ErrReplaceCallbackPanic = errors.New("replace callback panicked")
+
+// replaceSubscription is a callback registered with OnReplace.
+type replaceSubscription struct {
+	typ  reflect.Type
+	tags []string
+	fn   func(oldValue, newValue any)
+}
+
+// replacement is an instance swapped for another under a key.
+type replacement struct {
+	key   RegistryKey
+	old   reflect.Value
+	value reflect.Value
+}
+
+// WithErrorHandler sets the handler receiving errors the container cannot return to a caller,
+// e.g. ErrReplaceCallbackPanic. Without a handler such errors are dropped.
+func WithErrorHandler(fn func(error)) Option {
+	return func(o *options) {
+		o.errorHandler = fn
+	}
+}
+
+// matches reports whether the subscription is interested in a swap under the key.
+func (s replaceSubscription) matches(key RegistryKey) bool {
+	return s.typ == key.Type && (len(s.tags) == 0 || slices.Contains(s.tags, key.Tag))
+}
+
+// OnReplace subscribes fn to swaps of the instance of the type, e.g. to rewire a server when its
+// *tls.Config is refreshed. Without tags every tag of the type is observed, otherwise only the given ones.
+// A swap happens when Refresh replaces a cached instance and when Singleton replaces a registered one.
+// Callbacks run synchronously after the registry is updated, in subscription order, and before Refresh
+// closes the old instance. A panicking callback is recovered and reported to the handler set with
+// WithErrorHandler as ErrReplaceCallbackPanic. Subscriptions belong to the container: its scopes do not
+// notify them.
+func (d *Dino) OnReplace(rt reflect.Type, fn func(oldValue, newValue any), tags ...string) {
+	if rt == nil || fn == nil {
+		return
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.subscriptions = append(d.subscriptions, replaceSubscription{
+		typ:  rt,
+		tags: tags,
+		fn:   fn,
+	})
+}
+
+// notifyReplaced calls the subscriptions matching each replacement. It must not be called with the
+// container locked, so callbacks can use the container.
+func (d *Dino) notifyReplaced(replacements []replacement) {
+	if len(replacements) == 0 {
+		return
+	}
+
+	d.mutex.Lock()
+	subscriptions := slices.Clone(d.subscriptions)
+	d.mutex.Unlock()
+
+	for _, repl := range replacements {
+		for _, sub := range subscriptions {
+			if sub.matches(repl.key) {
+				d.replaced(sub.fn, repl)
+			}
+		}
+	}
+}
+
+// replaced calls the callback with the replacement, recovering a panic and passing it to the error handler.
+func (d *Dino) replaced(fn func(oldValue, newValue any), repl replacement) {
+	defer func() {
+		if rec := recover(); rec != nil && d.options.errorHandler != nil {
+			d.options.errorHandler(fmt.Errorf("%w for %s: %v", ErrReplaceCallbackPanic, keyName(repl.key), rec))
+		}
+	}()
+
+	fn(repl.old.Interface(), repl.value.Interface())
+}
@@ -0,0 +1,139 @@
+package dino_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/yuppyweb/dino"
+)
+
+type TLSConfig struct {
+	cert string
+}
+
+type replaceLog struct {
+	old []any
+	new []any
+}
+
+func (l *replaceLog) record(oldValue, newValue any) {
+	l.old = append(l.old, oldValue)
+	l.new = append(l.new, newValue)
+}
+
+func TestDino_OnReplaceRefresh(t *testing.T) {
+	di, _ := newPoolContainer(t)
+	log := new(replaceLog)
+
+	di.OnReplace(reflect.TypeFor[*ConnectionPool](), func(oldValue, newValue any) {
+		// Callbacks run outside the container lock and may use it
+		if _, err := di.Resolve(reflect.TypeFor[string]()); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		pool, ok := oldValue.(*ConnectionPool)
+		if !ok || pool.closed {
+			t.Error("expected the old instance to be open while subscribers are notified")
+		}
+
+		log.record(oldValue, newValue)
+	})
+
+	before, err := di.Resolve(reflect.TypeFor[*ConnectionPool]())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := di.Refresh(reflect.TypeFor[*ConnectionPool]()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	after, err := di.Resolve(reflect.TypeFor[*ConnectionPool]())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(log.old) != 1 || log.old[0] != before || log.new[0] != after {
+		t.Errorf("expected a single notification from %v to %v, got %v to %v", before, after, log.old, log.new)
+	}
+}
+
+func TestDino_OnReplaceSingleton(t *testing.T) {
+	di := dino.New()
+	log := new(replaceLog)
+
+	first := &TLSConfig{cert: "first"}
+	second := &TLSConfig{cert: "second"}
+
+	di.OnReplace(reflect.TypeFor[*TLSConfig](), log.record)
+
+	if err := di.Singleton(first); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(log.old) != 0 {
+		t.Fatalf("expected no notification for a first registration, got %d", len(log.old))
+	}
+
+	if err := di.Singleton(second); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(log.old) != 1 || log.old[0] != first || log.new[0] != second {
+		t.Errorf("expected a notification from first to second, got %v to %v", log.old, log.new)
+	}
+}
+
+func TestDino_OnReplaceTags(t *testing.T) {
+	di := dino.New()
+	tagged := new(replaceLog)
+	all := new(replaceLog)
+
+	di.OnReplace(reflect.TypeFor[*TLSConfig](), tagged.record, "public")
+	di.OnReplace(reflect.TypeFor[*TLSConfig](), all.record)
+
+	for _, tag := range []string{"public", "internal"} {
+		for _, cert := range []string{"first", "second"} {
+			if err := di.Singleton(&TLSConfig{cert: cert}, tag); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+	}
+
+	if len(tagged.new) != 1 {
+		t.Errorf("expected one notification for the public tag, got %d", len(tagged.new))
+	}
+
+	if len(all.new) != 2 {
+		t.Errorf("expected a notification for every tag, got %d", len(all.new))
+	}
+}
+
+func TestDino_OnReplacePanic(t *testing.T) {
+	var handled []error
+
+	di := dino.New(dino.WithErrorHandler(func(err error) {
+		handled = append(handled, err)
+	}))
+	log := new(replaceLog)
+
+	di.OnReplace(reflect.TypeFor[*TLSConfig](), func(any, any) {
+		panic("rewire failed")
+	})
+	di.OnReplace(reflect.TypeFor[*TLSConfig](), log.record)
+
+	for _, cert := range []string{"first", "second"} {
+		if err := di.Singleton(&TLSConfig{cert: cert}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if len(handled) != 1 || !errors.Is(handled[0], dino.ErrReplaceCallbackPanic) {
+		t.Fatalf("expected ErrReplaceCallbackPanic to be handled, got %v", handled)
+	}
+
+	if len(log.new) != 1 {
+		t.Errorf("expected later subscriptions to be notified after a panic, got %d", len(log.new))
+	}
+}
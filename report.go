@@ -0,0 +1,177 @@
+package dino
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// FieldOutcome describes what an injection did with a struct field.
+type FieldOutcome uint8
+
+const (
+	// FieldResolved is a field set to a dependency from the registry, or to an environment variable.
+	FieldResolved FieldOutcome = iota
+	// FieldAutoCreated is a field set to a dependency missing from the registry that was created automatically.
+	FieldAutoCreated
+	// FieldPreserved is a field that already held a value and was kept.
+	FieldPreserved
+	// FieldSkipped is an unexported field or a field tagged `inject:"-"`.
+	FieldSkipped
+	// FieldMissing is a field left unset: an optional dependency missing from the registry, or
+	// a dependency beyond the auto-creation depth.
+	FieldMissing
+)
+
+// String returns a human-readable name of the field outcome.
+func (o FieldOutcome) String() string {
+	switch o {
+	case FieldResolved:
+		return "resolved"
+	case FieldAutoCreated:
+		return "auto-created"
+	case FieldPreserved:
+		return "preserved"
+	case FieldSkipped:
+		return "skipped"
+	case FieldMissing:
+		return "missing"
+	default:
+		return "unknown"
+	}
+}
+
+// FieldReport is the outcome of injecting a single struct field.
+type FieldReport struct {
+	// Path is the path of the field from the target, e.g. "Repo.DB" for the DB field of the Repo field.
+	Path    string
+	Type    reflect.Type
+	Outcome FieldOutcome
+	// Key is the registry key the field was resolved or auto-created with.
+	Key RegistryKey
+	// Env is the environment variable the field was read from, if any.
+	Env string
+	// Factory is the type of the factory function called to produce the resolved value, if any.
+	Factory reflect.Type
+	// Predicted reports whether the outcome was predicted by Preview rather than done by an injection.
+	Predicted bool
+}
+
+// String renders the field as its path, type and outcome, e.g. `Repo.DB *app.Database: resolved *app.Database`.
+func (f FieldReport) String() string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "%s %s: %s", f.Path, f.Type, f.Outcome)
+
+	switch {
+	case f.Env != "":
+		fmt.Fprintf(&sb, " from environment variable %s", f.Env)
+
+	case f.Outcome == FieldResolved:
+		fmt.Fprintf(&sb, " %s", keyName(f.Key))
+	}
+
+	switch {
+	case f.Factory != nil && f.Predicted:
+		fmt.Fprintf(&sb, ", would invoke factory %s", f.Factory)
+
+	case f.Factory != nil:
+		fmt.Fprintf(&sb, ", factory %s called", f.Factory)
+	}
+
+	return sb.String()
+}
+
+// Report lists the outcome of every field visited by an injection, nested fields included,
+// in the order they were visited.
+type Report struct {
+	Fields []FieldReport
+}
+
+// String renders one field per line.
+func (r Report) String() string {
+	var sb strings.Builder
+
+	for _, field := range r.Fields {
+		sb.WriteString(field.String())
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+// Field returns the report of the field at the path, e.g. "Repo.DB".
+func (r Report) Field(path string) (FieldReport, bool) {
+	for _, field := range r.Fields {
+		if field.Path == path {
+			return field, true
+		}
+	}
+
+	var missing FieldReport
+
+	return missing, false
+}
+
+// InjectReport injects the target like Inject and reports the outcome of every field it visited.
+// Fields resolved from the registry are not followed further, while the fields of auto-created and
+// preserved structs are reported under their path. On failure, the report lists the fields visited so far.
+func (d *Dino) InjectReport(target any) (Report, error) {
+	report := &reportRecorder{
+		fields:    nil,
+		prefix:    nil,
+		predicted: false,
+	}
+
+	err := d.inject(target, report)
+
+	return Report{Fields: report.fields}, err
+}
+
+// reportRecorder collects the outcomes of the fields visited by an injection.
+type reportRecorder struct {
+	fields []FieldReport
+	// prefix is the path of the struct whose fields are being visited.
+	prefix []string
+	// predicted marks the outcomes as predicted by Preview.
+	predicted bool
+}
+
+// fieldOutcome returns the report of a field of the type with the outcome, completed by the caller.
+func fieldOutcome(rt reflect.Type, outcome FieldOutcome) FieldReport {
+	return FieldReport{
+		Path:      "",
+		Type:      rt,
+		Outcome:   outcome,
+		Key:       RegistryKey{Tag: "", Type: nil, Scope: "", Qualifier: nil},
+		Env:       "",
+		Factory:   nil,
+		Predicted: false,
+	}
+}
+
+// record adds the outcome of the named field to the report being built, if any.
+func (i *Injector) record(name string, field FieldReport) {
+	if i.report == nil {
+		return
+	}
+
+	field.Path = strings.Join(append(slices.Clip(i.report.prefix), name), ".")
+	field.Predicted = i.report.predicted
+	i.report.fields = append(i.report.fields, field)
+}
+
+// enterField makes the named field the struct whose fields are being visited.
+func (i *Injector) enterField(name string) {
+	if i.report != nil {
+		i.report.prefix = append(i.report.prefix, name)
+	}
+}
+
+// leaveField returns to the struct the field being visited belongs to.
+func (i *Injector) leaveField() {
+	if i.report != nil {
+		i.report.prefix = i.report.prefix[:len(i.report.prefix)-1]
+	}
+}
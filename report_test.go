@@ -0,0 +1,115 @@
+package dino_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/yuppyweb/dino"
+)
+
+type ReportDB struct {
+	DSN string
+}
+
+type ReportCache struct {
+	Size int
+}
+
+type ReportClock struct {
+	Zone string
+}
+
+type ReportRepo struct {
+	DB *ReportDB
+}
+
+type ReportTarget struct {
+	DB      *ReportDB
+	Repo    *ReportRepo
+	Clock   *ReportClock
+	Cache   *ReportCache `inject:",optional"`
+	Ignored *ReportDB    `inject:"-"`
+	name    string
+}
+
+func TestDino_InjectReport(t *testing.T) {
+	t.Parallel()
+
+	di := dino.New()
+
+	if err := di.Factory(func() *ReportDB {
+		return &ReportDB{DSN: "postgres"}
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	target := ReportTarget{Clock: &ReportClock{Zone: "UTC"}, name: "report"}
+
+	report, err := di.InjectReport(&target)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := `DB *dino_test.ReportDB: resolved *dino_test.ReportDB, factory func() *dino_test.ReportDB called
+Repo *dino_test.ReportRepo: auto-created
+Repo.DB *dino_test.ReportDB: resolved *dino_test.ReportDB
+Clock *dino_test.ReportClock: preserved
+Clock.Zone string: preserved
+Cache *dino_test.ReportCache: missing
+Ignored *dino_test.ReportDB: skipped
+name string: skipped
+`
+
+	if got := report.String(); got != expected {
+		t.Errorf("unexpected report:\n%s\nexpected:\n%s", got, expected)
+	}
+
+	if target.DB == nil || target.Repo.DB != target.DB || target.Ignored != nil || target.Cache != nil {
+		t.Errorf("unexpected injection: %+v", target)
+	}
+
+	field, ok := report.Field("Repo.DB")
+	if !ok || field.Outcome != dino.FieldResolved || field.Factory != nil {
+		t.Errorf("expected Repo.DB to be resolved without factory, got %+v", field)
+	}
+
+	// Injecting again keeps every field set by the first injection
+	again, err := di.InjectReport(&target)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, path := range []string{"DB", "Repo", "Repo.DB", "Clock"} {
+		if field, ok := again.Field(path); !ok || field.Outcome != dino.FieldPreserved {
+			t.Errorf("expected %s to be preserved, got %+v", path, field)
+		}
+	}
+}
+
+func TestDino_InjectReportError(t *testing.T) {
+	t.Parallel()
+
+	di := dino.New()
+
+	if err := di.Factory(func() (*ReportCache, error) {
+		return nil, errProvideFailed
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var target ReportTarget
+
+	report, err := di.InjectReport(&target)
+	if !errors.Is(err, errProvideFailed) {
+		t.Fatalf("expected the factory error, got %v", err)
+	}
+
+	// The fields visited before the failure are reported
+	if _, ok := report.Field("Repo.DB"); !ok {
+		t.Errorf("expected the fields visited so far, got:\n%s", report)
+	}
+
+	if _, ok := report.Field("Cache"); ok {
+		t.Errorf("expected the failed field not to be reported, got:\n%s", report)
+	}
+}
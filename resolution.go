@@ -0,0 +1,78 @@
+package dino
+
+import (
+	"strconv"
+	"strings"
+)
+
+// StepKind describes how a dependency on a resolution path is obtained.
+type StepKind uint8
+
+const (
+	// StepTarget is a struct passed to Inject by the caller.
+	StepTarget StepKind = iota
+	// StepFactory is a dependency produced by a registered factory function.
+	StepFactory
+	// StepAutoCreated is a dependency missing from the registry that is created automatically.
+	StepAutoCreated
+)
+
+// String returns a human-readable name of the step kind.
+func (k StepKind) String() string {
+	switch k {
+	case StepTarget:
+		return "target"
+	case StepFactory:
+		return "factory"
+	case StepAutoCreated:
+		return "auto-created"
+	default:
+		return "unknown"
+	}
+}
+
+// ResolutionStep is a single hop on a resolution path.
+type ResolutionStep struct {
+	Key  RegistryKey
+	Kind StepKind
+}
+
+// String renders the step as its type, tag and kind, e.g. `*app.Database "primary" (factory)`.
+func (s ResolutionStep) String() string {
+	var sb strings.Builder
+
+	sb.WriteString(s.Key.Type.String())
+
+	if s.Key.Tag != "" {
+		sb.WriteString(" ")
+		sb.WriteString(strconv.Quote(s.Key.Tag))
+	}
+
+	sb.WriteString(" (")
+	sb.WriteString(s.Kind.String())
+	sb.WriteString(")")
+
+	return sb.String()
+}
+
+// ResolutionError reports a resolution failure together with the path of dependencies that led to it.
+type ResolutionError struct {
+	Path []ResolutionStep
+	Err  error
+}
+
+// Error returns the underlying error message followed by the resolution path.
+func (e *ResolutionError) Error() string {
+	steps := make([]string, len(e.Path))
+
+	for idx, step := range e.Path {
+		steps[idx] = step.String()
+	}
+
+	return e.Err.Error() + ": " + strings.Join(steps, " -> ")
+}
+
+// Unwrap returns the underlying error.
+func (e *ResolutionError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,87 @@
+package dino
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// defaultGracePeriod bounds the shutdown performed by Run unless WithGracePeriod is used.
+const defaultGracePeriod = 30 * time.Second
+
+// ErrRunFailed is returned by Run when a failure is reported through Lifecycle.Fail.
+var ErrRunFailed = errors.New("application failed")
+
+// RunOption configures Dino.Run.
+type RunOption func(*runOptions)
+
+// runOptions holds the settings of Dino.Run.
+type runOptions struct {
+	signals     []os.Signal
+	gracePeriod time.Duration
+}
+
+// newRunOptions builds the settings from the provided options, starting from the defaults.
+func newRunOptions(opts ...RunOption) *runOptions {
+	o := &runOptions{
+		signals:     []os.Signal{os.Interrupt, syscall.SIGTERM},
+		gracePeriod: defaultGracePeriod,
+	}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return o
+}
+
+// WithSignals sets the OS signals that make Run shut down, SIGINT and SIGTERM by default.
+// Without signals, Run only stops when its context is done or a failure is reported.
+func WithSignals(signals ...os.Signal) RunOption {
+	return func(o *runOptions) {
+		o.signals = signals
+	}
+}
+
+// WithGracePeriod sets how long Run lets the shutdown take, 30 seconds by default.
+func WithGracePeriod(gracePeriod time.Duration) RunOption {
+	return func(o *runOptions) {
+		o.gracePeriod = gracePeriod
+	}
+}
+
+// Run starts the container, blocks until the context is done, one of the configured OS signals arrives
+// or a failure is reported through Lifecycle.Fail, and then shuts the container down within the grace
+// period. It returns the reported failure together with any shutdown error; a failure to start is
+// returned right away, after the instances already started were closed.
+func (d *Dino) Run(ctx context.Context, opts ...RunOption) error {
+	o := newRunOptions(opts...)
+
+	if len(o.signals) > 0 {
+		var stop context.CancelFunc
+
+		ctx, stop = signal.NotifyContext(ctx, o.signals...)
+		defer stop()
+	}
+
+	if err := d.Start(ctx); err != nil {
+		return err
+	}
+
+	var runErr error
+
+	select {
+	case <-ctx.Done():
+	case err := <-d.options.lifecycle.failures:
+		runErr = fmt.Errorf("%w: %w", ErrRunFailed, err)
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), o.gracePeriod)
+	defer cancel()
+
+	return errors.Join(runErr, d.Shutdown(shutdownCtx))
+}
@@ -0,0 +1,142 @@
+package dino_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/yuppyweb/dino"
+)
+
+type runLog struct {
+	mutex  sync.Mutex
+	events []string
+}
+
+func (l *runLog) hook(name string) dino.Hook {
+	return dino.Hook{
+		OnStart: func(context.Context) error {
+			l.add("start " + name)
+
+			return nil
+		},
+		OnStop: func(context.Context) error {
+			l.add("stop " + name)
+
+			return nil
+		},
+	}
+}
+
+func (l *runLog) add(event string) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.events = append(l.events, event)
+}
+
+func (l *runLog) String() string {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	return strings.Join(l.events, ",")
+}
+
+func TestDino_RunUntilCancelled(t *testing.T) {
+	t.Parallel()
+
+	log := new(runLog)
+	di := dino.New()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if _, err := di.Invoke(func(lc *dino.Lifecycle) {
+		lc.Append(log.hook("database"))
+		lc.Append(dino.Hook{
+			OnStart: func(context.Context) error {
+				log.add("start server")
+
+				// Simulate an interrupt once everything runs
+				cancel()
+
+				return nil
+			},
+			OnStop: func(context.Context) error {
+				log.add("stop server")
+
+				return nil
+			},
+		})
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := di.Run(ctx, dino.WithSignals(), dino.WithGracePeriod(time.Second)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "start database,start server,stop server,stop database"
+	if log.String() != expected {
+		t.Fatalf("expected %s, got %s", expected, log.String())
+	}
+}
+
+func TestDino_RunStopsOnFailure(t *testing.T) {
+	t.Parallel()
+
+	log := new(runLog)
+	di := dino.New()
+
+	if _, err := di.Invoke(func(lc *dino.Lifecycle) {
+		lc.Append(log.hook("database"))
+		lc.Append(dino.Hook{
+			OnStart: func(context.Context) error {
+				// A background listener failing after the start
+				go lc.Fail(errHookFailed)
+
+				return nil
+			},
+			OnStop: nil,
+		})
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err := di.Run(context.Background(), dino.WithSignals())
+	if !errors.Is(err, dino.ErrRunFailed) || !errors.Is(err, errHookFailed) {
+		t.Fatalf("expected the reported failure, got %v", err)
+	}
+
+	if log.String() != "start database,stop database" {
+		t.Fatalf("expected the container to be shut down, got %s", log.String())
+	}
+}
+
+func TestDino_RunStartFailure(t *testing.T) {
+	t.Parallel()
+
+	log := new(runLog)
+	di := dino.New()
+
+	if _, err := di.Invoke(func(lc *dino.Lifecycle) {
+		lc.Append(log.hook("database"))
+		lc.Append(dino.Hook{
+			OnStart: func(context.Context) error { return errHookFailed },
+			OnStop:  nil,
+		})
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := di.Run(context.Background(), dino.WithSignals()); !errors.Is(err, errHookFailed) {
+		t.Fatalf("expected the start failure, got %v", err)
+	}
+
+	if log.String() != "start database,stop database" {
+		t.Fatalf("expected the started hooks to be rolled back, got %s", log.String())
+	}
+}
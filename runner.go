@@ -0,0 +1,62 @@
+package dino
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Runner registers a function returning nothing but errors, e.g. func(db *Database) error { return db.Ping() },
+// to be called with its dependencies injected by the next Build, and so by Start, after every factory is
+// built. Runners are called once each, in registration order; a failing runner makes Build return its
+// error and is called again by the next Build, together with the runners registered after it.
+func (d *Dino) Runner(fn any) error {
+	rv := reflect.ValueOf(fn)
+
+	if isNil(rv) {
+		return fmt.Errorf("%w: runner function cannot be nil", ErrInvalidInputValue)
+	}
+
+	rt := rv.Type()
+
+	if !isFunction(rt) {
+		return fmt.Errorf("%w: runner expected a function, got %v", ErrInvalidInputValue, rt.Kind())
+	}
+
+	for outType := range rt.Outs() {
+		if !isError(outType) {
+			return fmt.Errorf("%w: runner %s returns %s, register it with Factory instead",
+				ErrInvalidInputValue, rt, outType)
+		}
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.runners = append(d.runners, rv)
+
+	return nil
+}
+
+// run calls the runners registered since the last build, in registration order, keeping the failed
+// runner and those after it for the next build.
+func (d *Dino) run() error {
+	for len(d.runners) > 0 {
+		fn := d.runners[0]
+		injector := newInjector(d.registry, d.options)
+
+		values, err := injector.invoke(fn)
+		if err != nil {
+			return fmt.Errorf("failed to run %s: %w", fn.Type(), err)
+		}
+
+		for _, val := range values {
+			if err := asError(val); err != nil {
+				return fmt.Errorf("runner %s returned error: %w", fn.Type(), err)
+			}
+		}
+
+		d.runners = d.runners[1:]
+	}
+
+	return nil
+}
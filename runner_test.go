@@ -0,0 +1,135 @@
+package dino_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/yuppyweb/dino"
+)
+
+type RunnerDatabase struct {
+	pings int
+	err   error
+}
+
+func (db *RunnerDatabase) Ping() error {
+	db.pings++
+
+	return db.err
+}
+
+func TestDino_Runner(t *testing.T) {
+	t.Parallel()
+
+	db := &RunnerDatabase{pings: 0, err: nil}
+	di := dino.New()
+
+	if err := di.Singleton(db); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var order []string
+
+	if err := di.Runner(func(db *RunnerDatabase) error {
+		order = append(order, "ping")
+
+		return db.Ping()
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := di.Runner(func() { order = append(order, "warm up") }); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := di.Build(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Runners are called once, a later build or start does not call them again
+	if err := di.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if db.pings != 1 || strings.Join(order, ",") != "ping,warm up" {
+		t.Fatalf("expected each runner to run once in order, got %d pings and %v", db.pings, order)
+	}
+}
+
+func TestDino_RunnerError(t *testing.T) {
+	t.Parallel()
+
+	db := &RunnerDatabase{pings: 0, err: errHookFailed}
+	di := dino.New()
+
+	if err := di.Singleton(db); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := di.Runner(func(db *RunnerDatabase) error { return db.Ping() }); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := di.Start(context.Background()); !errors.Is(err, errHookFailed) {
+		t.Fatalf("expected the runner error, got %v", err)
+	}
+
+	// A failed runner is called again by the next build
+	db.err = nil
+
+	if err := di.Build(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if db.pings != 2 {
+		t.Fatalf("expected the failed runner to run again, got %d pings", db.pings)
+	}
+}
+
+func TestDino_RunnerInvalid(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		fn      any
+		message string
+	}{
+		{name: "nil", fn: nil, message: "runner function cannot be nil"},
+		{name: "not a function", fn: 42, message: "runner expected a function, got int"},
+		{
+			name:    "returns a value",
+			fn:      func() (*RunnerDatabase, error) { return &RunnerDatabase{pings: 0, err: nil}, nil },
+			message: "returns *dino_test.RunnerDatabase, register it with Factory instead",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := dino.New().Runner(tt.fn)
+			if !errors.Is(err, dino.ErrInvalidInputValue) {
+				t.Fatalf("expected ErrInvalidInputValue, got %v", err)
+			}
+
+			if !strings.Contains(err.Error(), tt.message) {
+				t.Fatalf("expected the error to contain %q, got %v", tt.message, err)
+			}
+		})
+	}
+}
+
+func TestDino_FactoryPointsErrorOnlyFunctionsToRunner(t *testing.T) {
+	t.Parallel()
+
+	err := dino.New().Factory(func(*RunnerDatabase) error { return nil })
+	if !errors.Is(err, dino.ErrNoRegistrableOutputs) {
+		t.Fatalf("expected ErrNoRegistrableOutputs, got %v", err)
+	}
+
+	if !strings.Contains(err.Error(), "register it with Runner") {
+		t.Fatalf("expected the error to point at Runner, got %v", err)
+	}
+}
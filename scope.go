@@ -0,0 +1,141 @@
+package dino
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+// ErrNotScope is returned by CloseScope when called on a container that is not a scope.
+var ErrNotScope = errors.New("container is not a scope")
+
+// scoped returns the options of a scope of the container: settings are shared, while the scope tracks
+// its own instances and falls back to the container for lifetimes and dependencies.
+func (o *options) scoped() *options {
+	return &options{
+		skipCyclicFields:   o.skipCyclicFields,
+		cacheSharing:       o.cacheSharing,
+		aggregateErrors:    o.aggregateErrors,
+		registry:           nil,
+		ttls:               sync.Map{},
+		perScope:           sync.Map{},
+		deps:               sync.Map{},
+		factories:          sync.Map{},
+		typedFactories:     sync.Map{},
+		appended:           sync.Map{},
+		stats:              o.stats,
+		lifecycle:          newLifecycle(),
+		healthCheckTimeout: o.healthCheckTimeout,
+		errorHandler:       o.errorHandler,
+		logger:             o.logger,
+		trace:              o.trace,
+		expvarName:         "",
+		parent:             o,
+		factoryLocks:       newKeyLocks(),
+		buildParallelism:   o.buildParallelism,
+		parallelArgs:       o.parallelArgs,
+		envLookup:          o.envLookup,
+		flags:              o.flags,
+		defaultLogger:      o.defaultLogger,
+		qualifiers:         o.qualifiers,
+		maxAutoCreateDepth: o.maxAutoCreateDepth,
+		strictAutoCreate:   o.strictAutoCreate,
+	}
+}
+
+// Scoped makes the factory create an instance per scope: its results are cached and closed by the scope
+// resolving them, e.g. a transaction per request, instead of by the container the factory is registered with.
+func Scoped() RegisterOption {
+	return func(o *registerOptions) {
+		o.scoped = true
+	}
+}
+
+// setPerScope records whether the factories registered under the keys create an instance per scope.
+func (o *options) setPerScope(keys []RegistryKey, scoped bool) {
+	for _, key := range keys {
+		if scoped {
+			o.perScope.Store(key, struct{}{})
+		} else {
+			o.perScope.Delete(key)
+		}
+	}
+}
+
+// scopedKey reports whether the factory registered for the key creates an instance per scope.
+func (o *options) scopedKey(key RegistryKey) bool {
+	if _, ok := o.perScope.Load(key); ok {
+		return true
+	}
+
+	return o.parent != nil && o.parent.scopedKey(key)
+}
+
+// Scope creates a child container, e.g. for a single request. The scope sees everything registered in
+// the container, while its own registrations, the results of its own factories and of factories registered
+// with Scoped stay in the scope. Results of the other factories of the container are cached and closed by
+// the container, even if first resolved within the scope. Instances created by the scope are closed by
+// CloseScope, never by the container.
+func (d *Dino) Scope() *Dino {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	return &Dino{
+		registry:      NewChildRegistry(d.registry),
+		options:       d.options.scoped(),
+		mutex:         sync.RWMutex{},
+		parent:        d,
+		subscriptions: nil,
+		runners:       nil,
+	}
+}
+
+// CloseScope closes the instances created by the scope in reverse order, leaving the instances of
+// the container it was created from alone, then discards the scope's registrations.
+func (d *Dino) CloseScope() error {
+	if d.parent == nil {
+		return ErrNotScope
+	}
+
+	closeErr := d.Close()
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	var clearErr error
+
+	if child, ok := d.registry.(*ChildRegistry); ok {
+		if err := child.Clear(); err != nil {
+			clearErr = fmt.Errorf("discard scope registrations: %w", err)
+		}
+	}
+
+	return errors.Join(closeErr, clearErr)
+}
+
+// Middleware runs every request with a scope of the container, available to handlers through
+// FromContext and ScopeFromContext, and closes the scope once the handler returns.
+func (d *Dino) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		scope := d.Scope()
+
+		defer func() {
+			_ = scope.CloseScope()
+		}()
+
+		next.ServeHTTP(w, r.WithContext(NewContext(r.Context(), scope)))
+	})
+}
+
+// ScopeFromContext returns the scope stored in the context by Middleware or NewContext, if any.
+// Unlike FromContext, it reports false when the context carries a container that is not a scope.
+func ScopeFromContext(ctx context.Context) (*Dino, bool) {
+	scope, ok := FromContext(ctx)
+	if !ok || scope.parent == nil {
+		return nil, false
+	}
+
+	return scope, true
+}
@@ -0,0 +1,206 @@
+package dino_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync/atomic"
+	"testing"
+
+	"github.com/yuppyweb/dino"
+)
+
+type ScopeDatabase struct {
+	closed atomic.Int32
+}
+
+func (d *ScopeDatabase) Close() error {
+	d.closed.Add(1)
+
+	return nil
+}
+
+type ScopeTx struct {
+	DB     *ScopeDatabase
+	closed atomic.Int32
+}
+
+func (tx *ScopeTx) Close() error {
+	tx.closed.Add(1)
+
+	return nil
+}
+
+func newScopeContainer(t *testing.T) (*dino.Dino, *ScopeDatabase) {
+	t.Helper()
+
+	db := new(ScopeDatabase)
+	di := dino.New()
+
+	if err := di.Singleton(db); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := di.FactoryWith(func(db *ScopeDatabase) *ScopeTx { return &ScopeTx{DB: db} }, dino.Scoped()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	return di, db
+}
+
+func resolveTx(t *testing.T, di *dino.Dino) *ScopeTx {
+	t.Helper()
+
+	val, err := di.Resolve(reflect.TypeFor[*ScopeTx]())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tx, _ := val.(*ScopeTx)
+
+	return tx
+}
+
+func TestDino_CloseScope(t *testing.T) {
+	t.Parallel()
+
+	di, db := newScopeContainer(t)
+
+	scope := di.Scope()
+	tx := resolveTx(t, scope)
+
+	if tx.DB != db {
+		t.Fatalf("expected the scope to share the container singleton")
+	}
+
+	if resolveTx(t, scope) != tx {
+		t.Fatalf("expected the scope to cache its own instance")
+	}
+
+	if err := scope.CloseScope(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if tx.closed.Load() != 1 || db.closed.Load() != 0 {
+		t.Fatalf("expected only the scope instance to be closed, got tx %d and db %d",
+			tx.closed.Load(), db.closed.Load())
+	}
+
+	// The container never saw the scope instance, and the scope registrations are gone
+	if resolveTx(t, di) == tx {
+		t.Fatalf("expected the container to create its own instance")
+	}
+
+	if err := di.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if tx.closed.Load() != 1 || db.closed.Load() != 1 {
+		t.Fatalf("expected every instance to be closed once, got tx %d and db %d",
+			tx.closed.Load(), db.closed.Load())
+	}
+}
+
+func TestDino_CloseScopeKeepsContainerFactoryResults(t *testing.T) {
+	t.Parallel()
+
+	di := dino.New()
+
+	if err := di.Factory(func() *ScopeDatabase { return &ScopeDatabase{} }); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The first resolution happens within the scope, the result still belongs to the container
+	scope := di.Scope()
+
+	db, err := dino.Resolve[*ScopeDatabase](scope)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := scope.CloseScope(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if db.closed.Load() != 0 {
+		t.Fatal("expected the scope not to close the container instance")
+	}
+
+	if shared, err := dino.Resolve[*ScopeDatabase](di); err != nil || shared != db {
+		t.Fatalf("expected the container to cache the instance, got %v, %v", shared, err)
+	}
+
+	if err := di.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if db.closed.Load() != 1 {
+		t.Fatalf("expected the container to close its instance once, got %d", db.closed.Load())
+	}
+}
+
+func TestDino_CloseAfterLeakedScope(t *testing.T) {
+	t.Parallel()
+
+	di, db := newScopeContainer(t)
+
+	scope := di.Scope()
+	tx := resolveTx(t, scope)
+
+	if err := di.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := scope.CloseScope(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if tx.closed.Load() != 1 || db.closed.Load() != 1 {
+		t.Fatalf("expected every instance to be closed once, got tx %d and db %d",
+			tx.closed.Load(), db.closed.Load())
+	}
+}
+
+func TestDino_CloseScopeNotScope(t *testing.T) {
+	t.Parallel()
+
+	if err := dino.New().CloseScope(); !errors.Is(err, dino.ErrNotScope) {
+		t.Fatalf("expected ErrNotScope, got %v", err)
+	}
+}
+
+func TestDino_Middleware(t *testing.T) {
+	t.Parallel()
+
+	di, db := newScopeContainer(t)
+
+	var tx *ScopeTx
+
+	handler := di.Middleware(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		scope, ok := dino.ScopeFromContext(r.Context())
+		if !ok {
+			t.Errorf("expected a request scope")
+
+			return
+		}
+
+		tx = resolveTx(t, scope)
+	}))
+
+	request := httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/", nil)
+	handler.ServeHTTP(httptest.NewRecorder(), request)
+
+	if tx == nil || tx.closed.Load() != 1 {
+		t.Fatalf("expected the request scope to be closed after the handler returned")
+	}
+
+	if db.closed.Load() != 0 {
+		t.Fatalf("expected the container singleton to survive the request")
+	}
+
+	if _, ok := dino.ScopeFromContext(context.Background()); ok {
+		t.Fatalf("expected no scope outside of the middleware")
+	}
+}
@@ -0,0 +1,214 @@
+package dino
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// RegistrySnapshot is a copy of the entries of a registry taken at a point in time.
+// The stored reflect.Values are shared with the registry, the snapshot does not copy what they point to.
+type RegistrySnapshot struct {
+	entries map[RegistryKey]reflect.Value
+	// settings holds the per-key settings of the container the snapshot was taken of, by store.
+	settings map[*sync.Map]map[any]any
+	// lifecycle records the instances tracked by the container the snapshot was taken of.
+	lifecycle *lifecycleSnapshot
+}
+
+// Len returns the number of entries in the snapshot.
+func (s RegistrySnapshot) Len() int {
+	return len(s.entries)
+}
+
+// takeSnapshot copies every entry of the registry into a snapshot.
+func takeSnapshot(registry Registry) RegistrySnapshot {
+	entries := make(map[RegistryKey]reflect.Value)
+
+	for key, rv := range registry.All() {
+		entries[key] = rv
+	}
+
+	return RegistrySnapshot{
+		entries:   entries,
+		settings:  nil,
+		lifecycle: nil,
+	}
+}
+
+// snapshotStores returns the per-key stores of the options that Dino.Snapshot copies along with the registry.
+func (o *options) snapshotStores() []*sync.Map {
+	return []*sync.Map{&o.ttls, &o.perScope, &o.deps, &o.factories, &o.typedFactories}
+}
+
+// takeSettings copies the settings recorded in the stores.
+func takeSettings(stores []*sync.Map) map[*sync.Map]map[any]any {
+	settings := make(map[*sync.Map]map[any]any, len(stores))
+
+	for _, store := range stores {
+		values := make(map[any]any)
+
+		store.Range(func(key, value any) bool {
+			values[key] = value
+
+			return true
+		})
+
+		settings[store] = values
+	}
+
+	return settings
+}
+
+// restoreSettings replaces the settings recorded in the stores with the copied ones. Stores the snapshot
+// holds no copy of, e.g. those of another container, are left untouched.
+func restoreSettings(stores []*sync.Map, settings map[*sync.Map]map[any]any) {
+	for _, store := range stores {
+		values, ok := settings[store]
+		if !ok {
+			continue
+		}
+
+		store.Clear()
+
+		for key, value := range values {
+			store.Store(key, value)
+		}
+	}
+}
+
+// restoreSnapshot replaces the entries of the registry with the ones of the snapshot:
+// entries added after the snapshot are deleted and changed or deleted ones are registered again.
+func restoreSnapshot(registry Registry, snapshot RegistrySnapshot) error {
+	var (
+		stale []RegistryKey
+		errs  []error
+	)
+
+	// Collect the keys first, the registry is not modified while ranging over it
+	for key := range registry.All() {
+		if _, ok := snapshot.entries[key]; !ok {
+			stale = append(stale, key)
+		}
+	}
+
+	for _, key := range stale {
+		if err := registry.Delete(key); err != nil && !errors.Is(err, ErrValueNotFound) {
+			errs = append(errs, fmt.Errorf("delete type %s with tag '%s': %w", key.Type, key.Tag, err))
+		}
+	}
+
+	for key, rv := range snapshot.entries {
+		if err := registry.Register(key, rv); err != nil {
+			errs = append(errs, fmt.Errorf("restore type %s with tag '%s': %w", key.Type, key.Tag, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Snapshot returns a copy of the entries currently stored in the registry.
+func (r *SyncMapRegistry) Snapshot() RegistrySnapshot {
+	return takeSnapshot(r)
+}
+
+// Restore atomically replaces the entries of the registry with the ones of the snapshot: registrations
+// and deletions made concurrently happen either before or after it. Afterwards, observers are notified
+// about every removed entry and every entry of the snapshot.
+func (r *SyncMapRegistry) Restore(snapshot RegistrySnapshot) error {
+	for key, rv := range snapshot.entries {
+		if err := validateEntry(key, rv); err != nil {
+			return fmt.Errorf("restore type %s with tag '%s': %w", key.Type, key.Tag, err)
+		}
+	}
+
+	removed := r.replace(snapshot.entries)
+
+	for key, value := range removed {
+		r.observers.notifyDelete(key, storedValue(value))
+	}
+
+	for key, rv := range snapshot.entries {
+		r.observers.notifyRegister(key, rv)
+	}
+
+	return nil
+}
+
+// replace swaps the entries of the registry for the provided ones under the write lock and returns
+// the values of the keys that are not present anymore.
+func (r *SyncMapRegistry) replace(entries map[RegistryKey]reflect.Value) map[RegistryKey]any {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	removed := make(map[RegistryKey]any)
+
+	r.sm.Range(func(k, value any) bool {
+		if key, ok := k.(RegistryKey); ok {
+			if _, kept := entries[key]; !kept {
+				removed[key] = value
+			}
+		}
+
+		return true
+	})
+
+	for key := range removed {
+		r.sm.Delete(key)
+		r.index.remove(key)
+	}
+
+	for key, rv := range entries {
+		r.sm.Store(key, rv)
+		r.index.add(key)
+	}
+
+	return removed
+}
+
+// restorer is implemented by registries restoring snapshots atomically.
+type restorer interface {
+	Restore(snapshot RegistrySnapshot) error
+}
+
+// Snapshot returns a copy of the container's registry, including factory results cached so far,
+// together with the settings of its registrations and the instances it tracks for Shutdown.
+func (d *Dino) Snapshot() RegistrySnapshot {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	snapshot := takeSnapshot(d.registry)
+	snapshot.settings = takeSettings(d.options.snapshotStores())
+	snapshot.lifecycle = d.options.lifecycle.snapshot()
+
+	return snapshot
+}
+
+// Restore rolls the container back to the snapshot. Registrations made after the snapshot disappear,
+// along with their settings such as TTLs, dependencies and typed providers registered with Provide,
+// replaced or deleted ones return, and factories resolved since are called again on their next
+// resolution. Instances created since are no longer tracked and are not closed by Shutdown. The container
+// is locked while restoring, so other container calls observe either the state before or after the restore.
+// Snapshots of a registry rather than a container only roll the registry back.
+func (d *Dino) Restore(snapshot RegistrySnapshot) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	var err error
+
+	if registry, ok := d.registry.(restorer); ok {
+		err = registry.Restore(snapshot)
+	} else {
+		err = restoreSnapshot(d.registry, snapshot)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to restore registry snapshot: %w", err)
+	}
+
+	restoreSettings(d.options.snapshotStores(), snapshot.settings)
+	d.options.lifecycle.restore(snapshot.lifecycle)
+
+	return nil
+}
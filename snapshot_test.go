@@ -0,0 +1,216 @@
+package dino_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/yuppyweb/dino"
+)
+
+type SnapshotLogger struct {
+	Name string
+}
+
+func TestSnapshot_RegistryRestore(t *testing.T) {
+	t.Parallel()
+
+	kept := dino.RegistryKey{
+		Tag:   "kept",
+		Type:  reflect.TypeFor[int](),
+		Scope: "",
+	}
+
+	deleted := dino.RegistryKey{
+		Tag:   "deleted",
+		Type:  reflect.TypeFor[int](),
+		Scope: "",
+	}
+
+	added := dino.RegistryKey{
+		Tag:   "added",
+		Type:  reflect.TypeFor[int](),
+		Scope: "",
+	}
+
+	forEachRegistry(t, func(t *testing.T, registry testRegistry) {
+		for _, key := range []dino.RegistryKey{kept, deleted} {
+			if err := registry.Register(key, reflect.ValueOf(1)); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+
+		snapshot := registry.Snapshot()
+		if snapshot.Len() != 2 {
+			t.Fatalf("expected 2 entries in snapshot, got %d", snapshot.Len())
+		}
+
+		if err := registry.Register(kept, reflect.ValueOf(2)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if err := registry.Register(added, reflect.ValueOf(3)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if err := registry.Delete(deleted); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if err := registry.Restore(snapshot); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if _, err := registry.Find(added); !errors.Is(err, dino.ErrValueNotFound) {
+			t.Fatalf("expected entry added after the snapshot to disappear, got %v", err)
+		}
+
+		for _, key := range []dino.RegistryKey{kept, deleted} {
+			rv, err := registry.Find(key)
+			if err != nil {
+				t.Fatalf("expected %v to be restored, got %v", key, err)
+			}
+
+			if rv.Int() != 1 {
+				t.Fatalf("expected original value for %v, got %v", key, rv.Int())
+			}
+		}
+	})
+}
+
+func TestSnapshot_DinoRestoreSingleton(t *testing.T) {
+	t.Parallel()
+
+	original := &SnapshotLogger{Name: "original"}
+
+	di := dino.New()
+
+	if err := di.Singleton(original); err != nil {
+		t.Fatalf("unexpected error during singleton registration: %v", err)
+	}
+
+	snapshot := di.Snapshot()
+
+	if err := di.Singleton(&SnapshotLogger{Name: "fake"}); err != nil {
+		t.Fatalf("unexpected error during singleton registration: %v", err)
+	}
+
+	val, err := di.Resolve(reflect.TypeFor[*SnapshotLogger]())
+	if err != nil {
+		t.Fatalf("unexpected error from Resolve: %v", err)
+	}
+
+	if logger, ok := val.(*SnapshotLogger); !ok || logger.Name != "fake" {
+		t.Fatalf("expected overriding singleton, got %v", val)
+	}
+
+	if err := di.Restore(snapshot); err != nil {
+		t.Fatalf("unexpected error from Restore: %v", err)
+	}
+
+	val, err = di.Resolve(reflect.TypeFor[*SnapshotLogger]())
+	if err != nil {
+		t.Fatalf("unexpected error from Resolve: %v", err)
+	}
+
+	if val != original {
+		t.Fatalf("expected original singleton after restore, got %v", val)
+	}
+}
+
+func TestSnapshot_DinoRestoreCachedFactory(t *testing.T) {
+	t.Parallel()
+
+	calls := 0
+
+	di := dino.New()
+
+	err := di.Factory(func() *SnapshotLogger {
+		calls++
+
+		return &SnapshotLogger{Name: "factory"}
+	})
+	if err != nil {
+		t.Fatalf("unexpected error during factory registration: %v", err)
+	}
+
+	snapshot := di.Snapshot()
+
+	for range 2 {
+		if _, err := di.Resolve(reflect.TypeFor[*SnapshotLogger]()); err != nil {
+			t.Fatalf("unexpected error from Resolve: %v", err)
+		}
+	}
+
+	if calls != 1 {
+		t.Fatalf("expected factory result to be cached, got %d calls", calls)
+	}
+
+	if err := di.Restore(snapshot); err != nil {
+		t.Fatalf("unexpected error from Restore: %v", err)
+	}
+
+	if _, err := di.Resolve(reflect.TypeFor[*SnapshotLogger]()); err != nil {
+		t.Fatalf("unexpected error from Resolve: %v", err)
+	}
+
+	if calls != 2 {
+		t.Fatalf("expected cached result to be rolled back, got %d calls", calls)
+	}
+}
+
+type SnapshotConn struct {
+	closed bool
+}
+
+func (c *SnapshotConn) Close() error {
+	c.closed = true
+
+	return nil
+}
+
+func TestSnapshot_DinoRestoreTrackedInstances(t *testing.T) {
+	t.Parallel()
+
+	di := dino.New()
+
+	if err := di.Singleton(&SnapshotLogger{Name: "original"}); err != nil {
+		t.Fatalf("unexpected error during singleton registration: %v", err)
+	}
+
+	snapshot := di.Snapshot()
+
+	if err := di.Factory(func(*SnapshotLogger) *SnapshotConn {
+		return &SnapshotConn{closed: false}
+	}); err != nil {
+		t.Fatalf("unexpected error during factory registration: %v", err)
+	}
+
+	conn, err := dino.Resolve[*SnapshotConn](di)
+	if err != nil {
+		t.Fatalf("unexpected error from Resolve: %v", err)
+	}
+
+	if err := di.Restore(snapshot); err != nil {
+		t.Fatalf("unexpected error from Restore: %v", err)
+	}
+
+	order, err := di.Order()
+	if err != nil {
+		t.Fatalf("unexpected error from Order: %v", err)
+	}
+
+	for _, key := range order {
+		if key.Type == reflect.TypeFor[*SnapshotConn]() {
+			t.Fatalf("expected the factory registered after the snapshot to leave the order, got %v", order)
+		}
+	}
+
+	if err := di.Close(); err != nil {
+		t.Fatalf("unexpected error from Close: %v", err)
+	}
+
+	if conn.closed {
+		t.Error("expected the instance created after the snapshot to be no longer tracked")
+	}
+}
@@ -0,0 +1,162 @@
+package dino
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// StartOption configures Dino.Start.
+type StartOption func(*startOptions)
+
+// startOptions holds the settings of Dino.Start.
+type startOptions struct {
+	parallel int
+}
+
+// newStartOptions builds the settings from the provided options, starting from the defaults.
+func newStartOptions(opts ...StartOption) *startOptions {
+	o := &startOptions{
+		parallel: 1,
+	}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return o
+}
+
+// Parallel makes Start run up to n start hooks at the same dependency depth concurrently, once every
+// instance of the previous depth has started. The hooks share a context derived from the one passed
+// to Start, cancelled as soon as one of them fails, and hooks still waiting for their turn are not run.
+// Lifecycle hooks start after every instance created before them. With n below 2 hooks run one by one.
+func Parallel(n int) StartOption {
+	return func(o *startOptions) {
+		o.parallel = n
+	}
+}
+
+// startInOrder runs the start hooks of the ordered instances one by one and returns the started
+// instances appended to started.
+func (d *Dino) startInOrder(ctx context.Context, ordered, started []*instance) ([]*instance, error) {
+	for _, inst := range ordered {
+		if hook := inst.startHook(); hook != nil {
+			if err := hook(ctx); err != nil {
+				return started, fmt.Errorf("start %s: %w", inst.name(), err)
+			}
+
+			started = append(started, inst)
+		}
+
+		d.options.lifecycle.markStarted(inst)
+	}
+
+	return started, nil
+}
+
+// startInParallel runs the start hooks of the pending instances depth by depth, up to limit at a time,
+// and returns the started instances appended to started. It stops at the first depth that fails.
+func (d *Dino) startInParallel(ctx context.Context, pending, started []*instance, limit int) ([]*instance, error) {
+	depths := d.options.startDepths(pending)
+	levels := make([][]*instance, slices.Max(depths)+1)
+
+	for idx, inst := range pending {
+		levels[depths[idx]] = append(levels[depths[idx]], inst)
+	}
+
+	var mutex sync.Mutex
+
+	for _, level := range levels {
+		group, groupCtx := errgroup.WithContext(ctx)
+		group.SetLimit(limit)
+
+		for _, inst := range level {
+			hook := inst.startHook()
+			if hook == nil {
+				d.options.lifecycle.markStarted(inst)
+
+				continue
+			}
+
+			group.Go(func() error {
+				// A sibling failed or the caller gave up while the hook was waiting for its turn
+				if err := context.Cause(groupCtx); err != nil {
+					return fmt.Errorf("start %s: %w", inst.name(), err)
+				}
+
+				if err := hook(groupCtx); err != nil {
+					return fmt.Errorf("start %s: %w", inst.name(), err)
+				}
+
+				mutex.Lock()
+				started = append(started, inst)
+				mutex.Unlock()
+
+				d.options.lifecycle.markStarted(inst)
+
+				return nil
+			})
+		}
+
+		if err := group.Wait(); err != nil {
+			return started, err
+		}
+	}
+
+	return started, nil
+}
+
+// startDepths returns the dependency depth of every instance: 0 for instances depending on none of
+// the others, one more than the deepest of their dependencies otherwise. Lifecycle hooks depend on
+// every instance created before them, their owner among others. The instances must be free of cycles.
+func (o *options) startDepths(instances []*instance) []int {
+	positions := make(map[RegistryKey][]int, len(instances))
+	for idx, inst := range instances {
+		positions[inst.key] = append(positions[inst.key], idx)
+	}
+
+	depths := make([]int, len(instances))
+	known := make([]bool, len(instances))
+
+	var depth func(idx int) int
+
+	depth = func(idx int) int {
+		if known[idx] {
+			return depths[idx]
+		}
+
+		var deps []int
+
+		if instances[idx].hook != nil {
+			for pos := range idx {
+				deps = append(deps, pos)
+			}
+		} else {
+			for _, dep := range o.dependencies(instances[idx].key) {
+				for _, pos := range positions[dep] {
+					if pos != idx {
+						deps = append(deps, pos)
+					}
+				}
+			}
+		}
+
+		for _, pos := range deps {
+			depths[idx] = max(depths[idx], depth(pos)+1)
+		}
+
+		known[idx] = true
+
+		return depths[idx]
+	}
+
+	for idx := range instances {
+		depth(idx)
+	}
+
+	return depths
+}
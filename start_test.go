@@ -0,0 +1,168 @@
+package dino_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/yuppyweb/dino"
+)
+
+// eventLog records lifecycle events from concurrently running hooks.
+type eventLog struct {
+	mutex  sync.Mutex
+	events []string
+}
+
+func (l *eventLog) add(event string) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.events = append(l.events, event)
+}
+
+func (l *eventLog) list() []string {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	return slices.Clone(l.events)
+}
+
+type SleepyStarter struct {
+	name  string
+	delay time.Duration
+	err   error
+	log   *eventLog
+}
+
+func (s *SleepyStarter) Start(ctx context.Context) error {
+	select {
+	case <-time.After(s.delay):
+		s.log.add("start " + s.name)
+
+		return s.err
+
+	case <-ctx.Done():
+		s.log.add("cancel " + s.name)
+
+		return ctx.Err()
+	}
+}
+
+func (s *SleepyStarter) Close() error {
+	s.log.add("close " + s.name)
+
+	return nil
+}
+
+type SleepyDependent struct {
+	log *eventLog
+}
+
+func (s *SleepyDependent) Start(context.Context) error {
+	s.log.add("start dependent")
+
+	return nil
+}
+
+func newSleepyContainer(t *testing.T, log *eventLog, starters ...*SleepyStarter) *dino.Dino {
+	t.Helper()
+
+	di := dino.New()
+
+	for _, starter := range starters {
+		starter.log = log
+
+		if err := di.Singleton(starter, starter.name); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	return di
+}
+
+func TestDino_StartParallel(t *testing.T) {
+	t.Parallel()
+
+	log := new(eventLog)
+	di := newSleepyContainer(t, log,
+		&SleepyStarter{name: "a", delay: 100 * time.Millisecond, err: nil, log: nil},
+		&SleepyStarter{name: "b", delay: 100 * time.Millisecond, err: nil, log: nil},
+		&SleepyStarter{name: "c", delay: 100 * time.Millisecond, err: nil, log: nil},
+		&SleepyStarter{name: "d", delay: 100 * time.Millisecond, err: nil, log: nil},
+	)
+
+	if err := di.Factory(func(in struct {
+		dino.In
+
+		Starter *SleepyStarter `inject:"d"`
+	},
+	) *SleepyDependent {
+		return &SleepyDependent{log: in.Starter.log}
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	begin := time.Now()
+
+	if err := di.Start(context.Background(), dino.Parallel(4)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// One by one, the four starters would take 400ms
+	if elapsed := time.Since(begin); elapsed >= 300*time.Millisecond {
+		t.Fatalf("expected the starters to run concurrently, took %v", elapsed)
+	}
+
+	events := log.list()
+	if len(events) != 5 || events[4] != "start dependent" {
+		t.Fatalf("expected the dependent to start after the starters, got %v", events)
+	}
+}
+
+func TestDino_StartParallelDependencyOrder(t *testing.T) {
+	t.Parallel()
+
+	di, log := newStartChain(t, nil)
+
+	if err := di.Start(context.Background(), dino.Parallel(4)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "start config,start pool,start database,start handler"
+	if strings.Join(log.events, ",") != expected {
+		t.Fatalf("expected %s, got %v", expected, log.events)
+	}
+}
+
+func TestDino_StartParallelCancelsSiblings(t *testing.T) {
+	t.Parallel()
+
+	log := new(eventLog)
+	di := newSleepyContainer(t, log,
+		&SleepyStarter{name: "quick", delay: 0, err: nil, log: nil},
+		&SleepyStarter{name: "failing", delay: 20 * time.Millisecond, err: errHookFailed, log: nil},
+		&SleepyStarter{name: "slow", delay: 5 * time.Second, err: nil, log: nil},
+		&SleepyStarter{name: "pending", delay: 0, err: nil, log: nil},
+	)
+
+	begin := time.Now()
+
+	if err := di.Start(context.Background(), dino.Parallel(2)); !errors.Is(err, errHookFailed) {
+		t.Fatalf("expected the start error, got %v", err)
+	}
+
+	if elapsed := time.Since(begin); elapsed >= time.Second {
+		t.Fatalf("expected the slow starter to be cancelled, took %v", elapsed)
+	}
+
+	// The pending starter waits for a free slot and is never run, the quick one is rolled back
+	expected := "start quick,start failing,cancel slow,close quick"
+	if strings.Join(log.list(), ",") != expected {
+		t.Fatalf("expected %s, got %v", expected, log.list())
+	}
+}
@@ -0,0 +1,257 @@
+package dino
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// slowestFactoriesLimit is the number of factories reported by ContainerStats.SlowestFactories.
+const slowestFactoriesLimit = 5
+
+// KeyStats counts the lookups of a single registry key.
+type KeyStats struct {
+	// Hits is the number of lookups that found a registered value or factory.
+	Hits uint64
+	// CacheHits is the number of hits that found an instance, registered or cached, instead of a factory.
+	CacheHits uint64
+	// Misses is the number of lookups that found nothing.
+	Misses uint64
+	// FactoryCalls is the number of times a factory registered for the key was called.
+	FactoryCalls uint64
+	// FactoryErrors is the number of factory calls that failed.
+	FactoryErrors uint64
+}
+
+// ContainerStats is a copy of the counters collected by a container created with WithStats, together with
+// a summary of its registry. It can be marshaled to JSON, leaving out the per-key counters.
+type ContainerStats struct {
+	KeyStats
+
+	// Registrations is the number of entries in the registry, factories and instances.
+	Registrations int
+	// CachedInstances is the number of registry entries holding the cached result of a factory.
+	CachedInstances int
+	// ResolutionErrors is the number of Inject, Invoke and Resolve calls that failed.
+	ResolutionErrors uint64
+	// CacheHitRatio is the share of lookups served by an instance without calling a factory,
+	// or zero before the first lookup.
+	CacheHitRatio float64
+	// SlowestFactories holds the five factories with the longest cumulative call duration, slowest first.
+	SlowestFactories []FactoryTiming
+	// Keys holds the counters of every key that was looked up.
+	Keys map[RegistryKey]KeyStats `json:"-"`
+}
+
+// FactoryTiming is the cumulative duration of the calls of the factory registered for a key.
+type FactoryTiming struct {
+	// Name is the type of the key, followed by its tag in brackets if it has one.
+	Name     string
+	Key      RegistryKey `json:"-"`
+	Calls    uint64
+	Duration time.Duration
+}
+
+// keyCounters holds the live counters of a registry key.
+type keyCounters struct {
+	hits          atomic.Uint64
+	cacheHits     atomic.Uint64
+	misses        atomic.Uint64
+	factoryCalls  atomic.Uint64
+	factoryErrors atomic.Uint64
+	factoryTime   atomic.Int64
+	builds        atomic.Uint64
+}
+
+// statsCollector counts lookups and factory calls. A nil collector ignores all updates,
+// so containers without stats only pay for a nil check.
+type statsCollector struct {
+	keys             sync.Map
+	totals           keyCounters
+	resolutionErrors atomic.Uint64
+	mutex            sync.Mutex
+	baseline         map[RegistryKey]uint64
+}
+
+// WithStats makes a container count registry hits, misses and factory calls per key,
+// reported by Dino.Stats.
+func WithStats() Option {
+	return func(o *options) {
+		o.stats = new(statsCollector)
+	}
+}
+
+// hit records a lookup that found a value for the key, an instance unless it found a factory.
+func (s *statsCollector) hit(key RegistryKey, factory bool) {
+	if s == nil {
+		return
+	}
+
+	counters := s.counters(key)
+
+	s.totals.hits.Add(1)
+	counters.hits.Add(1)
+
+	if !factory {
+		s.totals.cacheHits.Add(1)
+		counters.cacheHits.Add(1)
+	}
+}
+
+// miss records a lookup that found nothing for the key.
+func (s *statsCollector) miss(key RegistryKey) {
+	if s == nil {
+		return
+	}
+
+	s.totals.misses.Add(1)
+	s.counters(key).misses.Add(1)
+}
+
+// factoryCall records a call of the factory registered for the key.
+func (s *statsCollector) factoryCall(key RegistryKey) {
+	if s == nil {
+		return
+	}
+
+	s.totals.factoryCalls.Add(1)
+	s.counters(key).factoryCalls.Add(1)
+}
+
+// start returns the time a factory call starts at, or the zero time without stats,
+// so that containers without stats do not read the clock.
+func (s *statsCollector) start() time.Time {
+	if s == nil {
+		return time.Time{}
+	}
+
+	return time.Now()
+}
+
+// factoryDone records how long a call of the factory registered for the key took since start,
+// and whether it failed.
+func (s *statsCollector) factoryDone(key RegistryKey, start time.Time, err error) {
+	if s == nil {
+		return
+	}
+
+	counters := s.counters(key)
+	elapsed := int64(time.Since(start))
+
+	s.totals.factoryTime.Add(elapsed)
+	counters.factoryTime.Add(elapsed)
+
+	if err != nil {
+		s.totals.factoryErrors.Add(1)
+		counters.factoryErrors.Add(1)
+	}
+}
+
+// resolutionError records a failed Inject, Invoke or Resolve call.
+func (s *statsCollector) resolutionError() {
+	if s == nil {
+		return
+	}
+
+	s.resolutionErrors.Add(1)
+}
+
+// counters returns the live counters of the key, creating them on first use.
+func (s *statsCollector) counters(key RegistryKey) *keyCounters {
+	value, ok := s.keys.Load(key)
+	if !ok {
+		value, _ = s.keys.LoadOrStore(key, new(keyCounters))
+	}
+
+	counters, _ := value.(*keyCounters)
+
+	return counters
+}
+
+// snapshot copies the current counters.
+func (s *statsCollector) snapshot() ContainerStats {
+	stats := ContainerStats{
+		KeyStats:         KeyStats{},
+		Registrations:    0,
+		CachedInstances:  0,
+		ResolutionErrors: 0,
+		CacheHitRatio:    0,
+		SlowestFactories: nil,
+		Keys:             make(map[RegistryKey]KeyStats),
+	}
+
+	if s == nil {
+		return stats
+	}
+
+	stats.KeyStats = s.totals.load()
+	stats.ResolutionErrors = s.resolutionErrors.Load()
+
+	if lookups := stats.Hits + stats.Misses; lookups > 0 {
+		stats.CacheHitRatio = float64(stats.CacheHits) / float64(lookups)
+	}
+
+	s.keys.Range(func(key, value any) bool {
+		if counters, ok := value.(*keyCounters); ok {
+			regKey, _ := key.(RegistryKey)
+			stats.Keys[regKey] = counters.load()
+
+			if calls := counters.factoryCalls.Load(); calls > 0 {
+				stats.SlowestFactories = append(stats.SlowestFactories, FactoryTiming{
+					Name:     keyName(regKey),
+					Key:      regKey,
+					Calls:    calls,
+					Duration: time.Duration(counters.factoryTime.Load()),
+				})
+			}
+		}
+
+		return true
+	})
+
+	slices.SortFunc(stats.SlowestFactories, func(a, b FactoryTiming) int {
+		return cmp.Or(cmp.Compare(b.Duration, a.Duration), cmp.Compare(a.Name, b.Name))
+	})
+
+	if len(stats.SlowestFactories) > slowestFactoriesLimit {
+		stats.SlowestFactories = stats.SlowestFactories[:slowestFactoriesLimit]
+	}
+
+	return stats
+}
+
+// load copies the counters.
+func (c *keyCounters) load() KeyStats {
+	return KeyStats{
+		Hits:          c.hits.Load(),
+		CacheHits:     c.cacheHits.Load(),
+		Misses:        c.misses.Load(),
+		FactoryCalls:  c.factoryCalls.Load(),
+		FactoryErrors: c.factoryErrors.Load(),
+	}
+}
+
+// Stats returns a copy of the counters collected since the container was created, together with the
+// number of registrations and cached factory results in its registry. Without the WithStats option all
+// counters are zero and the registry is not inspected.
+func (d *Dino) Stats() ContainerStats {
+	stats := d.options.stats.snapshot()
+	if d.options.stats == nil {
+		return stats
+	}
+
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	for key, rv := range d.registry.All() {
+		stats.Registrations++
+
+		if _, ok := d.options.factory(key); ok && !isFactory(key, rv) {
+			stats.CachedInstances++
+		}
+	}
+
+	return stats
+}
@@ -0,0 +1,204 @@
+package dino_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/yuppyweb/dino"
+)
+
+type StatsService struct {
+	Port int
+}
+
+func TestDino_Stats(t *testing.T) {
+	t.Parallel()
+
+	di := dino.New(dino.WithStats())
+
+	if err := di.Singleton(8080); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err := di.Factory(func(port int) *StatsService {
+		return &StatsService{Port: port}
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The second lookup reuses the cached result and must not count as a factory call
+	for range 2 {
+		if _, err := di.Resolve(reflect.TypeFor[*StatsService]()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if _, err := di.Resolve(reflect.TypeFor[string](), "missing"); err == nil {
+		t.Fatalf("expected an error resolving an unregistered type")
+	}
+
+	stats := di.Stats()
+
+	expected := map[dino.RegistryKey]dino.KeyStats{
+		{Tag: "", Type: reflect.TypeFor[*StatsService](), Scope: ""}: {
+			Hits: 2, CacheHits: 1, Misses: 0, FactoryCalls: 1, FactoryErrors: 0,
+		},
+		{Tag: "", Type: reflect.TypeFor[int](), Scope: ""}: {
+			Hits: 1, CacheHits: 1, Misses: 0, FactoryCalls: 0, FactoryErrors: 0,
+		},
+		{Tag: "missing", Type: reflect.TypeFor[string](), Scope: ""}: {
+			Hits: 0, CacheHits: 0, Misses: 1, FactoryCalls: 0, FactoryErrors: 0,
+		},
+	}
+
+	if !reflect.DeepEqual(stats.Keys, expected) {
+		t.Fatalf("expected key stats %v, got %v", expected, stats.Keys)
+	}
+
+	totals := dino.KeyStats{Hits: 3, CacheHits: 2, Misses: 1, FactoryCalls: 1, FactoryErrors: 0}
+	if stats.KeyStats != totals {
+		t.Fatalf("expected totals %+v, got %+v", totals, stats.KeyStats)
+	}
+
+	// The returned stats are a copy
+	stats.Keys[dino.RegistryKey{Tag: "copy", Type: reflect.TypeFor[int](), Scope: ""}] = dino.KeyStats{}
+	if len(di.Stats().Keys) != len(expected) {
+		t.Fatalf("expected the container stats to be unaffected by changes to the copy")
+	}
+}
+
+func TestDino_StatsDisabled(t *testing.T) {
+	t.Parallel()
+
+	di := dino.New()
+
+	if err := di.Singleton(1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := di.Resolve(reflect.TypeFor[int]()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	stats := di.Stats()
+	if stats.KeyStats != (dino.KeyStats{}) || len(stats.Keys) != 0 {
+		t.Fatalf("expected empty stats, got %+v", stats)
+	}
+}
+
+func TestDino_StatsConcurrent(t *testing.T) {
+	t.Parallel()
+
+	di := dino.New(dino.WithStats())
+
+	if err := di.Singleton(1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var wg sync.WaitGroup
+
+	for range 16 {
+		wg.Go(func() {
+			for range 10 {
+				if _, err := di.Resolve(reflect.TypeFor[int]()); err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+
+				_ = di.Stats()
+			}
+		})
+	}
+
+	wg.Wait()
+
+	if hits := di.Stats().Hits; hits != 160 {
+		t.Fatalf("expected 160 hits, got %d", hits)
+	}
+}
+
+type StatsSlow struct{}
+
+type StatsFast struct{}
+
+type StatsFailing struct{}
+
+func TestDino_StatsSummary(t *testing.T) {
+	t.Parallel()
+
+	di := dino.New(dino.WithStats())
+
+	if err := di.Singleton(8080); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	factories := []any{
+		func(int) *StatsService { return &StatsService{} },
+		func() *StatsFast { return &StatsFast{} },
+		func(*StatsFast) *StatsSlow {
+			time.Sleep(20 * time.Millisecond)
+
+			return &StatsSlow{}
+		},
+		func() (*StatsFailing, error) { return nil, errHookFailed },
+	}
+
+	for _, factory := range factories {
+		if err := di.Factory(factory); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	for range 2 {
+		if _, err := di.Invoke(func(*StatsSlow, *StatsService) {}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if _, err := di.Resolve(reflect.TypeFor[*StatsFailing]()); err == nil {
+		t.Fatal("expected the failing factory to return an error")
+	}
+
+	stats := di.Stats()
+
+	if stats.Registrations != 5 || stats.CachedInstances != 3 {
+		t.Errorf("expected 5 registrations and 3 cached instances, got %d and %d",
+			stats.Registrations, stats.CachedInstances)
+	}
+
+	if stats.FactoryCalls != 4 || stats.FactoryErrors != 1 || stats.ResolutionErrors != 1 {
+		t.Errorf("expected 4 factory calls, 1 factory error and 1 resolution error, got %+v", stats)
+	}
+
+	// 7 lookups: the first invoke calls 3 factories and hits the port, the second hits 2 cached instances,
+	// then the failing factory is called
+	if stats.CacheHitRatio != 3.0/7.0 {
+		t.Errorf("expected a cache hit ratio of 3/7, got %v", stats.CacheHitRatio)
+	}
+
+	if len(stats.SlowestFactories) != 4 {
+		t.Fatalf("expected every called factory to be timed, got %v", stats.SlowestFactories)
+	}
+
+	slowest := stats.SlowestFactories[0]
+	if slowest.Name != "*dino_test.StatsSlow" || slowest.Calls != 1 || slowest.Duration < 20*time.Millisecond {
+		t.Errorf("expected the slow factory to come first, got %+v", slowest)
+	}
+
+	data, err := json.Marshal(stats)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var decoded dino.ContainerStats
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if decoded.Registrations != stats.Registrations || decoded.SlowestFactories[0].Name != slowest.Name {
+		t.Errorf("expected the stats to survive a JSON round trip, got %s", data)
+	}
+}
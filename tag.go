@@ -0,0 +1,61 @@
+package dino
+
+import (
+	"strings"
+)
+
+// envTagPrefix marks an "inject" struct tag naming an environment variable instead of a registry tag.
+const envTagPrefix = "env:"
+
+// qualifierTagPrefix marks an "inject" struct tag naming a qualifier registered with WithQualifierName.
+const qualifierTagPrefix = "q:"
+
+// injectTag is the parsed value of an "inject" struct tag: a registry tag name, an environment
+// variable after the env: prefix or a qualifier name after the q: prefix, followed by comma-separated
+// options, e.g. `inject:"primary,optional"`, `inject:"env:PORT,optional"` or `inject:"q:mypkg.Primary"`.
+type injectTag struct {
+	name      string
+	env       string
+	qualifier string
+	optional  bool
+	skip      bool
+}
+
+// parseInjectTag parses the value of an "inject" struct tag. Unknown options are ignored.
+func parseInjectTag(tag string) injectTag {
+	name, opts, found := strings.Cut(tag, ",")
+
+	parsed := injectTag{
+		name:      name,
+		env:       "",
+		qualifier: "",
+		optional:  false,
+		skip:      tag == "-",
+	}
+
+	if parsed.skip {
+		parsed.name = ""
+	}
+
+	if env, ok := strings.CutPrefix(name, envTagPrefix); ok {
+		parsed.name = ""
+		parsed.env = env
+	}
+
+	if qualifier, ok := strings.CutPrefix(name, qualifierTagPrefix); ok {
+		parsed.name = ""
+		parsed.qualifier = qualifier
+	}
+
+	if !found {
+		return parsed
+	}
+
+	for opt := range strings.SplitSeq(opts, ",") {
+		if strings.TrimSpace(opt) == "optional" {
+			parsed.optional = true
+		}
+	}
+
+	return parsed
+}
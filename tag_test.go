@@ -0,0 +1,89 @@
+package dino_test
+
+import (
+	"testing"
+
+	"github.com/yuppyweb/dino"
+)
+
+func TestTag_ParseInjectTag(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		tag      string
+		expected string
+		optional bool
+	}{
+		{
+			name:     "Empty tag",
+			tag:      "",
+			expected: "",
+			optional: false,
+		},
+		{
+			name:     "Name only",
+			tag:      "primary",
+			expected: "primary",
+			optional: false,
+		},
+		{
+			name:     "Name with optional",
+			tag:      "primary,optional",
+			expected: "primary",
+			optional: true,
+		},
+		{
+			name:     "Optional without name",
+			tag:      ",optional",
+			expected: "",
+			optional: true,
+		},
+		{
+			name:     "Spaces around options",
+			tag:      "cache, optional",
+			expected: "cache",
+			optional: true,
+		},
+		{
+			name:     "Environment variable has no registry name",
+			tag:      "env:PORT,optional",
+			expected: "",
+			optional: true,
+		},
+		{
+			name:     "Qualifier has no registry name",
+			tag:      "q:mypkg.Primary,optional",
+			expected: "",
+			optional: true,
+		},
+		{
+			name:     "Skipped field has no registry name",
+			tag:      "-",
+			expected: "",
+			optional: false,
+		},
+		{
+			name:     "Unknown option is ignored",
+			tag:      "cache,unknown",
+			expected: "cache",
+			optional: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			name, optional := dino.MockParseInjectTag(tc.tag)
+
+			if name != tc.expected {
+				t.Errorf("expected name '%s', got '%s'", tc.expected, name)
+			}
+
+			if optional != tc.optional {
+				t.Errorf("expected optional to be %v, got %v", tc.optional, optional)
+			}
+		})
+	}
+}
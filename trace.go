@@ -0,0 +1,107 @@
+package dino
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+// TraceHook receives an event for every key the container resolves, e.g. to report resolutions to an APM.
+// ResolveStart is called when the resolution of the key begins and the returned function when it ends,
+// with the error it failed with, if any. Resolutions of dependencies begin and end while the resolution
+// of their dependent is in progress, so the events nest like the dependency graph. A key missing from
+// the registry ends with ErrValueNotFound even if the dependency is then auto-created.
+type TraceHook interface {
+	ResolveStart(key RegistryKey) func(err error)
+}
+
+// WithTrace makes the container report every resolution to the hook.
+func WithTrace(hook TraceHook) Option {
+	return func(o *options) {
+		o.trace = hook
+	}
+}
+
+// Span is a single resolution recorded by a CollectingTracer.
+type Span struct {
+	Key      RegistryKey
+	Start    time.Time
+	Duration time.Duration
+	Err      error
+	// Children are the resolutions of dependencies made while this one was in progress.
+	Children []*Span
+}
+
+// CollectingTracer is a TraceHook recording resolutions as a tree of spans, for tests and debugging.
+// Spans are nested by the order their resolutions begin and end, so resolutions running concurrently
+// may be recorded as children of each other.
+type CollectingTracer struct {
+	mutex sync.Mutex
+	roots []*Span
+	open  []*Span
+}
+
+// NewCollectingTracer creates a tracer with no recorded spans.
+func NewCollectingTracer() *CollectingTracer {
+	return &CollectingTracer{
+		mutex: sync.Mutex{},
+		roots: nil,
+		open:  nil,
+	}
+}
+
+// ResolveStart records the beginning of the resolution of the key, as a child of the resolution
+// in progress, if any.
+func (t *CollectingTracer) ResolveStart(key RegistryKey) func(err error) {
+	span := &Span{
+		Key:      key,
+		Start:    time.Now(),
+		Duration: 0,
+		Err:      nil,
+		Children: nil,
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if len(t.open) > 0 {
+		parent := t.open[len(t.open)-1]
+		parent.Children = append(parent.Children, span)
+	} else {
+		t.roots = append(t.roots, span)
+	}
+
+	t.open = append(t.open, span)
+
+	return func(err error) {
+		t.mutex.Lock()
+		defer t.mutex.Unlock()
+
+		span.Duration = time.Since(span.Start)
+		span.Err = err
+
+		if idx := slices.Index(t.open, span); idx >= 0 {
+			t.open = slices.Delete(t.open, idx, idx+1)
+		}
+	}
+}
+
+// Spans returns the top-level resolutions recorded so far, in the order they began.
+func (t *CollectingTracer) Spans() []*Span {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	return slices.Clone(t.roots)
+}
+
+// Reset discards the recorded spans.
+func (t *CollectingTracer) Reset() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.roots = nil
+	t.open = nil
+}
+
+// Ensure CollectingTracer implements the TraceHook interface.
+var _ TraceHook = (*CollectingTracer)(nil)
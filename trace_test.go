@@ -0,0 +1,119 @@
+package dino_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/yuppyweb/dino"
+)
+
+type TracedRepository struct{}
+
+type TracedService struct{}
+
+type TracedHandler struct{}
+
+func newTracedChain(t *testing.T, repoErr error) (*dino.Dino, *dino.CollectingTracer) {
+	t.Helper()
+
+	tracer := dino.NewCollectingTracer()
+	di := dino.New(dino.WithTrace(tracer))
+
+	factories := []any{
+		func() (*TracedRepository, error) { return &TracedRepository{}, repoErr },
+		func(*TracedRepository) *TracedService { return &TracedService{} },
+		func(*TracedService) *TracedHandler { return &TracedHandler{} },
+	}
+
+	for _, factory := range factories {
+		if err := di.Factory(factory); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	return di, tracer
+}
+
+// assertSpanChain checks that the spans form a single chain of the given types, each the only child of the previous.
+func assertSpanChain(t *testing.T, spans []*dino.Span, types ...reflect.Type) []*dino.Span {
+	t.Helper()
+
+	var chain []*dino.Span
+
+	for _, typ := range types {
+		if len(spans) != 1 {
+			t.Fatalf("expected a single span for %s, got %d", typ, len(spans))
+		}
+
+		if spans[0].Key.Type != typ {
+			t.Fatalf("expected span for %s, got %s", typ, spans[0].Key.Type)
+		}
+
+		chain = append(chain, spans[0])
+		spans = spans[0].Children
+	}
+
+	if len(spans) != 0 {
+		t.Fatalf("expected no spans below %s, got %d", types[len(types)-1], len(spans))
+	}
+
+	return chain
+}
+
+func TestCollectingTracer_Chain(t *testing.T) {
+	t.Parallel()
+
+	di, tracer := newTracedChain(t, nil)
+
+	if _, err := di.Resolve(reflect.TypeFor[*TracedHandler]()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	chain := assertSpanChain(t, tracer.Spans(),
+		reflect.TypeFor[*TracedHandler](),
+		reflect.TypeFor[*TracedService](),
+		reflect.TypeFor[*TracedRepository](),
+	)
+
+	for idx, span := range chain {
+		if span.Err != nil {
+			t.Errorf("unexpected error on %s: %v", span.Key.Type, span.Err)
+		}
+
+		if idx > 0 && span.Duration > chain[idx-1].Duration {
+			t.Errorf("expected %s to take no longer than its dependent", span.Key.Type)
+		}
+	}
+
+	// Cached instances are resolved without calling their dependencies again
+	tracer.Reset()
+
+	if _, err := di.Resolve(reflect.TypeFor[*TracedHandler]()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	assertSpanChain(t, tracer.Spans(), reflect.TypeFor[*TracedHandler]())
+}
+
+func TestCollectingTracer_Error(t *testing.T) {
+	t.Parallel()
+
+	di, tracer := newTracedChain(t, errHookFailed)
+
+	if _, err := di.Resolve(reflect.TypeFor[*TracedHandler]()); !errors.Is(err, errHookFailed) {
+		t.Fatalf("expected factory error, got %v", err)
+	}
+
+	chain := assertSpanChain(t, tracer.Spans(),
+		reflect.TypeFor[*TracedHandler](),
+		reflect.TypeFor[*TracedService](),
+		reflect.TypeFor[*TracedRepository](),
+	)
+
+	for _, span := range chain {
+		if !errors.Is(span.Err, errHookFailed) {
+			t.Errorf("expected factory error on %s, got %v", span.Key.Type, span.Err)
+		}
+	}
+}
@@ -0,0 +1,240 @@
+package dino
+
+import (
+	"errors"
+	"reflect"
+	"time"
+)
+
+// ErrTTLUnsupported is returned when an expiring registration is requested from a registry
+// that does not implement ExpiringRegistry.
+var ErrTTLUnsupported = errors.New("registry does not support expiring entries")
+
+// Clock tells the current time. Registries use it to expire entries, tests can replace it
+// to advance time deterministically.
+type Clock interface {
+	Now() time.Time
+}
+
+// systemClock is the Clock backed by time.Now.
+type systemClock struct{}
+
+// Now returns the current local time.
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// ExpiringRegistry is a Registry able to store values that expire after a duration.
+type ExpiringRegistry interface {
+	Registry
+	RegisterTTL(key RegistryKey, rv reflect.Value, ttl time.Duration) error
+}
+
+// ttlEntry is a value stored in a SyncMapRegistry until it expires. The value it replaced, usually
+// the factory function producing it, is kept as fallback and restored once the entry expires.
+type ttlEntry struct {
+	value    reflect.Value
+	expires  time.Time
+	fallback reflect.Value
+}
+
+// SetClock replaces the clock used to expire entries, time.Now by default.
+// It must be called before the registry is used concurrently.
+func (r *SyncMapRegistry) SetClock(clock Clock) {
+	r.clock = clock
+}
+
+// now returns the current time of the registry clock.
+func (r *SyncMapRegistry) now() time.Time {
+	if r.clock == nil {
+		return systemClock{}.Now()
+	}
+
+	return r.clock.Now()
+}
+
+// RegisterTTL stores a value in the registry with the specified key until ttl elapses.
+// Once expired, Find no longer returns the value: the value it replaced, e.g. the factory function
+// that produced it, is registered again, or the key is deleted if nothing was registered before.
+func (r *SyncMapRegistry) RegisterTTL(key RegistryKey, rv reflect.Value, ttl time.Duration) error {
+	if err := validateEntry(key, rv); err != nil {
+		return err
+	}
+
+	r.storeTTL(key, rv, ttl)
+	r.observers.notifyRegister(key, rv)
+
+	return nil
+}
+
+// storeTTL writes an expiring entry under the key, keeping the value it replaces as fallback.
+func (r *SyncMapRegistry) storeTTL(key RegistryKey, rv reflect.Value, ttl time.Duration) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	entry := &ttlEntry{
+		value:    rv,
+		expires:  r.now().Add(ttl),
+		fallback: reflect.Value{},
+	}
+
+	switch prev := r.loadStored(key).(type) {
+	case reflect.Value:
+		entry.fallback = prev
+
+	case *ttlEntry:
+		entry.fallback = prev.fallback
+	}
+
+	r.sm.Store(key, entry)
+	r.index.add(key)
+}
+
+// loadStored returns the raw value stored under the key, or nil.
+func (r *SyncMapRegistry) loadStored(key RegistryKey) any {
+	value, _ := r.sm.Load(key)
+
+	return value
+}
+
+// value returns the live value of a stored entry, expiring it if needed.
+// It returns ErrValueNotFound for an expired entry without fallback and ErrInvalidValue for malformed ones.
+func (r *SyncMapRegistry) value(key RegistryKey, stored any) (reflect.Value, error) {
+	switch entry := stored.(type) {
+	case reflect.Value:
+		return entry, nil
+
+	case *ttlEntry:
+		if r.now().Before(entry.expires) {
+			return entry.value, nil
+		}
+
+		return r.expire(key, entry)
+
+	default:
+		return reflect.Value{}, ErrInvalidValue
+	}
+}
+
+// expire replaces an expired entry with its fallback, or deletes it if it has none.
+func (r *SyncMapRegistry) expire(key RegistryKey, entry *ttlEntry) (reflect.Value, error) {
+	if entry.fallback.IsValid() {
+		if r.sm.CompareAndSwap(key, entry, entry.fallback) {
+			return entry.fallback, nil
+		}
+	} else if r.deleteExpired(key, entry) {
+		r.observers.notifyDelete(key, entry.value)
+
+		return reflect.Value{}, ErrValueNotFound
+	}
+
+	// The entry was replaced concurrently, use whatever is stored now
+	stored, ok := r.sm.Load(key)
+	if !ok {
+		return reflect.Value{}, ErrValueNotFound
+	}
+
+	return r.value(key, stored)
+}
+
+// deleteExpired removes the expired entry unless it was replaced concurrently.
+func (r *SyncMapRegistry) deleteExpired(key RegistryKey, entry *ttlEntry) bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if !r.sm.CompareAndDelete(key, entry) {
+		return false
+	}
+
+	r.index.remove(key)
+
+	return true
+}
+
+// storedValue unwraps a stored entry without checking its expiry.
+func storedValue(stored any) reflect.Value {
+	switch entry := stored.(type) {
+	case reflect.Value:
+		return entry
+
+	case *ttlEntry:
+		return entry.value
+
+	default:
+		return reflect.Value{}
+	}
+}
+
+// Ensure SyncMapRegistry implements the ExpiringRegistry interface.
+var _ ExpiringRegistry = (*SyncMapRegistry)(nil)
+
+// registerOptions holds the settings of a FactoryWith or SingletonWith registration.
+type registerOptions struct {
+	tags      []string
+	ttl       time.Duration
+	qualifier any
+	scoped    bool
+}
+
+// RegisterOption configures a FactoryWith or SingletonWith registration.
+type RegisterOption func(*registerOptions)
+
+// newRegisterOptions builds the settings from the provided options, starting from the defaults.
+func newRegisterOptions(opts ...RegisterOption) *registerOptions {
+	o := &registerOptions{
+		tags:      nil,
+		ttl:       0,
+		qualifier: nil,
+		scoped:    false,
+	}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return o
+}
+
+// Tags registers the factory under the specified tags instead of the empty tag.
+func Tags(tags ...string) RegisterOption {
+	return func(o *registerOptions) {
+		o.tags = append(o.tags, tags...)
+	}
+}
+
+// TTL makes the results of the factory expire after the specified duration. Once a cached result
+// expires, the next resolution calls the factory again. Shutdown only closes the latest result, and
+// only until it expires. The container registry must implement ExpiringRegistry, which the default
+// SyncMapRegistry does.
+func TTL(ttl time.Duration) RegisterOption {
+	return func(o *registerOptions) {
+		o.ttl = ttl
+	}
+}
+
+// lifetime returns the duration after which cached results for the key expire, if any.
+func (o *options) lifetime(key RegistryKey) (time.Duration, bool) {
+	value, ok := o.ttls.Load(key)
+	if !ok {
+		if o.parent != nil {
+			return o.parent.lifetime(key)
+		}
+
+		return 0, false
+	}
+
+	ttl, ok := value.(time.Duration)
+
+	return ttl, ok
+}
+
+// setLifetime records how long cached results under the keys live, or forgets it for a zero ttl.
+func (o *options) setLifetime(keys []RegistryKey, ttl time.Duration) {
+	for _, key := range keys {
+		if ttl > 0 {
+			o.ttls.Store(key, ttl)
+		} else {
+			o.ttls.Delete(key)
+		}
+	}
+}
@@ -0,0 +1,295 @@
+package dino_test
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/yuppyweb/dino"
+)
+
+type FakeClock struct {
+	mutex sync.Mutex
+	now   time.Time
+}
+
+func NewFakeClock() *FakeClock {
+	return &FakeClock{
+		mutex: sync.Mutex{},
+		now:   time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func (c *FakeClock) Now() time.Time {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.now
+}
+
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.now = c.now.Add(d)
+}
+
+type TTLToken struct {
+	Value int
+}
+
+func TestTTL_RegistryExpiresToFallback(t *testing.T) {
+	t.Parallel()
+
+	clock := NewFakeClock()
+
+	registry := new(dino.SyncMapRegistry)
+	registry.SetClock(clock)
+
+	key := dino.RegistryKey{
+		Tag:   "",
+		Type:  reflect.TypeFor[int](),
+		Scope: "",
+	}
+
+	if err := registry.Register(key, reflect.ValueOf(1)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := registry.RegisterTTL(key, reflect.ValueOf(2), time.Minute); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	clock.Advance(59 * time.Second)
+
+	rv, err := registry.Find(key)
+	if err != nil || rv.Int() != 2 {
+		t.Fatalf("expected unexpired value 2, got %v (%v)", rv, err)
+	}
+
+	clock.Advance(time.Second)
+
+	rv, err = registry.Find(key)
+	if err != nil || rv.Int() != 1 {
+		t.Fatalf("expected expired value to fall back to 1, got %v (%v)", rv, err)
+	}
+}
+
+func TestTTL_RegistryExpiresWithoutFallback(t *testing.T) {
+	t.Parallel()
+
+	clock := NewFakeClock()
+
+	registry := new(dino.SyncMapRegistry)
+	registry.SetClock(clock)
+
+	var deleted []dino.RegistryKey
+
+	registry.OnDelete(func(key dino.RegistryKey, _ reflect.Value) {
+		deleted = append(deleted, key)
+	})
+
+	key := dino.RegistryKey{
+		Tag:   "token",
+		Type:  reflect.TypeFor[int](),
+		Scope: "",
+	}
+
+	if err := registry.RegisterTTL(key, reflect.ValueOf(1), time.Minute); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if registry.Len() != 1 {
+		t.Fatalf("expected 1 entry before expiry, got %d", registry.Len())
+	}
+
+	clock.Advance(time.Minute)
+
+	if _, err := registry.Find(key); !errors.Is(err, dino.ErrValueNotFound) {
+		t.Fatalf("expected ErrValueNotFound after expiry, got %v", err)
+	}
+
+	if keys := registry.FindByType(key.Type); len(keys) != 0 {
+		t.Fatalf("expected expired key to be deleted lazily, got %v", keys)
+	}
+
+	if len(deleted) != 1 || deleted[0] != key {
+		t.Fatalf("expected expiry to be observed as a deletion, got %v", deleted)
+	}
+
+	if err := registry.RegisterTTL(key, reflect.ValueOf("x"), time.Minute); !errors.Is(err, dino.ErrTypeMismatch) {
+		t.Fatalf("expected ErrTypeMismatch, got %v", err)
+	}
+}
+
+func TestTTL_FactoryRunsAgainAfterExpiry(t *testing.T) {
+	t.Parallel()
+
+	clock := NewFakeClock()
+
+	registry := new(dino.SyncMapRegistry)
+	registry.SetClock(clock)
+
+	di := dino.New(dino.WithRegistry(registry))
+
+	calls := 0
+
+	err := di.FactoryWith(func() *TTLToken {
+		calls++
+
+		return &TTLToken{Value: calls}
+	}, dino.Tags("auth"), dino.TTL(5*time.Minute))
+	if err != nil {
+		t.Fatalf("unexpected error during factory registration: %v", err)
+	}
+
+	resolve := func() *TTLToken {
+		t.Helper()
+
+		val, err := di.Resolve(reflect.TypeFor[*TTLToken](), "auth")
+		if err != nil {
+			t.Fatalf("unexpected error from Resolve: %v", err)
+		}
+
+		token, ok := val.(*TTLToken)
+		if !ok {
+			t.Fatalf("expected *TTLToken, got %T", val)
+		}
+
+		return token
+	}
+
+	first := resolve()
+
+	clock.Advance(4 * time.Minute)
+
+	if resolve() != first || calls != 1 {
+		t.Fatalf("expected cached token before expiry, got %d calls", calls)
+	}
+
+	clock.Advance(time.Minute)
+
+	if token := resolve(); token == first || token.Value != 2 {
+		t.Fatalf("expected fresh token after expiry, got %v", token)
+	}
+
+	if resolve().Value != 2 || calls != 2 {
+		t.Fatalf("expected fresh token to be cached again, got %d calls", calls)
+	}
+}
+
+func TestTTL_FactoryWithoutExpiringRegistry(t *testing.T) {
+	t.Parallel()
+
+	di := dino.New(dino.WithRegistry(dino.NewMapRegistry()))
+
+	err := di.FactoryWith(func() *TTLToken { return &TTLToken{Value: 1} }, dino.TTL(time.Minute))
+	if !errors.Is(err, dino.ErrTTLUnsupported) {
+		t.Fatalf("expected ErrTTLUnsupported, got %v", err)
+	}
+}
+
+func TestTTL_FactoryReregisteredWithoutTTL(t *testing.T) {
+	t.Parallel()
+
+	clock := NewFakeClock()
+
+	registry := new(dino.SyncMapRegistry)
+	registry.SetClock(clock)
+
+	di := dino.New(dino.WithRegistry(registry))
+
+	calls := 0
+	factory := func() *TTLToken {
+		calls++
+
+		return &TTLToken{Value: calls}
+	}
+
+	if err := di.FactoryWith(factory, dino.TTL(time.Minute)); err != nil {
+		t.Fatalf("unexpected error during factory registration: %v", err)
+	}
+
+	if err := di.Factory(factory); err != nil {
+		t.Fatalf("unexpected error during factory registration: %v", err)
+	}
+
+	for range 2 {
+		if _, err := di.Resolve(reflect.TypeFor[*TTLToken]()); err != nil {
+			t.Fatalf("unexpected error from Resolve: %v", err)
+		}
+
+		clock.Advance(time.Hour)
+	}
+
+	if calls != 1 {
+		t.Fatalf("expected result of factory registered without TTL not to expire, got %d calls", calls)
+	}
+}
+
+type TTLConn struct {
+	closed bool
+}
+
+func (c *TTLConn) Close() error {
+	c.closed = true
+
+	return nil
+}
+
+func TestTTL_ShutdownClosesLatestResult(t *testing.T) {
+	t.Parallel()
+
+	clock := NewFakeClock()
+
+	registry := new(dino.SyncMapRegistry)
+	registry.SetClock(clock)
+
+	di := dino.New(dino.WithRegistry(registry))
+
+	if err := di.FactoryWith(func() *TTLConn { return &TTLConn{closed: false} }, dino.TTL(5*time.Minute)); err != nil {
+		t.Fatalf("unexpected error during factory registration: %v", err)
+	}
+
+	resolve := func() *TTLConn {
+		t.Helper()
+
+		conn, err := dino.Resolve[*TTLConn](di)
+		if err != nil {
+			t.Fatalf("unexpected error from Resolve: %v", err)
+		}
+
+		return conn
+	}
+
+	first := resolve()
+
+	clock.Advance(5 * time.Minute)
+
+	second := resolve()
+
+	if err := di.Shutdown(t.Context()); err != nil {
+		t.Fatalf("unexpected error from Shutdown: %v", err)
+	}
+
+	if first.closed || !second.closed {
+		t.Fatalf("expected only the refreshed result to be closed, got %v and %v", first.closed, second.closed)
+	}
+
+	clock.Advance(5 * time.Minute)
+
+	third := resolve()
+
+	// An expired result that was not refreshed is not closed either
+	clock.Advance(5 * time.Minute)
+
+	if err := di.Shutdown(t.Context()); err != nil {
+		t.Fatalf("unexpected error from Shutdown: %v", err)
+	}
+
+	if third.closed {
+		t.Fatal("expected the expired result not to be closed")
+	}
+}
@@ -0,0 +1,96 @@
+package dino
+
+import "slices"
+
+// build records that Build resolved the key eagerly. Such resolutions do not count as usage.
+func (s *statsCollector) build(key RegistryKey) {
+	if s == nil {
+		return
+	}
+
+	s.counters(key).builds.Add(1)
+}
+
+// usage returns how often every key was looked up, leaving out the eager resolutions of Build.
+func (s *statsCollector) usage() map[RegistryKey]uint64 {
+	usage := make(map[RegistryKey]uint64)
+
+	s.keys.Range(func(key, value any) bool {
+		if counters, ok := value.(*keyCounters); ok {
+			regKey, _ := key.(RegistryKey)
+			usage[regKey] = counters.hits.Load() - counters.builds.Load()
+		}
+
+		return true
+	})
+
+	return usage
+}
+
+// markBaseline makes the current usage the baseline unused keys are reported against.
+func (s *statsCollector) markBaseline() {
+	if s == nil {
+		return
+	}
+
+	usage := s.usage()
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.baseline = usage
+}
+
+// usedSinceBaseline returns the keys looked up since the baseline, or since the collector was created.
+func (s *statsCollector) usedSinceBaseline() map[RegistryKey]struct{} {
+	usage := s.usage()
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	used := make(map[RegistryKey]struct{})
+
+	for key, count := range usage {
+		if count > s.baseline[key] {
+			used[key] = struct{}{}
+		}
+	}
+
+	return used
+}
+
+// MarkUsageBaseline makes UnusedSince report only the keys not resolved after this call, e.g. once the
+// application is started. It requires the WithStats option.
+func (d *Dino) MarkUsageBaseline() {
+	d.options.stats.markBaseline()
+}
+
+// UnusedSince returns the registered keys that were never resolved since the container was created, or
+// since the last MarkUsageBaseline call, sorted by type name and tag, e.g. to find dead bindings in a test
+// exercising the whole application. A key counts as resolved when it is injected, passed to a function,
+// resolved directly or as a dependency of a factory; the eager resolutions of Build and Start and the
+// factory results the container caches do not count. The markers are left out of the report, e.g. keys
+// only used outside of the container. Usage is counted by the WithStats option: without it, nothing is
+// reported.
+func (d *Dino) UnusedSince(markers ...RegistryKey) []RegistryKey {
+	if d.options.stats == nil {
+		return nil
+	}
+
+	used := d.options.stats.usedSinceBaseline()
+
+	var keys []RegistryKey
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	for key := range d.registry.All() {
+		if _, ok := used[key]; !ok && !slices.Contains(markers, key) {
+			keys = append(keys, key)
+		}
+	}
+
+	sortKeys(keys)
+
+	return keys
+}
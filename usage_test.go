@@ -0,0 +1,99 @@
+package dino_test
+
+import (
+	"context"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/yuppyweb/dino"
+)
+
+type UsageConfig struct{}
+
+type UsageService struct{}
+
+type UsageLegacy struct{}
+
+func newUsageContainer(t *testing.T) *dino.Dino {
+	t.Helper()
+
+	di := dino.New(dino.WithStats())
+
+	if err := di.Singleton(&UsageConfig{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := di.Factory(func(*UsageConfig) *UsageService { return &UsageService{} }); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := di.Factory(func() *UsageLegacy { return &UsageLegacy{} }); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	return di
+}
+
+func TestDino_UnusedSince(t *testing.T) {
+	t.Parallel()
+
+	di := newUsageContainer(t)
+
+	// Building resolves every factory eagerly, which does not count as usage
+	if err := di.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := di.Invoke(func(*UsageService) {}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	unused := di.UnusedSince()
+
+	expected := []dino.RegistryKey{orderKey[*UsageLegacy]()}
+	if !slices.Equal(unused, expected) {
+		t.Errorf("expected %v to be unused, got %v", expected, unused)
+	}
+
+	if unused := di.UnusedSince(orderKey[*UsageLegacy]()); len(unused) != 0 {
+		t.Errorf("expected markers to be left out, got %v", unused)
+	}
+}
+
+func TestDino_MarkUsageBaseline(t *testing.T) {
+	t.Parallel()
+
+	di := newUsageContainer(t)
+
+	if _, err := di.Resolve(reflect.TypeFor[*UsageService]()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	di.MarkUsageBaseline()
+
+	if _, err := di.Resolve(reflect.TypeFor[*UsageLegacy]()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	unused := di.UnusedSince()
+
+	expected := []dino.RegistryKey{orderKey[*UsageConfig](), orderKey[*UsageService]()}
+	if !slices.Equal(unused, expected) {
+		t.Errorf("expected %v to be unused since the baseline, got %v", expected, unused)
+	}
+}
+
+func TestDino_UnusedSinceWithoutStats(t *testing.T) {
+	t.Parallel()
+
+	di := dino.New()
+
+	if err := di.Singleton(&UsageConfig{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if unused := di.UnusedSince(); unused != nil {
+		t.Errorf("expected nothing to be reported without stats, got %v", unused)
+	}
+}